@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/PipeOpsHQ/agent-sdk-go/llm"
 	"github.com/PipeOpsHQ/agent-sdk-go/observe"
 	"github.com/PipeOpsHQ/agent-sdk-go/state"
+	"github.com/PipeOpsHQ/agent-sdk-go/storage"
 	"github.com/PipeOpsHQ/agent-sdk-go/tools"
 	"github.com/PipeOpsHQ/agent-sdk-go/types"
 	"github.com/google/uuid"
@@ -20,6 +24,7 @@ import (
 
 type Agent struct {
 	provider            llm.Provider
+	model               string
 	store               state.Store
 	executionMode       ExecutionMode
 	systemPrompt        string
@@ -28,14 +33,22 @@ type Agent struct {
 	maxOutputTokens     int
 	maxInputTokens      int
 	retryPolicy         RetryPolicy
+	retryable           func(error) bool
 	toolTimeout         time.Duration
+	requestTimeout      time.Duration
+	maxRunDuration      time.Duration
 	parallelTools       bool
 	maxParallelTools    int
+	maxRepeatedCalls    int
+	maxToolArgsBytes    int
 	middlewares         []Middleware
 	observer            observe.Sink
 	conversationHistory []types.Message
 	contextManager      *ContextManager
+	tokenizer           Tokenizer
 	responseSchema      map[string]any
+	checkAvailability   bool
+	targetResolver      delivery.TargetResolver
 
 	mu        sync.RWMutex
 	tools     map[string]tools.Tool
@@ -55,6 +68,13 @@ func WithSystemPrompt(prompt string) Option {
 	return func(a *Agent) { a.systemPrompt = prompt }
 }
 
+// WithModel sets the model on every request this agent sends, overriding
+// the provider's configured default. Use it to run agents on different
+// models from one provider, e.g. a cheap router and a strong synthesizer.
+func WithModel(model string) Option {
+	return func(a *Agent) { a.model = strings.TrimSpace(model) }
+}
+
 func WithMaxIterations(max int) Option {
 	return func(a *Agent) {
 		if max > 0 {
@@ -78,11 +98,17 @@ func WithMaxInputTokens(max int) Option {
 	return func(a *Agent) {
 		if max > 0 {
 			a.maxInputTokens = max
-			a.contextManager = NewContextManager(max)
 		}
 	}
 }
 
+// WithTokenizer sets the tokenizer used for context budgeting. The default
+// is HeuristicTokenizer; use NewBPETokenizer with your own BPE encoding for
+// exact counts.
+func WithTokenizer(t Tokenizer) Option {
+	return func(a *Agent) { a.tokenizer = t }
+}
+
 // WithProviderRetries is kept for backward compatibility.
 func WithProviderRetries(retries int) Option {
 	return func(a *Agent) {
@@ -101,6 +127,16 @@ func WithRetryPolicy(policy RetryPolicy) Option {
 	}
 }
 
+// WithRetryable sets the predicate deciding which provider errors are worth
+// retrying. It applies to rate limits too. The default is IsRetryableError.
+func WithRetryable(fn func(error) bool) Option {
+	return func(a *Agent) {
+		if fn != nil {
+			a.retryable = fn
+		}
+	}
+}
+
 func WithToolTimeout(timeout time.Duration) Option {
 	return func(a *Agent) {
 		if timeout >= 0 {
@@ -109,6 +145,34 @@ func WithToolTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithRequestTimeout bounds each individual provider Generate call, separate
+// from the run's own deadline. A call that times out counts as a failed
+// attempt and is retried under the retry policy.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(a *Agent) {
+		if timeout >= 0 {
+			a.requestTimeout = timeout
+		}
+	}
+}
+
+// ErrRunTimeout is returned, wrapped, when a run exceeds the duration set by
+// WithMaxRunDuration.
+var ErrRunTimeout = errors.New("run exceeded max duration")
+
+// WithMaxRunDuration caps the wall-clock time of a whole run, covering every
+// provider call, retry, and tool call. When the deadline passes, RunDetailed
+// returns the output produced so far together with an error wrapping
+// ErrRunTimeout; RunStream returns that error after the chunks already
+// delivered. Zero disables the cap.
+func WithMaxRunDuration(d time.Duration) Option {
+	return func(a *Agent) {
+		if d >= 0 {
+			a.maxRunDuration = d
+		}
+	}
+}
+
 func WithParallelToolCalls(enabled bool) Option {
 	return func(a *Agent) { a.parallelTools = enabled }
 }
@@ -121,6 +185,37 @@ func WithMaxParallelTools(max int) Option {
 	}
 }
 
+// WithMaxRepeatedToolCalls sets how many consecutive identical tool calls
+// (same name and arguments) count as a loop. The call that reaches the limit
+// is not executed; the model receives a tool result telling it to stop
+// repeating instead. The check is off by default; 3 is a reasonable limit.
+// Zero or a negative value disables it.
+func WithMaxRepeatedToolCalls(max int) Option {
+	return func(a *Agent) {
+		a.maxRepeatedCalls = max
+	}
+}
+
+// WithMaxToolArgsBytes caps the size of a tool call's JSON arguments. An
+// oversized call is not executed; the model receives a tool error asking it
+// to pass a file reference instead. Defaults to DefaultMaxToolArgsBytes; a
+// negative value disables the cap.
+func WithMaxToolArgsBytes(max int) Option {
+	return func(a *Agent) {
+		if max != 0 {
+			a.maxToolArgsBytes = max
+		}
+	}
+}
+
+// WithTargetResolver expands the run's reply target (see delivery.WithTarget)
+// into concrete delivery targets before the run starts. The resolved targets
+// are recorded in run metadata under "deliveryTargets" and available to
+// tools and middleware via delivery.TargetsFromContext.
+func WithTargetResolver(r delivery.TargetResolver) Option {
+	return func(a *Agent) { a.targetResolver = r }
+}
+
 func WithStore(store state.Store) Option {
 	return func(a *Agent) { a.store = store }
 }
@@ -182,6 +277,13 @@ func WithTool(tool tools.Tool) Option {
 	}
 }
 
+// WithToolAvailabilityCheck drops tools whose tools.AvailabilityChecker
+// reports false (e.g. docker without a docker binary) so they are never
+// advertised to the model. Dropped tools are logged.
+func WithToolAvailabilityCheck(enabled bool) Option {
+	return func(a *Agent) { a.checkAvailability = enabled }
+}
+
 // WithResponseSchema sets a JSON schema that the LLM response must conform to.
 // Providers that support structured output will enforce the schema natively.
 func WithResponseSchema(schema map[string]any) Option {
@@ -198,18 +300,37 @@ func New(provider llm.Provider, opts ...Option) (*Agent, error) {
 		executionMode:    ExecutionModeLocal,
 		maxIterations:    6,
 		maxParallelTools: 10,
+		maxToolArgsBytes: DefaultMaxToolArgsBytes,
 		maxInputTokens:   DefaultMaxInputTokens,
 		tools:            make(map[string]tools.Tool),
 		retryPolicy:      defaultRetryPolicy(),
-		contextManager:   NewContextManager(DefaultMaxInputTokens),
+		retryable:        IsRetryableError,
 	}
 	for _, opt := range opts {
 		opt(a)
 	}
+	a.contextManager = NewContextManager(a.maxInputTokens, WithContextTokenizer(a.tokenizer))
 	a.retryPolicy = normalizeRetryPolicy(a.retryPolicy)
+	if a.checkAvailability {
+		a.dropUnavailableTools()
+	}
 	return a, nil
 }
 
+func (a *Agent) dropUnavailableTools() {
+	var dropped []string
+	for name, tool := range a.tools {
+		if !tools.IsAvailable(tool) {
+			delete(a.tools, name)
+			dropped = append(dropped, name)
+		}
+	}
+	if len(dropped) > 0 {
+		sort.Strings(dropped)
+		log.Printf("⚠️  Tools unavailable in this environment, not advertised: %s", strings.Join(dropped, ", "))
+	}
+}
+
 func (a *Agent) Run(ctx context.Context, input string) (string, error) {
 	result, err := a.RunDetailed(ctx, input)
 	if err != nil {
@@ -235,12 +356,13 @@ func (a *Agent) RunLiteDetailed(ctx context.Context, input string) (types.Respon
 	}
 	messages := a.buildInitialMessages(input)
 	req := types.Request{
+		Model:           a.model,
 		SystemPrompt:    a.systemPrompt,
 		Messages:        messages,
 		MaxOutputTokens: a.maxOutputTokens,
 		ResponseSchema:  a.responseSchema,
 	}
-	resp, err := a.generateWithRetry(ctx, req)
+	resp, err := a.generateWithRetry(ctx, req, nil)
 	if err != nil {
 		return types.Response{}, fmt.Errorf("generation failed: %w", err)
 	}
@@ -250,13 +372,23 @@ func (a *Agent) RunLiteDetailed(ctx context.Context, input string) (types.Respon
 
 // RunStream executes one generation turn and streams text chunks when the
 // provider supports it. When streaming is unavailable, it falls back to RunLite.
-func (a *Agent) RunStream(ctx context.Context, input string, onChunk func(types.StreamChunk) error) (types.RunResult, error) {
+func (a *Agent) RunStream(ctx context.Context, input string, onChunk func(types.StreamChunk) error) (_ types.RunResult, err error) {
 	if input == "" {
 		return types.RunResult{}, errors.New("input is required")
 	}
 	if onChunk == nil {
 		return types.RunResult{}, errors.New("onChunk is required")
 	}
+	if a.maxRunDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, a.maxRunDuration, ErrRunTimeout)
+		defer cancel()
+		defer func() {
+			if err != nil && runTimedOut(ctx) && !errors.Is(err, ErrRunTimeout) {
+				err = fmt.Errorf("%w (%s): %v", ErrRunTimeout, a.maxRunDuration, err)
+			}
+		}()
+	}
 
 	messages := a.buildInitialMessages(input)
 	runID := uuid.NewString()
@@ -280,7 +412,8 @@ func (a *Agent) RunStream(ctx context.Context, input string, onChunk func(types.
 			Messages:    all,
 			Usage:       resp.Usage,
 			Iterations:  1,
-			Provider:    a.provider.Name(),
+			Provider:    a.servedBy(resp),
+			Model:       resp.Model,
 			RunID:       runID,
 			SessionID:   sessionID,
 			StartedAt:   &start,
@@ -291,9 +424,10 @@ func (a *Agent) RunStream(ctx context.Context, input string, onChunk func(types.
 	toolDefs := a.listToolDefinitions()
 	trimmed := messages
 	if a.contextManager != nil {
-		trimmed = a.contextManager.TrimMessages(messages, a.systemPrompt, toolDefs, a.maxOutputTokens)
+		trimmed = a.contextManager.ForModel(a.model).TrimMessages(messages, a.systemPrompt, toolDefs, a.maxOutputTokens)
 	}
 	req := types.Request{
+		Model:           a.model,
 		SystemPrompt:    a.systemPrompt,
 		Messages:        trimmed,
 		Tools:           toolDefs,
@@ -312,7 +446,8 @@ func (a *Agent) RunStream(ctx context.Context, input string, onChunk func(types.
 		Messages:    all,
 		Usage:       resp.Usage,
 		Iterations:  1,
-		Provider:    a.provider.Name(),
+		Provider:    a.servedBy(resp),
+		Model:       resp.Model,
 		RunID:       runID,
 		SessionID:   sessionID,
 		StartedAt:   &start,
@@ -320,19 +455,43 @@ func (a *Agent) RunStream(ctx context.Context, input string, onChunk func(types.
 	}, nil
 }
 
-func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult, error) {
+func (a *Agent) RunDetailed(ctx context.Context, input string) (result types.RunResult, err error) {
 	if input == "" {
 		return types.RunResult{}, errors.New("input is required")
 	}
+	if a.maxRunDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, a.maxRunDuration, ErrRunTimeout)
+		defer cancel()
+	}
+
+	if a.targetResolver != nil {
+		if target := delivery.FromContext(ctx); target != nil {
+			targets, err := delivery.Resolve(ctx, a.targetResolver, target)
+			if err != nil {
+				return types.RunResult{}, fmt.Errorf("resolve delivery targets: %w", err)
+			}
+			ctx = delivery.WithTargets(ctx, targets)
+		}
+	}
 
 	runID := uuid.NewString()
 	sessionID := a.ensureSessionID()
 	startedAt := time.Now().UTC()
 	metadata := runMetadataFromContext(ctx)
+	if storage.RunIDFromContext(ctx) == "" {
+		// Tools save artifacts under this run unless the caller (e.g. a
+		// workflow) already scoped them to an outer run.
+		ctx = storage.WithRunID(ctx, runID)
+	}
 
 	messages := a.buildInitialMessages(input)
 	usage := &types.Usage{}
 	hasUsage := false
+	loop := &toolLoopDetector{max: a.maxRepeatedCalls}
+	retries := &types.RetryStats{}
+	servedModel := a.model
+	iterations := 0
 	events := []types.Event{
 		{
 			Type:      types.EventRunStarted,
@@ -344,12 +503,35 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 		},
 	}
 	a.emitRuntimeEvent(ctx, events[0])
+	defer func() {
+		if err == nil || !runTimedOut(ctx) {
+			return
+		}
+		completedAt := time.Now().UTC()
+		result = types.RunResult{
+			Output:      lastAssistantContent(messages),
+			Messages:    append([]types.Message(nil), messages...),
+			Usage:       usageOrNil(usage, hasUsage),
+			Iterations:  iterations,
+			Provider:    a.provider.Name(),
+			Model:       servedModel,
+			RunID:       runID,
+			SessionID:   sessionID,
+			StartedAt:   &startedAt,
+			CompletedAt: &completedAt,
+			Events:      append([]types.Event(nil), events...),
+			Retries:     retries,
+		}
+		if !errors.Is(err, ErrRunTimeout) {
+			err = fmt.Errorf("%w (%s): %v", ErrRunTimeout, a.maxRunDuration, err)
+		}
+	}()
 
 	if err := a.saveRun(ctx, state.RunRecord{
 		RunID:       runID,
 		SessionID:   sessionID,
 		Provider:    a.provider.Name(),
-		Status:      "running",
+		Status:      state.RunRunning,
 		Input:       input,
 		Output:      "",
 		Messages:    append([]types.Message(nil), messages...),
@@ -365,10 +547,11 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 
 	for i := 0; i < a.maxIterations; i++ {
 		iteration := i + 1
+		iterations = iteration
 
 		// Apply context trimming to prevent exceeding token limits
 		toolDefs := a.listToolDefinitions()
-		trimmedMessages := a.contextManager.TrimMessages(
+		trimmedMessages := a.contextManager.ForModel(servedModel).TrimMessages(
 			messages,
 			a.systemPrompt,
 			toolDefs,
@@ -376,6 +559,7 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 		)
 
 		req := types.Request{
+			Model:           a.model,
 			SystemPrompt:    a.systemPrompt,
 			Messages:        trimmedMessages,
 			Tools:           toolDefs,
@@ -409,7 +593,7 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 			return types.RunResult{}, fmt.Errorf("middleware before-generate failed: %w", err)
 		}
 
-		resp, err := a.generateWithRetry(ctx, req)
+		resp, err := a.generateWithRetry(ctx, req, retries)
 		if err != nil {
 			a.notifyError(ctx, &ErrorMiddlewareEvent{
 				RunID:     runID,
@@ -451,6 +635,9 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 			hasUsage = true
 		}
 
+		if resp.Model != "" {
+			servedModel = resp.Model
+		}
 		modelMsg := resp.Message
 		modelMsg.Role = types.RoleAssistant
 		messages = append(messages, modelMsg)
@@ -466,7 +653,7 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 				// Remove the empty assistant message before retrying
 				messages = messages[:len(messages)-1]
 				time.Sleep(time.Duration(emptyRetry) * 500 * time.Millisecond)
-				retryResp, retryErr := a.generateWithRetry(ctx, req)
+				retryResp, retryErr := a.generateWithRetry(ctx, req, retries)
 				if retryErr != nil {
 					continue
 				}
@@ -479,6 +666,7 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 					hasUsage = true
 				}
 				if retryMsg.Content != "" || len(retryMsg.ToolCalls) > 0 {
+					resp = retryResp
 					modelMsg = retryMsg
 					messages = append(messages, modelMsg)
 					break
@@ -520,12 +708,16 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 				finalUsage = usage
 			}
 
+			servedProvider := a.servedBy(resp)
+			if resp.Model != "" {
+				metadata["model"] = resp.Model
+			}
 			completedAt := time.Now().UTC()
 			if err := a.saveRun(ctx, state.RunRecord{
 				RunID:       runID,
 				SessionID:   sessionID,
-				Provider:    a.provider.Name(),
-				Status:      "completed",
+				Provider:    servedProvider,
+				Status:      state.RunCompleted,
 				Input:       input,
 				Output:      modelMsg.Content,
 				Messages:    append([]types.Message(nil), messages...),
@@ -544,9 +736,11 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 				Timestamp: completedAt,
 				RunID:     runID,
 				SessionID: sessionID,
-				Provider:  a.provider.Name(),
+				Provider:  servedProvider,
+				Model:     resp.Model,
 				Iteration: iteration,
 				Message:   "run completed",
+				Retries:   retries,
 			})
 			a.emitRuntimeEvent(ctx, events[len(events)-1])
 
@@ -555,16 +749,18 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 				Messages:    append([]types.Message(nil), messages...),
 				Usage:       finalUsage,
 				Iterations:  iteration,
-				Provider:    a.provider.Name(),
+				Provider:    servedProvider,
+				Model:       resp.Model,
 				RunID:       runID,
 				SessionID:   sessionID,
 				StartedAt:   &startedAt,
 				CompletedAt: &completedAt,
 				Events:      append([]types.Event(nil), events...),
+				Retries:     retries,
 			}, nil
 		}
 
-		toolMessages, toolEvents, err := a.executeToolCalls(ctx, runID, sessionID, iteration, modelMsg.ToolCalls)
+		toolMessages, toolEvents, err := a.executeToolCalls(ctx, runID, sessionID, iteration, modelMsg.ToolCalls, loop)
 		if err != nil {
 			if persistErr := a.markFailed(ctx, runID, sessionID, startedAt, input, messages, usageOrNil(usage, hasUsage), err); persistErr != nil {
 				return types.RunResult{}, fmt.Errorf("tool execution failed: %w (also failed to persist failure: %v)", err, persistErr)
@@ -594,19 +790,28 @@ func (a *Agent) RunDetailed(ctx context.Context, input string) (types.RunResult,
 	return types.RunResult{}, iterationErr
 }
 
-func (a *Agent) generateWithRetry(ctx context.Context, req types.Request) (types.Response, error) {
+// generateWithRetry calls the provider under the retry policy, adding the
+// retries and backoff it performs to stats when stats is non-nil.
+func (a *Agent) generateWithRetry(ctx context.Context, req types.Request, stats *types.RetryStats) (types.Response, error) {
 	policy := normalizeRetryPolicy(a.retryPolicy)
+	if stats == nil {
+		stats = &types.RetryStats{}
+	}
 
 	var lastErr error
 	rateLimitAttempts := 0
 
 	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
-		resp, err := a.provider.Generate(ctx, req)
+		resp, err := a.generateOnce(ctx, req)
 		if err == nil {
 			return resp, nil
 		}
 		lastErr = err
 
+		if !a.retryable(err) {
+			return types.Response{}, fmt.Errorf("provider %q failed after %d attempt(s) (not retryable): %w", a.provider.Name(), attempt, lastErr)
+		}
+
 		// Check if this is a rate limit error
 		if IsRateLimitError(err) {
 			rateLimitAttempts++
@@ -616,11 +821,12 @@ func (a *Agent) generateWithRetry(ctx context.Context, req types.Request) (types
 
 			// Use longer backoff for rate limits
 			backoff := policy.rateLimitBackoffForAttempt(rateLimitAttempts)
-			select {
-			case <-ctx.Done():
-				return types.Response{}, ctx.Err()
-			case <-time.After(backoff):
+			waited, err := sleepContext(ctx, backoff)
+			stats.Backoff += waited
+			if err != nil {
+				return types.Response{}, err
 			}
+			stats.RateLimitRetries++
 			// Don't count rate limit retries against regular attempts
 			attempt--
 			continue
@@ -631,16 +837,59 @@ func (a *Agent) generateWithRetry(ctx context.Context, req types.Request) (types
 		}
 
 		backoff := policy.backoffForAttempt(attempt)
-		select {
-		case <-ctx.Done():
-			return types.Response{}, ctx.Err()
-		case <-time.After(backoff):
+		waited, err := sleepContext(ctx, backoff)
+		stats.Backoff += waited
+		if err != nil {
+			return types.Response{}, err
 		}
+		stats.Retries++
 	}
 
 	return types.Response{}, fmt.Errorf("provider %q failed after %d attempt(s): %w", a.provider.Name(), policy.MaxAttempts, lastErr)
 }
 
+func (a *Agent) generateOnce(ctx context.Context, req types.Request) (types.Response, error) {
+	if a.requestTimeout <= 0 {
+		return a.provider.Generate(ctx, req)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, a.requestTimeout)
+	defer cancel()
+	resp, err := a.provider.Generate(callCtx, req)
+	if err != nil && ctx.Err() == nil && callCtx.Err() != nil {
+		return types.Response{}, fmt.Errorf("provider request timed out after %s: %w", a.requestTimeout, err)
+	}
+	return resp, err
+}
+
+// ErrToolPanicked wraps the recovered value when a tool handler panics.
+var ErrToolPanicked = errors.New("tool panicked")
+
+// ErrToolArgsTooLarge is the tool error for calls over the arguments cap set
+// by WithMaxToolArgsBytes.
+var ErrToolArgsTooLarge = errors.New("tool arguments too large")
+
+// executeTool runs a tool, turning a panic into an error so the run can
+// continue. The stack is logged; only the recovered message reaches the
+// model.
+func executeTool(ctx context.Context, tool tools.Tool, name string, args json.RawMessage) (out any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("tool %q panicked: %v\n%s", name, r, debug.Stack())
+			out, err = nil, fmt.Errorf("%w: %v", ErrToolPanicked, r)
+		}
+	}()
+	return tool.Execute(ctx, args)
+}
+
+// servedBy reports the provider that produced resp, falling back to the
+// configured provider when the response does not say.
+func (a *Agent) servedBy(resp types.Response) string {
+	if resp.Provider != "" {
+		return resp.Provider
+	}
+	return a.provider.Name()
+}
+
 func (a *Agent) listToolDefinitions() []types.ToolDefinition {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -658,10 +907,15 @@ func (a *Agent) executeToolCalls(
 	sessionID string,
 	iteration int,
 	calls []types.ToolCall,
+	loop *toolLoopDetector,
 ) ([]types.Message, []types.Event, error) {
 	toolset := a.snapshotTools()
 	results := make([]types.Message, len(calls))
 	eventSets := make([][]types.Event, len(calls))
+	repeated := make([]int, len(calls))
+	for i, call := range calls {
+		repeated[i] = loop.observe(call)
+	}
 
 	if a.parallelTools && len(calls) > 1 {
 		maxConcurrent := a.maxParallelTools
@@ -684,7 +938,7 @@ func (a *Agent) executeToolCalls(
 			go func() {
 				defer wg.Done()
 				defer func() { <-sem }() // release
-				msg, evs, err := a.executeOneToolCall(ctx, runID, sessionID, iteration, toolset, call)
+				msg, evs, err := a.executeOneToolCall(ctx, runID, sessionID, iteration, toolset, call, repeated[i])
 				if err != nil {
 					errMu.Lock()
 					if firstErr == nil {
@@ -703,7 +957,7 @@ func (a *Agent) executeToolCalls(
 		}
 	} else {
 		for i, call := range calls {
-			msg, evs, err := a.executeOneToolCall(ctx, runID, sessionID, iteration, toolset, call)
+			msg, evs, err := a.executeOneToolCall(ctx, runID, sessionID, iteration, toolset, call, repeated[i])
 			if err != nil {
 				return nil, nil, err
 			}
@@ -737,6 +991,7 @@ func (a *Agent) executeOneToolCall(
 	iteration int,
 	toolset map[string]tools.Tool,
 	call types.ToolCall,
+	repeatCount int,
 ) (types.Message, []types.Event, error) {
 	toolCall := call
 	startedAt := time.Now().UTC()
@@ -770,40 +1025,60 @@ func (a *Agent) executeOneToolCall(
 	var (
 		payload any
 		toolErr error
+		content string
 	)
-	if !ok {
+	if repeatCount > 0 {
+		toolErr = fmt.Errorf("tool %q called with identical arguments %d times in a row", toolCall.Name, repeatCount)
+		payload = map[string]any{
+			"error": toolErr.Error(),
+			"hint":  "Stop repeating this call. Use the result you already have, change the arguments, or answer the user.",
+		}
+	} else if !ok {
 		toolErr = fmt.Errorf("tool %q not found", toolCall.Name)
 		payload = map[string]any{"error": toolErr.Error()}
+	} else if size := len(toolCall.Arguments); a.maxToolArgsBytes > 0 && size > a.maxToolArgsBytes {
+		toolErr = fmt.Errorf("%w: tool %q arguments are %d bytes, limit is %d", ErrToolArgsTooLarge, toolCall.Name, size, a.maxToolArgsBytes)
+		payload = map[string]any{
+			"error":      toolErr.Error(),
+			"sizeBytes":  size,
+			"limitBytes": a.maxToolArgsBytes,
+			"hint":       "Do not inline large content in tool arguments. Write it to a file (for example with the tmpdir tool) and pass the file path instead.",
+		}
 	} else {
 		args := toolCall.Arguments
 		if len(args) == 0 {
 			args = json.RawMessage(`{}`)
 		}
 
-		toolCtx := ctx
+		toolCtx := tools.WithSessionID(tools.WithModel(tools.WithProvider(ctx, a.provider), a.model), sessionID)
 		cancel := func() {}
 		if a.toolTimeout > 0 {
-			toolCtx, cancel = context.WithTimeout(ctx, a.toolTimeout)
+			toolCtx, cancel = context.WithTimeout(toolCtx, a.toolTimeout)
 		}
-		out, err := tool.Execute(toolCtx, args)
+		out, err := executeTool(toolCtx, tool, toolCall.Name, args)
 		cancel()
 		if err != nil {
 			toolErr = err
 			payload = map[string]any{"error": err.Error()}
+		} else if text, err := tools.FormatResult(tool, out); err == nil {
+			content = text
 		} else {
 			payload = out
 		}
 	}
 
-	encoded, err := json.Marshal(payload)
-	if err != nil {
-		encoded = []byte(fmt.Sprintf(`{"error":"failed to encode tool output","detail":%q}`, err.Error()))
+	if content == "" {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			encoded = []byte(fmt.Sprintf(`{"error":"failed to encode tool output","detail":%q}`, err.Error()))
+		}
+		content = string(encoded)
 	}
 	result := types.Message{
 		Role:       types.RoleTool,
 		Name:       toolCall.Name,
 		ToolCallID: toolCall.ID,
-		Content:    string(encoded),
+		Content:    content,
 	}
 
 	finishedAt := time.Now().UTC()
@@ -939,7 +1214,7 @@ func (a *Agent) saveProgress(
 		RunID:     runID,
 		SessionID: sessionID,
 		Provider:  a.provider.Name(),
-		Status:    "running",
+		Status:    state.RunRunning,
 		Input:     input,
 		Output:    "",
 		Messages:  append([]types.Message(nil), messages...),
@@ -963,6 +1238,13 @@ func (a *Agent) markFailed(
 ) error {
 	now := time.Now().UTC()
 	metadata := runMetadataFromContext(ctx)
+	if runTimedOut(ctx) {
+		// Record the failure even though the run's deadline has passed.
+		ctx = context.WithoutCancel(ctx)
+		if runErr != nil && !errors.Is(runErr, ErrRunTimeout) {
+			runErr = fmt.Errorf("%w: %v", ErrRunTimeout, runErr)
+		}
+	}
 	errText := ""
 	if runErr != nil {
 		errText = runErr.Error()
@@ -971,7 +1253,7 @@ func (a *Agent) markFailed(
 		RunID:       runID,
 		SessionID:   sessionID,
 		Provider:    a.provider.Name(),
-		Status:      "failed",
+		Status:      state.RunFailed,
 		Input:       input,
 		Output:      "",
 		Messages:    append([]types.Message(nil), messages...),
@@ -1000,12 +1282,13 @@ func (a *Agent) markFailed(
 func runMetadataFromContext(ctx context.Context) map[string]any {
 	md := map[string]any{}
 	if target := delivery.FromContext(ctx); target != nil {
-		md["replyTo"] = map[string]any{
-			"channel":     target.Channel,
-			"destination": target.Destination,
-			"threadId":    target.ThreadID,
-			"userId":      target.UserID,
-			"metadata":    target.Metadata,
+		md["replyTo"] = targetMetadata(target)
+		if targets := delivery.TargetsFromContext(ctx); len(targets) != 1 || !delivery.Equal(targets[0], target) {
+			list := make([]map[string]any, 0, len(targets))
+			for _, t := range targets {
+				list = append(list, targetMetadata(t))
+			}
+			md["deliveryTargets"] = list
 		}
 	}
 	if turnType := delivery.TurnTypeFromContext(ctx); turnType != "" {
@@ -1017,6 +1300,31 @@ func runMetadataFromContext(ctx context.Context) map[string]any {
 	return md
 }
 
+// runTimedOut reports whether ctx ended because of WithMaxRunDuration.
+func runTimedOut(ctx context.Context) bool {
+	return ctx.Err() != nil && errors.Is(context.Cause(ctx), ErrRunTimeout)
+}
+
+// lastAssistantContent returns the most recent non-empty assistant text.
+func lastAssistantContent(messages []types.Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == types.RoleAssistant && messages[i].Content != "" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+func targetMetadata(target *delivery.Target) map[string]any {
+	return map[string]any{
+		"channel":     target.Channel,
+		"destination": target.Destination,
+		"threadId":    target.ThreadID,
+		"userId":      target.UserID,
+		"metadata":    target.Metadata,
+	}
+}
+
 func (a *Agent) saveRun(ctx context.Context, run state.RunRecord) error {
 	if a.store == nil {
 		return nil
@@ -1075,6 +1383,10 @@ func (a *Agent) RegisterTool(tool tools.Tool) {
 	if def.Name == "" {
 		return
 	}
+	if a.checkAvailability && !tools.IsAvailable(tool) {
+		log.Printf("⚠️  Tool %q unavailable in this environment, not registered", def.Name)
+		return
+	}
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if a.tools == nil {
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/PipeOpsHQ/agent-sdk-go/delivery"
 	"github.com/PipeOpsHQ/agent-sdk-go/llm"
 	"github.com/PipeOpsHQ/agent-sdk-go/state"
 	"github.com/PipeOpsHQ/agent-sdk-go/tools"
@@ -238,6 +239,83 @@ func TestAgent_Run_RespectsToolTimeout(t *testing.T) {
 	}
 }
 
+type stallingProvider struct {
+	calls int
+}
+
+func (p *stallingProvider) Name() string { return "stalling-provider" }
+
+func (p *stallingProvider) Capabilities() llm.Capabilities {
+	return llm.Capabilities{}
+}
+
+func (p *stallingProvider) Generate(ctx context.Context, req types.Request) (types.Response, error) {
+	p.calls++
+	if p.calls == 1 {
+		return types.Response{Message: types.Message{
+			Role:      types.RoleAssistant,
+			Content:   "draft answer",
+			ToolCalls: []types.ToolCall{{ID: "call-1", Name: "noop", Arguments: json.RawMessage(`{}`)}},
+		}}, nil
+	}
+	<-ctx.Done()
+	return types.Response{}, ctx.Err()
+}
+
+func TestAgent_WithMaxRunDuration_ReturnsPartialOutput(t *testing.T) {
+	store := newMemoryStateStore()
+	noop := tools.NewFuncTool("noop", "does nothing", map[string]any{"type": "object"},
+		func(context.Context, json.RawMessage) (any, error) { return "done", nil })
+	a, err := New(&stallingProvider{},
+		WithTool(noop),
+		WithStore(store),
+		WithMaxRunDuration(50*time.Millisecond),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	start := time.Now()
+	result, err := a.RunDetailed(context.Background(), "hello")
+	if !errors.Is(err, ErrRunTimeout) {
+		t.Fatalf("expected ErrRunTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("run was not cut off: %s", elapsed)
+	}
+	if result.Output != "draft answer" || result.Iterations != 2 || result.RunID == "" {
+		t.Fatalf("unexpected partial result: %+v", result)
+	}
+
+	run, err := store.LoadRun(context.Background(), result.RunID)
+	if err != nil {
+		t.Fatalf("expected persisted run: %v", err)
+	}
+	if run.Status != state.RunFailed || !strings.Contains(run.Error, ErrRunTimeout.Error()) {
+		t.Fatalf("unexpected persisted run: status=%q error=%q", run.Status, run.Error)
+	}
+}
+
+func TestAgent_WithMaxRunDuration_CapsRunStream(t *testing.T) {
+	a, err := New(&stallingProvider{calls: 1},
+		WithMaxRunDuration(50*time.Millisecond),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	start := time.Now()
+	_, err = a.RunStream(context.Background(), "hello", func(types.StreamChunk) error { return nil })
+	if !errors.Is(err, ErrRunTimeout) {
+		t.Fatalf("expected ErrRunTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("stream was not cut off: %s", elapsed)
+	}
+}
+
 type usageProvider struct {
 	calls int
 }
@@ -302,6 +380,49 @@ func TestAgent_RunDetailed_ReturnsMetadata(t *testing.T) {
 	}
 }
 
+type routedProvider struct{}
+
+func (p *routedProvider) Name() string { return "router" }
+
+func (p *routedProvider) Capabilities() llm.Capabilities { return llm.Capabilities{} }
+
+func (p *routedProvider) Generate(ctx context.Context, req types.Request) (types.Response, error) {
+	_ = ctx
+	_ = req
+	return types.Response{
+		Message:  types.Message{Role: types.RoleAssistant, Content: "routed"},
+		Provider: "backup",
+		Model:    "backup-model-2",
+	}, nil
+}
+
+func TestAgent_RunDetailed_ReportsServingProviderAndModel(t *testing.T) {
+	store := newMemoryStateStore()
+	a, err := New(&routedProvider{}, WithStore(store))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	result, err := a.RunDetailed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("run detailed failed: %v", err)
+	}
+	if result.Provider != "backup" || result.Model != "backup-model-2" {
+		t.Fatalf("unexpected provider/model: %q/%q", result.Provider, result.Model)
+	}
+	last := result.Events[len(result.Events)-1]
+	if last.Type != types.EventRunCompleted || last.Provider != "backup" || last.Model != "backup-model-2" {
+		t.Fatalf("unexpected completion event: %#v", last)
+	}
+	run, err := store.LoadRun(context.Background(), result.RunID)
+	if err != nil {
+		t.Fatalf("load run: %v", err)
+	}
+	if run.Provider != "backup" || run.Metadata["model"] != "backup-model-2" {
+		t.Fatalf("unexpected persisted run: provider=%q metadata=%v", run.Provider, run.Metadata)
+	}
+}
+
 type memoryStateStore struct {
 	mu          sync.Mutex
 	runs        map[string]state.RunRecord
@@ -441,6 +562,59 @@ func TestAgent_RunDetailed_PersistsCompletedRun(t *testing.T) {
 	}
 }
 
+func TestAgent_WithTargetResolver_RecordsFanOut(t *testing.T) {
+	store := newMemoryStateStore()
+	resolver := delivery.ResolverFunc(func(_ context.Context, target *delivery.Target) ([]*delivery.Target, error) {
+		if target.Metadata["route"] != "ops" {
+			return []*delivery.Target{target}, nil
+		}
+		return []*delivery.Target{
+			{Channel: "slack", Destination: "#ops"},
+			{Channel: "webhook", Destination: "https://hooks.example.com/ops"},
+			{Channel: "slack", Destination: "#ops"},
+		}, nil
+	})
+	a, err := New(&simpleProvider{}, WithStore(store), WithTargetResolver(resolver))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	ctx := delivery.WithTarget(context.Background(), &delivery.Target{Channel: "logical", Metadata: map[string]string{"route": "ops"}})
+	result, err := a.RunDetailed(ctx, "hello")
+	if err != nil {
+		t.Fatalf("RunDetailed failed: %v", err)
+	}
+	run, err := store.LoadRun(context.Background(), result.RunID)
+	if err != nil {
+		t.Fatalf("expected persisted run: %v", err)
+	}
+	targets, ok := run.Metadata["deliveryTargets"].([]map[string]any)
+	if !ok || len(targets) != 2 {
+		t.Fatalf("deliveryTargets = %#v", run.Metadata["deliveryTargets"])
+	}
+	if targets[0]["channel"] != "slack" || targets[1]["channel"] != "webhook" {
+		t.Errorf("unexpected targets: %v", targets)
+	}
+
+	ctx = delivery.WithTarget(context.Background(), &delivery.Target{Channel: "slack", Destination: "#general"})
+	result, err = a.RunDetailed(ctx, "hello")
+	if err != nil {
+		t.Fatalf("RunDetailed failed: %v", err)
+	}
+	run, _ = store.LoadRun(context.Background(), result.RunID)
+	if _, ok := run.Metadata["deliveryTargets"]; ok {
+		t.Errorf("pass-through resolution should not record deliveryTargets: %v", run.Metadata)
+	}
+
+	failing := delivery.ResolverFunc(func(context.Context, *delivery.Target) ([]*delivery.Target, error) {
+		return nil, errors.New("no route")
+	})
+	a, _ = New(&simpleProvider{}, WithTargetResolver(failing))
+	if _, err := a.RunDetailed(ctx, "hello"); err == nil || !strings.Contains(err.Error(), "no route") {
+		t.Fatalf("expected resolver error, got %v", err)
+	}
+}
+
 func TestAgent_RunDetailed_PersistsFailedRun(t *testing.T) {
 	store := newMemoryStateStore()
 	a, err := New(
@@ -525,6 +699,50 @@ func TestAgent_Run_UsesStructuredRetryPolicy(t *testing.T) {
 	}
 }
 
+// hangOnceProvider stalls its first call until the context ends.
+type hangOnceProvider struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (p *hangOnceProvider) Name() string                   { return "hang-once" }
+func (p *hangOnceProvider) Capabilities() llm.Capabilities { return llm.Capabilities{} }
+func (p *hangOnceProvider) Generate(ctx context.Context, _ types.Request) (types.Response, error) {
+	p.mu.Lock()
+	p.calls++
+	first := p.calls == 1
+	p.mu.Unlock()
+	if first {
+		<-ctx.Done()
+		return types.Response{}, ctx.Err()
+	}
+	return types.Response{Message: types.Message{Role: types.RoleAssistant, Content: "recovered"}}, nil
+}
+
+func TestAgent_WithRequestTimeout_RetriesStalledCall(t *testing.T) {
+	p := &hangOnceProvider{}
+	a, err := New(p,
+		WithRequestTimeout(20*time.Millisecond),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := a.RunDetailed(ctx, "hello")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if result.Output != "recovered" || p.calls != 2 {
+		t.Fatalf("output=%q calls=%d, want recovered after 2 calls", result.Output, p.calls)
+	}
+	if result.Retries == nil || result.Retries.Retries != 1 {
+		t.Fatalf("expected one recorded retry, got %+v", result.Retries)
+	}
+}
+
 func TestAgent_WithProviderRetries_CompatibilityWrapper(t *testing.T) {
 	p := &retryPolicyProvider{failTill: 1}
 	a, err := New(p, WithProviderRetries(1))
@@ -645,6 +863,64 @@ func TestAgent_Middleware_CanMutateGenerateRequestAndResponse(t *testing.T) {
 	}
 }
 
+func TestAgent_WithModel_OverridesRequestModel(t *testing.T) {
+	router := &inspectProvider{}
+	cheap, err := New(router, WithModel("small-model"))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	if _, err := cheap.Run(context.Background(), "route this"); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if router.lastReq.Model != "small-model" {
+		t.Fatalf("expected request model small-model, got %q", router.lastReq.Model)
+	}
+	if _, err := cheap.RunLite(context.Background(), "again"); err != nil {
+		t.Fatalf("run lite failed: %v", err)
+	}
+	if router.lastReq.Model != "small-model" {
+		t.Fatalf("expected RunLite request model small-model, got %q", router.lastReq.Model)
+	}
+
+	plain := &inspectProvider{}
+	def, err := New(plain)
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	if _, err := def.Run(context.Background(), "hello"); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if plain.lastReq.Model != "" {
+		t.Fatalf("expected provider default (empty model), got %q", plain.lastReq.Model)
+	}
+}
+
+func TestAgent_WithToolAvailabilityCheck_DropsUnavailableTools(t *testing.T) {
+	noop := func(context.Context, json.RawMessage) (any, error) { return "ok", nil }
+	missing := tools.NewFuncTool("needs_binary", "", nil, noop).WithAvailability(func() bool { return false })
+	present := tools.NewFuncTool("always_here", "", nil, noop)
+
+	a, err := New(&inspectProvider{}, WithTool(missing), WithTool(present), WithToolAvailabilityCheck(true))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	if got := a.ListTools(); len(got) != 1 || got[0] != "always_here" {
+		t.Fatalf("expected only always_here to be advertised, got %v", got)
+	}
+	a.RegisterTool(missing)
+	if got := a.ListTools(); len(got) != 1 {
+		t.Fatalf("expected RegisterTool to skip unavailable tool, got %v", got)
+	}
+
+	unchecked, err := New(&inspectProvider{}, WithTool(missing))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	if got := unchecked.ListTools(); len(got) != 1 || got[0] != "needs_binary" {
+		t.Fatalf("expected tools kept without the check, got %v", got)
+	}
+}
+
 type toolFlowProvider struct {
 	calls int
 }
@@ -730,6 +1006,79 @@ func TestAgent_Middleware_CanMutateToolCallAndResult(t *testing.T) {
 	}
 }
 
+func TestAgent_ToolPanicBecomesToolError(t *testing.T) {
+	panicky := tools.NewFuncTool(
+		"echo_tool",
+		"panics",
+		map[string]any{"type": "object"},
+		func(ctx context.Context, args json.RawMessage) (any, error) {
+			var m map[string]int
+			m["boom"]++ // nil map write
+			return nil, nil
+		},
+	)
+	var toolErr error
+	m := &middlewareProbe{
+		afterTool: func(event *ToolMiddlewareEvent) error {
+			toolErr = event.ToolError
+			return nil
+		},
+	}
+	a, err := New(&toolFlowProvider{}, WithTool(panicky), WithMiddleware(m), WithMaxIterations(3))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	out, err := a.Run(context.Background(), "run")
+	if err != nil {
+		t.Fatalf("run should survive a panicking tool: %v", err)
+	}
+	if !errors.Is(toolErr, ErrToolPanicked) {
+		t.Fatalf("tool error = %v, want ErrToolPanicked", toolErr)
+	}
+	if !strings.Contains(out, "assignment to entry in nil map") || strings.Contains(out, "goroutine") {
+		t.Fatalf("expected recovered message without stack, got %q", out)
+	}
+}
+
+func TestAgent_OversizedToolArgsRejected(t *testing.T) {
+	executed := false
+	echo := tools.NewFuncTool(
+		"echo_tool",
+		"echo",
+		map[string]any{"type": "object"},
+		func(ctx context.Context, args json.RawMessage) (any, error) {
+			executed = true
+			return map[string]any{"ok": true}, nil
+		},
+	)
+	var toolErr error
+	m := &middlewareProbe{
+		afterTool: func(event *ToolMiddlewareEvent) error {
+			toolErr = event.ToolError
+			return nil
+		},
+	}
+	a, err := New(&toolFlowProvider{}, WithTool(echo), WithMiddleware(m), WithMaxToolArgsBytes(8), WithMaxIterations(3))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	out, err := a.Run(context.Background(), "run")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if executed {
+		t.Fatal("oversized tool call should not execute")
+	}
+	if !errors.Is(toolErr, ErrToolArgsTooLarge) {
+		t.Fatalf("tool error = %v, want ErrToolArgsTooLarge", toolErr)
+	}
+	if !strings.Contains(out, "limitBytes") || !strings.Contains(out, "file path") {
+		t.Fatalf("expected structured size error for the model, got %q", out)
+	}
+}
+
 func TestAgent_Middleware_OnErrorIsCalled(t *testing.T) {
 	var (
 		mu        sync.Mutex
@@ -863,3 +1212,61 @@ func TestAgent_RunStream_EmitsChunks(t *testing.T) {
 		t.Fatalf("unexpected chunks: %#v", chunks)
 	}
 }
+
+type loopingProvider struct {
+	calls int
+}
+
+func (p *loopingProvider) Name() string { return "looping" }
+
+func (p *loopingProvider) Capabilities() llm.Capabilities { return llm.Capabilities{Tools: true} }
+
+func (p *loopingProvider) Generate(ctx context.Context, req types.Request) (types.Response, error) {
+	_ = ctx
+	p.calls++
+	last := req.Messages[len(req.Messages)-1]
+	if last.Role == types.RoleTool && strings.Contains(last.Content, "Stop repeating") {
+		return types.Response{Message: types.Message{Role: types.RoleAssistant, Content: "gave up"}}, nil
+	}
+	args := `{"q":"same","n":1}`
+	if p.calls%2 == 0 {
+		args = `{"n":1, "q":"same"}`
+	}
+	return types.Response{Message: types.Message{
+		Role:      types.RoleAssistant,
+		ToolCalls: []types.ToolCall{{ID: fmt.Sprintf("call-%d", p.calls), Name: "lookup", Arguments: json.RawMessage(args)}},
+	}}, nil
+}
+
+func TestAgent_Run_BreaksRepeatedToolCallLoop(t *testing.T) {
+	executions := 0
+	lookup := tools.NewFuncTool("lookup", "lookup", map[string]any{"type": "object"}, func(ctx context.Context, args json.RawMessage) (any, error) {
+		_ = ctx
+		_ = args
+		executions++
+		return map[string]any{"found": false}, nil
+	})
+
+	a, err := New(&loopingProvider{}, WithTool(lookup), WithMaxIterations(10), WithMaxRepeatedToolCalls(3))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	out, err := a.Run(context.Background(), "find it")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if out != "gave up" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if executions != 2 {
+		t.Fatalf("expected 2 executions before loop break, got %d", executions)
+	}
+
+	disabled, err := New(&loopingProvider{}, WithTool(lookup), WithMaxIterations(4))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	if _, err := disabled.Run(context.Background(), "find it"); err == nil || !strings.Contains(err.Error(), "max iterations") {
+		t.Fatalf("expected max iterations error with loop detection off by default, got %v", err)
+	}
+}
@@ -21,15 +21,72 @@ const (
 // exceeding provider rate limits.
 type ContextManager struct {
 	maxInputTokens int
+	tokenizer      Tokenizer
+	model          string
+}
+
+// ContextOption configures a ContextManager.
+type ContextOption func(*ContextManager)
+
+// WithContextTokenizer sets the tokenizer used for budget calculations.
+// A nil tokenizer keeps the heuristic default.
+func WithContextTokenizer(t Tokenizer) ContextOption {
+	return func(cm *ContextManager) {
+		if t != nil {
+			cm.tokenizer = t
+		}
+	}
 }
 
 // NewContextManager creates a ContextManager with the specified token limit.
 // If maxTokens is <= 0, DefaultMaxInputTokens is used.
-func NewContextManager(maxTokens int) *ContextManager {
+func NewContextManager(maxTokens int, opts ...ContextOption) *ContextManager {
 	if maxTokens <= 0 {
 		maxTokens = DefaultMaxInputTokens
 	}
-	return &ContextManager{maxInputTokens: maxTokens}
+	cm := &ContextManager{maxInputTokens: maxTokens, tokenizer: HeuristicTokenizer{}}
+	for _, opt := range opts {
+		opt(cm)
+	}
+	return cm
+}
+
+// ForModel returns a copy of the manager that counts tokens for model.
+func (cm *ContextManager) ForModel(model string) *ContextManager {
+	clone := *cm
+	clone.model = model
+	return &clone
+}
+
+// CountTokens counts tokens in text using the configured tokenizer.
+func (cm *ContextManager) CountTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	if cm.tokenizer == nil {
+		return EstimateTokens(text)
+	}
+	return cm.tokenizer.CountTokens(cm.model, text)
+}
+
+// CountMessageTokens counts tokens for a single message, including role
+// and tool-call overhead.
+func (cm *ContextManager) CountMessageTokens(msg types.Message) int {
+	return messageTokens(msg, cm.CountTokens)
+}
+
+// CountMessagesTokens counts total tokens for a slice of messages.
+func (cm *ContextManager) CountMessagesTokens(messages []types.Message) int {
+	total := 0
+	for _, msg := range messages {
+		total += cm.CountMessageTokens(msg)
+	}
+	return total
+}
+
+// CountToolDefinitionsTokens counts tokens for tool definitions.
+func (cm *ContextManager) CountToolDefinitionsTokens(tools []types.ToolDefinition) int {
+	return toolDefinitionsTokens(tools, cm.CountTokens)
 }
 
 // EstimateTokens provides a rough token count for a string.
@@ -44,16 +101,20 @@ func EstimateTokens(text string) int {
 // EstimateMessageTokens estimates tokens for a single message,
 // including role overhead.
 func EstimateMessageTokens(msg types.Message) int {
+	return messageTokens(msg, EstimateTokens)
+}
+
+func messageTokens(msg types.Message, count func(string) int) int {
 	// Base overhead for role and message structure
 	tokens := 4
 
 	// Content tokens
-	tokens += EstimateTokens(msg.Content)
+	tokens += count(msg.Content)
 
 	// Tool call overhead
 	for _, tc := range msg.ToolCalls {
 		tokens += 10 // ID, name overhead
-		tokens += EstimateTokens(string(tc.Arguments))
+		tokens += count(string(tc.Arguments))
 	}
 
 	// Tool result overhead
@@ -75,10 +136,14 @@ func EstimateMessagesTokens(messages []types.Message) int {
 
 // EstimateToolDefinitionsTokens estimates tokens for tool definitions.
 func EstimateToolDefinitionsTokens(tools []types.ToolDefinition) int {
+	return toolDefinitionsTokens(tools, EstimateTokens)
+}
+
+func toolDefinitionsTokens(tools []types.ToolDefinition, count func(string) int) int {
 	total := 0
 	for _, tool := range tools {
 		total += 10 // name overhead
-		total += EstimateTokens(tool.Description)
+		total += count(tool.Description)
 		// Rough estimate for JSON schema
 		total += 50
 	}
@@ -107,7 +172,7 @@ func (cm *ContextManager) TrimMessages(
 	}
 
 	// Calculate fixed overhead
-	fixedTokens := EstimateTokens(systemPrompt) + EstimateToolDefinitionsTokens(tools) + reserveTokens
+	fixedTokens := cm.CountTokens(systemPrompt) + cm.CountToolDefinitionsTokens(tools) + reserveTokens
 	availableTokens := cm.maxInputTokens - fixedTokens
 
 	if availableTokens <= 0 {
@@ -119,7 +184,7 @@ func (cm *ContextManager) TrimMessages(
 	}
 
 	// Calculate total tokens needed
-	totalTokens := EstimateMessagesTokens(messages)
+	totalTokens := cm.CountMessagesTokens(messages)
 
 	// If we're under budget, return all messages
 	if totalTokens <= availableTokens {
@@ -132,13 +197,13 @@ func (cm *ContextManager) TrimMessages(
 
 	// Always include the last message (current user input)
 	lastMsg := messages[len(messages)-1]
-	lastMsgTokens := EstimateMessageTokens(lastMsg)
+	lastMsgTokens := cm.CountMessageTokens(lastMsg)
 	usedTokens += lastMsgTokens
 
 	// Work backwards from second-to-last message
 	for i := len(messages) - 2; i >= 0; i-- {
 		msg := messages[i]
-		msgTokens := EstimateMessageTokens(msg)
+		msgTokens := cm.CountMessageTokens(msg)
 
 		if usedTokens+msgTokens > availableTokens {
 			break
@@ -291,9 +356,9 @@ func (cm *ContextManager) ShouldTrim(
 	systemPrompt string,
 	tools []types.ToolDefinition,
 ) bool {
-	fixedTokens := EstimateTokens(systemPrompt) + EstimateToolDefinitionsTokens(tools)
+	fixedTokens := cm.CountTokens(systemPrompt) + cm.CountToolDefinitionsTokens(tools)
 	availableTokens := cm.maxInputTokens - fixedTokens
-	totalTokens := EstimateMessagesTokens(messages)
+	totalTokens := cm.CountMessagesTokens(messages)
 	return totalTokens > availableTokens
 }
 
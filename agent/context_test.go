@@ -1,6 +1,8 @@
 package agent
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/PipeOpsHQ/agent-sdk-go/types"
@@ -155,3 +157,70 @@ func TestNewContextManager(t *testing.T) {
 		}
 	})
 }
+
+type wordEncoding struct{}
+
+func (wordEncoding) Encode(text string, _, _ []string) []int {
+	return make([]int, len(strings.Fields(text)))
+}
+
+type recordingTokenizer struct {
+	models []string
+}
+
+func (r *recordingTokenizer) CountTokens(model, text string) int {
+	r.models = append(r.models, model)
+	return len(text)
+}
+
+func TestBPETokenizer(t *testing.T) {
+	resolved := map[string]int{}
+	tok := NewBPETokenizer(func(model string) (BPEEncoding, error) {
+		resolved[model]++
+		if model == "unknown" {
+			return nil, errors.New("no encoding")
+		}
+		return wordEncoding{}, nil
+	})
+
+	if got := tok.CountTokens("gpt-4o", "one two three four five"); got != 5 {
+		t.Errorf("CountTokens = %d, want 5", got)
+	}
+	tok.CountTokens("gpt-4o", "again")
+	if resolved["gpt-4o"] != 1 {
+		t.Errorf("encoding resolved %d times, want cached", resolved["gpt-4o"])
+	}
+	if got := tok.CountTokens("unknown", "hello world this is a test"); got != EstimateTokens("hello world this is a test") {
+		t.Errorf("fallback CountTokens = %d", got)
+	}
+	if got := tok.CountTokens("gpt-4o", ""); got != 0 {
+		t.Errorf("empty CountTokens = %d", got)
+	}
+}
+
+func TestContextManager_UsesTokenizer(t *testing.T) {
+	tok := &recordingTokenizer{}
+	cm := NewContextManager(40, WithContextTokenizer(tok)).ForModel("model-x")
+
+	if got := cm.CountTokens("abcdefgh"); got != 8 {
+		t.Errorf("CountTokens = %d, want 8", got)
+	}
+	messages := []types.Message{
+		{Role: types.RoleUser, Content: strings.Repeat("a", 20)},
+		{Role: types.RoleAssistant, Content: strings.Repeat("b", 20)},
+		{Role: types.RoleUser, Content: "latest"},
+	}
+	// Heuristic counts would fit all three; character counts must trim.
+	if NewContextManager(40).ShouldTrim(messages, "", nil) {
+		t.Fatal("heuristic manager unexpectedly wants to trim")
+	}
+	trimmed := cm.TrimMessages(messages, "", nil, 0)
+	if len(trimmed) != 2 || trimmed[len(trimmed)-1].Content != "latest" {
+		t.Errorf("trimmed = %+v", trimmed)
+	}
+	for _, m := range tok.models {
+		if m != "model-x" {
+			t.Fatalf("tokenizer called with model %q", m)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
+)
+
+// DefaultMaxToolArgsBytes is the default cap on a tool call's JSON arguments.
+const DefaultMaxToolArgsBytes = 1 << 20
+
+// toolLoopDetector tracks consecutive identical tool calls within one run.
+type toolLoopDetector struct {
+	max   int
+	last  string
+	count int
+}
+
+// observe records call and returns the consecutive repeat count when the
+// call should be blocked, or 0 when it may run.
+func (d *toolLoopDetector) observe(call types.ToolCall) int {
+	if d == nil || d.max <= 0 {
+		return 0
+	}
+	key := call.Name + "\x00" + canonicalArgs(call.Arguments)
+	if key == d.last {
+		d.count++
+	} else {
+		d.last = key
+		d.count = 1
+	}
+	if d.count >= d.max {
+		return d.count
+	}
+	return 0
+}
+
+// canonicalArgs normalizes JSON arguments so that key order and whitespace
+// do not hide a repeated call.
+func canonicalArgs(raw json.RawMessage) string {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return "{}"
+	}
+	var v any
+	if err := json.Unmarshal(trimmed, &v); err != nil {
+		return string(trimmed)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(trimmed)
+	}
+	return string(out)
+}
@@ -1,8 +1,15 @@
 package agent
 
 import (
+	"context"
+	"errors"
+	"io"
 	"math/rand"
+	"net"
+	"regexp"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -83,6 +90,43 @@ func IsRateLimitError(err error) bool {
 		strings.Contains(errStr, "too many requests")
 }
 
+// statusCodePattern finds an HTTP status in provider errors such as
+// "openai API error (503): ..." or "Error 400, Message: ...".
+var statusCodePattern = regexp.MustCompile(`(?i)\b(?:error|status|code)\W{0,3}([1-5]\d\d)\b`)
+
+// IsRetryableError is the default retry classifier. It retries rate limits,
+// timeouts, connection failures, 5xx, 408 and 429, and rejects other 4xx
+// responses (400, 401, 403, 404, 422, ...) and cancellation. Errors it cannot
+// classify are retried.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if IsRateLimitError(err) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if m := statusCodePattern.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		switch {
+		case code >= 500, code == 408, code == 429:
+			return true
+		case code >= 400:
+			return false
+		}
+	}
+	return true
+}
+
 // rateLimitBackoffForAttempt calculates backoff for rate limit errors with jitter.
 func (p RetryPolicy) rateLimitBackoffForAttempt(retryNumber int) time.Duration {
 	if retryNumber < 1 {
@@ -121,3 +165,17 @@ func (p RetryPolicy) backoffForAttempt(retryNumber int) time.Duration {
 	}
 	return delay
 }
+
+// sleepContext waits for d or until ctx is done, returning how long it
+// actually waited.
+func sleepContext(ctx context.Context, d time.Duration) (time.Duration, error) {
+	start := time.Now()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return time.Since(start), ctx.Err()
+	case <-timer.C:
+		return time.Since(start), nil
+	}
+}
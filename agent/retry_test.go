@@ -1,9 +1,16 @@
 package agent
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/llm"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
 )
 
 func TestIsRateLimitError(t *testing.T) {
@@ -101,3 +108,105 @@ func TestNormalizeRetryPolicy_RateLimitDefaults(t *testing.T) {
 		}
 	})
 }
+
+type scriptedErrProvider struct {
+	errs  []error
+	calls int
+}
+
+func (p *scriptedErrProvider) Name() string { return "flaky" }
+
+func (p *scriptedErrProvider) Capabilities() llm.Capabilities { return llm.Capabilities{} }
+
+func (p *scriptedErrProvider) Generate(_ context.Context, _ types.Request) (types.Response, error) {
+	p.calls++
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = p.errs[1:]
+		return types.Response{}, err
+	}
+	return types.Response{Message: types.Message{Role: types.RoleAssistant, Content: "ok"}}, nil
+}
+
+func TestRunDetailed_ReportsRetryStats(t *testing.T) {
+	provider := &scriptedErrProvider{errs: []error{
+		errors.New("connection reset"),
+		errors.New("API error (429): Too Many Requests"),
+		errors.New("connection reset"),
+	}}
+	a, err := New(provider, WithRetryPolicy(RetryPolicy{
+		MaxAttempts:          3,
+		BaseBackoff:          time.Millisecond,
+		MaxBackoff:           time.Millisecond,
+		RateLimitBaseBackoff: 2 * time.Millisecond,
+		RateLimitMaxBackoff:  2 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := a.RunDetailed(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("RunDetailed failed: %v", err)
+	}
+	if res.Retries == nil || res.Retries.Retries != 2 || res.Retries.RateLimitRetries != 1 {
+		t.Fatalf("retries = %+v", res.Retries)
+	}
+	if res.Retries.Backoff < 4*time.Millisecond {
+		t.Errorf("backoff = %v, want >= 4ms", res.Retries.Backoff)
+	}
+	last := res.Events[len(res.Events)-1]
+	if last.Type != types.EventRunCompleted || last.Retries == nil || last.Retries.Retries != 2 {
+		t.Errorf("completion event = %+v", last)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil error", nil, false},
+		{"unclassified", errors.New("transient error"), true},
+		{"rate limit", errors.New("API error (429): Too Many Requests"), true},
+		{"server error", errors.New("openai API error (503): unavailable"), true},
+		{"request timeout status", errors.New("anthropic API error (408): timeout"), true},
+		{"deadline", fmt.Errorf("provider request timed out: %w", context.DeadlineExceeded), true},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"bad request", errors.New("openai API error (400): invalid model"), false},
+		{"unauthorized", errors.New("anthropic API error (401): invalid x-api-key"), false},
+		{"unprocessable", errors.New("Error 422, Message: bad schema"), false},
+		{"canceled", fmt.Errorf("call: %w", context.Canceled), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.expected {
+				t.Errorf("IsRetryableError(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithRetryable(t *testing.T) {
+	provider := &scriptedErrProvider{errs: []error{errors.New("openai API error (401): bad key")}}
+	a, err := New(provider, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Run(context.Background(), "hi"); err == nil || !strings.Contains(err.Error(), "not retryable") {
+		t.Fatalf("expected non-retryable failure, got %v", err)
+	}
+
+	provider = &scriptedErrProvider{errs: []error{errors.New("openai API error (401): bad key")}}
+	a, err = New(provider,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		WithRetryable(func(error) bool { return true }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := a.Run(context.Background(), "hi"); err != nil || out != "ok" {
+		t.Fatalf("custom predicate should retry 401: %q, %v", out, err)
+	}
+}
@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"strings"
+	"sync"
+)
+
+// Tokenizer counts the tokens a model would see for a piece of text.
+// Implementations must be safe for concurrent use.
+type Tokenizer interface {
+	CountTokens(model, text string) int
+}
+
+// HeuristicTokenizer estimates tokens at ~4 characters per token. It is the
+// default when no tokenizer is configured.
+type HeuristicTokenizer struct{}
+
+func (HeuristicTokenizer) CountTokens(_ string, text string) int {
+	return EstimateTokens(text)
+}
+
+// BPEEncoding is the subset of a byte-pair encoding used for counting. The
+// SDK does not bundle BPE vocabularies; bring your own. The signature matches
+// the Encode method of github.com/pkoukk/tiktoken-go, so its encodings can be
+// used without an adapter type.
+type BPEEncoding interface {
+	Encode(text string, allowedSpecial, disallowedSpecial []string) []int
+}
+
+// EncodingResolver returns the encoding for a model name. An empty model
+// should resolve to a reasonable default encoding (e.g. cl100k_base).
+type EncodingResolver func(model string) (BPEEncoding, error)
+
+// BPETokenizer counts tokens with a caller-supplied BPE encoding resolved
+// per model. Models that fail to resolve fall back to the heuristic estimate.
+type BPETokenizer struct {
+	resolve  EncodingResolver
+	fallback Tokenizer
+
+	mu        sync.Mutex
+	encodings map[string]BPEEncoding
+}
+
+// NewBPETokenizer wraps resolve, caching one encoding per model. With
+// tiktoken-go:
+//
+//	tok := agent.NewBPETokenizer(func(model string) (agent.BPEEncoding, error) {
+//		if model == "" {
+//			return tiktoken.GetEncoding("cl100k_base")
+//		}
+//		return tiktoken.EncodingForModel(model)
+//	})
+func NewBPETokenizer(resolve EncodingResolver) *BPETokenizer {
+	return &BPETokenizer{
+		resolve:   resolve,
+		fallback:  HeuristicTokenizer{},
+		encodings: make(map[string]BPEEncoding),
+	}
+}
+
+func (t *BPETokenizer) CountTokens(model, text string) int {
+	if text == "" {
+		return 0
+	}
+	enc := t.encoding(model)
+	if enc == nil {
+		return t.fallback.CountTokens(model, text)
+	}
+	return len(enc.Encode(text, nil, nil))
+}
+
+func (t *BPETokenizer) encoding(model string) BPEEncoding {
+	if t == nil || t.resolve == nil {
+		return nil
+	}
+	model = strings.TrimSpace(model)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if enc, ok := t.encodings[model]; ok {
+		return enc
+	}
+	enc, err := t.resolve(model)
+	if err != nil {
+		enc = nil
+	}
+	// Cache failures too so unknown models don't re-resolve on every count.
+	t.encodings[model] = enc
+	return enc
+}
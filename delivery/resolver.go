@@ -0,0 +1,107 @@
+package delivery
+
+import "context"
+
+const resolvedTargetsContextKey contextKey = "delivery.targets"
+
+// TargetResolver expands a logical reply target into the concrete targets a
+// run's result should be delivered to, e.g. fanning out to Slack and a
+// webhook based on target metadata or per-user preferences.
+type TargetResolver interface {
+	Resolve(ctx context.Context, target *Target) ([]*Target, error)
+}
+
+// ResolverFunc adapts a function to TargetResolver.
+type ResolverFunc func(ctx context.Context, target *Target) ([]*Target, error)
+
+func (f ResolverFunc) Resolve(ctx context.Context, target *Target) ([]*Target, error) {
+	return f(ctx, target)
+}
+
+// Resolve expands target with r. A nil resolver or nil target yields the
+// target itself. Results are normalized; empty and duplicate targets are
+// dropped.
+func Resolve(ctx context.Context, r TargetResolver, target *Target) ([]*Target, error) {
+	target = Normalize(target)
+	if target == nil {
+		return nil, nil
+	}
+	if r == nil {
+		return []*Target{target}, nil
+	}
+	resolved, err := r.Resolve(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Target, 0, len(resolved))
+	for _, t := range resolved {
+		t = Normalize(t)
+		if t == nil || containsTarget(out, t) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// WithTargets stores already-resolved delivery targets on context.
+func WithTargets(ctx context.Context, targets []*Target) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	out := make([]*Target, 0, len(targets))
+	for _, t := range targets {
+		if t = Normalize(t); t != nil {
+			out = append(out, t)
+		}
+	}
+	return context.WithValue(ctx, resolvedTargetsContextKey, out)
+}
+
+// TargetsFromContext returns the resolved delivery targets on context. When
+// none were stored it falls back to the single reply target, if any.
+func TargetsFromContext(ctx context.Context) []*Target {
+	if ctx == nil {
+		return nil
+	}
+	if targets, ok := ctx.Value(resolvedTargetsContextKey).([]*Target); ok {
+		return append([]*Target(nil), targets...)
+	}
+	if target := FromContext(ctx); target != nil {
+		return []*Target{target}
+	}
+	return nil
+}
+
+// Equal reports whether a and b route to the same place after
+// normalization.
+func Equal(a, b *Target) bool {
+	a, b = Normalize(a), Normalize(b)
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Channel == b.Channel && a.Destination == b.Destination &&
+		a.ThreadID == b.ThreadID && a.UserID == b.UserID &&
+		sameMetadata(a.Metadata, b.Metadata)
+}
+
+func containsTarget(list []*Target, t *Target) bool {
+	for _, existing := range list {
+		if Equal(existing, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameMetadata(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
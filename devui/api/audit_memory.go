@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryAuditStore struct {
+	mu      sync.RWMutex
+	entries []AuditLogEntry
+	nextID  int64
+}
+
+// NewMemoryAuditStore returns an AuditReader that keeps entries in memory,
+// for tests and ephemeral dev servers. It lists newest first with the same
+// limit/offset semantics as the SQLite store.
+func NewMemoryAuditStore() AuditReader {
+	return &memoryAuditStore{}
+}
+
+func (s *memoryAuditStore) Record(_ context.Context, entry AuditLog) error {
+	if entry.Action == "" || entry.Resource == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	s.entries = append(s.entries, AuditLogEntry{
+		ID:         s.nextID,
+		ActorKeyID: entry.ActorKeyID,
+		Action:     entry.Action,
+		Resource:   entry.Resource,
+		Payload:    entry.Payload,
+		CreatedAt:  time.Now().UTC(),
+	})
+	return nil
+}
+
+func (s *memoryAuditStore) List(_ context.Context, limit int, offset int) ([]AuditLogEntry, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// Entries are appended in time order, so newest-first is a reverse walk.
+	out := make([]AuditLogEntry, 0, min(limit, len(s.entries)))
+	for i := len(s.entries) - 1 - offset; i >= 0 && len(out) < limit; i-- {
+		out = append(out, s.entries[i])
+	}
+	return out, nil
+}
+
+func (s *memoryAuditStore) Close() error { return nil }
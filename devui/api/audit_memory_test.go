@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMemoryAuditStore_RecordAndList(t *testing.T) {
+	store := NewMemoryAuditStore()
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		if err := store.Record(ctx, AuditLog{ActorKeyID: "key", Action: "run.create", Resource: fmt.Sprintf("run-%d", i)}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	if err := store.Record(ctx, AuditLog{Action: "", Resource: "ignored"}); err != nil {
+		t.Fatalf("record without action: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		limit  int
+		offset int
+		want   []string
+	}{
+		{"newest first", 2, 0, []string{"run-5", "run-4"}},
+		{"offset", 2, 3, []string{"run-2", "run-1"}},
+		{"default limit", 0, 0, []string{"run-5", "run-4", "run-3", "run-2", "run-1"}},
+		{"negative offset", 1, -1, []string{"run-5"}},
+		{"past the end", 10, 5, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.List(ctx, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d", len(got), len(tt.want))
+			}
+			for i, e := range got {
+				if e.Resource != tt.want[i] {
+					t.Errorf("entry %d = %q, want %q", i, e.Resource, tt.want[i])
+				}
+				if e.ID == 0 || e.CreatedAt.IsZero() || e.ActorKeyID != "key" {
+					t.Errorf("entry %d missing fields: %+v", i, e)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryAuditStore_Concurrent(t *testing.T) {
+	store := NewMemoryAuditStore()
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = store.Record(ctx, AuditLog{Action: "a", Resource: fmt.Sprint(i)})
+			_, _ = store.List(ctx, 10, 0)
+		}(i)
+	}
+	wg.Wait()
+	got, _ := store.List(ctx, 100, 0)
+	if len(got) != 50 {
+		t.Fatalf("got %d entries, want 50", len(got))
+	}
+	seen := map[int64]bool{}
+	for _, e := range got {
+		if seen[e.ID] {
+			t.Fatalf("duplicate ID %d", e.ID)
+		}
+		seen[e.ID] = true
+	}
+}
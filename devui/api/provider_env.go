@@ -21,6 +21,7 @@ var providerEnvFields = []providerEnvField{
 	{Key: "OPENAI_API_KEY", Secret: true},
 	{Key: "OPENAI_MODEL", Secret: false},
 	{Key: "OPENAI_BASE_URL", Secret: false},
+	{Key: "AGENT_OPENAI_BASE_URL", Secret: false},
 	{Key: "ANTHROPIC_API_KEY", Secret: true},
 	{Key: "ANTHROPIC_MODEL", Secret: false},
 	{Key: "ANTHROPIC_BASE_URL", Secret: false},
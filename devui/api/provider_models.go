@@ -10,6 +10,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	providerfactory "github.com/PipeOpsHQ/agent-sdk-go/providers/factory"
 )
 
 type providerModelsResponse struct {
@@ -91,7 +93,7 @@ func fetchOpenAIModels() ([]string, string, error) {
 	if apiKey == "" {
 		return nil, "fallback", fmt.Errorf("OPENAI_API_KEY not configured")
 	}
-	base := strings.TrimSpace(os.Getenv("OPENAI_BASE_URL"))
+	base := providerfactory.OpenAIBaseURL()
 	if base == "" {
 		base = "https://api.openai.com"
 	}
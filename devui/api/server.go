@@ -10,6 +10,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"github.com/PipeOpsHQ/agent-sdk-go/flow"
 	"github.com/PipeOpsHQ/agent-sdk-go/observe"
 	observestore "github.com/PipeOpsHQ/agent-sdk-go/observe/store"
+	"github.com/PipeOpsHQ/agent-sdk-go/redact"
 	cronpkg "github.com/PipeOpsHQ/agent-sdk-go/runtime/cron"
 	"github.com/PipeOpsHQ/agent-sdk-go/state"
 	fwtools "github.com/PipeOpsHQ/agent-sdk-go/tools"
@@ -171,6 +173,7 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("/api/v1/commands/execute", s.require(auth.RoleViewer, s.handleCommandExecute))
 
 	s.mux.HandleFunc("/api/v1/tools/registry", s.require(auth.RoleViewer, s.handleToolRegistry))
+	s.mux.HandleFunc("/api/v1/tools/registry/", s.require(auth.RoleOperator, s.handleToolRegistryAction))
 	s.mux.HandleFunc("/api/v1/tools/intelligence", s.require(auth.RoleViewer, s.handleToolIntelligence))
 	s.mux.HandleFunc("/api/v1/tools/templates", s.require(auth.RoleViewer, s.handleToolTemplates))
 	s.mux.HandleFunc("/api/v1/tools/instances", s.require(auth.RoleViewer, s.handleToolInstances))
@@ -301,6 +304,7 @@ func (s *Server) handleRuns(w http.ResponseWriter, r *http.Request, _ principal)
 		Status:    strings.TrimSpace(r.URL.Query().Get("status")),
 		Limit:     parseInt(r.URL.Query().Get("limit"), 100),
 		Offset:    parseInt(r.URL.Query().Get("offset"), 0),
+		Metadata:  metadataFilter(r.URL.Query()),
 	}
 	runs, err := s.cfg.StateStore.ListRuns(r.Context(), q)
 	if err != nil {
@@ -310,6 +314,22 @@ func (s *Server) handleRuns(w http.ResponseWriter, r *http.Request, _ principal)
 	writeJSON(w, http.StatusOK, runs)
 }
 
+// metadataFilter collects meta.<key>=<value> query parameters.
+func metadataFilter(values url.Values) map[string]string {
+	var filter map[string]string
+	for name, vals := range values {
+		key, ok := strings.CutPrefix(name, "meta.")
+		if !ok || strings.TrimSpace(key) == "" || len(vals) == 0 {
+			continue
+		}
+		if filter == nil {
+			filter = map[string]string{}
+		}
+		filter[key] = strings.TrimSpace(vals[0])
+	}
+	return filter
+}
+
 func (s *Server) handleRunSubresources(w http.ResponseWriter, r *http.Request, p principal) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/v1/runs/")
 	parts := splitPath(path)
@@ -946,7 +966,7 @@ func (s *Server) handleToolRegistry(w http.ResponseWriter, r *http.Request, _ pr
 		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
 		return
 	}
-	tools := fwtools.ToolCatalog()
+	tools := fwtools.Registry()
 	bundles := fwtools.BundleCatalog()
 	writeJSON(w, http.StatusOK, map[string]any{
 		"tools":       tools,
@@ -956,6 +976,35 @@ func (s *Server) handleToolRegistry(w http.ResponseWriter, r *http.Request, _ pr
 	})
 }
 
+// handleToolRegistryAction serves POST /api/v1/tools/registry/{name}/{enable|disable}.
+func (s *Server) handleToolRegistryAction(w http.ResponseWriter, r *http.Request, p principal) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+		return
+	}
+	parts := splitPath(strings.TrimPrefix(r.URL.Path, "/api/v1/tools/registry/"))
+	if len(parts) != 2 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unsupported tools endpoint"))
+		return
+	}
+	name, action := parts[0], parts[1]
+	if !fwtools.ToolExists(name) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("tool %q not found", name))
+		return
+	}
+	switch action {
+	case "disable":
+		fwtools.Disable(name)
+	case "enable":
+		fwtools.Enable(name)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("unsupported tools action %q", action))
+		return
+	}
+	s.audit(r.Context(), p, "tool."+action, "tools", map[string]any{"name": name})
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "name": name, "disabled": fwtools.IsDisabled(name)})
+}
+
 func (s *Server) handleToolInstances(w http.ResponseWriter, r *http.Request, p principal) {
 	if s.cfg.CatalogStore == nil {
 		writeError(w, http.StatusNotImplemented, fmt.Errorf("catalog store not configured"))
@@ -1303,15 +1352,32 @@ func (s *Server) audit(ctx context.Context, p principal, action string, resource
 	if s == nil || s.cfg.AuditStore == nil {
 		return
 	}
-	body, _ := json.Marshal(payload)
 	_ = s.cfg.AuditStore.Record(ctx, AuditLog{
 		ActorKeyID: p.KeyID,
 		Action:     action,
 		Resource:   resource,
-		Payload:    string(body),
+		Payload:    auditPayload(payload),
 	})
 }
 
+// auditPayload encodes payload as JSON with secrets scrubbed. Redaction runs
+// on the decoded values so the stored payload stays valid JSON.
+func auditPayload(payload any) string {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	var generic any
+	if err := json.Unmarshal(body, &generic); err != nil {
+		return redact.Redact(string(body))
+	}
+	body, err = json.Marshal(redact.Value(generic, redact.Replacement))
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
 func (s *Server) Emit(event observe.Event) {
 	event.Normalize()
 	s.stream.publish(event)
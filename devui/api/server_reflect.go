@@ -83,7 +83,7 @@ func (s *Server) handleReflect(w http.ResponseWriter, r *http.Request, _ princip
 			"source":       sk.Source,
 			"allowedTools": sk.AllowedTools,
 		}
-		for k, v := range sk.Metadata {
+		for k, v := range sk.RedactedMetadata() {
 			meta[k] = v
 		}
 		a := Action{
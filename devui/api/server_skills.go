@@ -35,7 +35,8 @@ func (s *Server) handleSkillsList(w http.ResponseWriter, _ *http.Request) {
 			"description":  sk.Description,
 			"license":      sk.License,
 			"allowedTools": sk.AllowedTools,
-			"metadata":     sk.Metadata,
+			"requires":     sk.Requires,
+			"metadata":     sk.RedactedMetadata(),
 			"source":       sk.Source,
 			"path":         sk.Path,
 		})
@@ -63,7 +64,12 @@ func (s *Server) handleSkillsInstall(w http.ResponseWriter, r *http.Request) {
 		destDir = "./skills"
 	}
 
-	count, err := skill.InstallFromGitHub(req.RepoURL, destDir)
+	installed := []string{}
+	count, err := skill.InstallFromGitHub(r.Context(), req.RepoURL, destDir, skill.WithProgress(func(ev skill.InstallProgress) {
+		if ev.Stage == skill.InstallStageInstalled {
+			installed = append(installed, ev.Skill)
+		}
+	}))
 	if err != nil {
 		msg := strings.ToLower(err.Error())
 		status := http.StatusInternalServerError
@@ -77,6 +83,7 @@ func (s *Server) handleSkillsInstall(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]any{
 		"status":  "installed",
 		"count":   count,
+		"skills":  installed,
 		"repoUrl": req.RepoURL,
 	})
 }
@@ -100,7 +107,8 @@ func (s *Server) handleSkillByName(w http.ResponseWriter, r *http.Request, p pri
 			"description":  sk.Description,
 			"license":      sk.License,
 			"allowedTools": sk.AllowedTools,
-			"metadata":     sk.Metadata,
+			"requires":     sk.Requires,
+			"metadata":     sk.RedactedMetadata(),
 			"instructions": sk.Instructions,
 			"source":       sk.Source,
 			"path":         sk.Path,
@@ -0,0 +1,363 @@
+// Package client is a typed Go client for the DevUI HTTP API.
+//
+//	c := client.New("http://127.0.0.1:7070", client.WithAPIKey(key))
+//	runs, err := c.ListRuns(ctx, client.ListRunsOptions{Status: "failed", Limit: 20})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/devui/api"
+	"github.com/PipeOpsHQ/agent-sdk-go/runtime/cron"
+	"github.com/PipeOpsHQ/agent-sdk-go/state"
+	"github.com/PipeOpsHQ/agent-sdk-go/tools"
+)
+
+const maxResponseBytes = 8 << 20
+
+// APIError is returned when the server answers with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("devui api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+type Option func(*Client)
+
+// WithAPIKey sends key in the X-API-Key header.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = strings.TrimSpace(key) }
+}
+
+// WithHTTPClient replaces the default client (60s timeout).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.http = hc
+		}
+	}
+}
+
+// New returns a client for the DevUI server at baseURL, e.g.
+// "http://127.0.0.1:7070".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+		http:    &http.Client{Timeout: 60 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Do sends a request to path (e.g. "/api/v1/runs") and decodes a JSON
+// response into out when out is non-nil. body, if non-nil, is sent as JSON.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeAPIError(resp.StatusCode, raw)
+	}
+	if out == nil || len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+func decodeAPIError(status int, raw []byte) error {
+	var payload struct {
+		Error string `json:"error"`
+	}
+	msg := strings.TrimSpace(string(raw))
+	if err := json.Unmarshal(raw, &payload); err == nil && payload.Error != "" {
+		msg = payload.Error
+	}
+	return &APIError{StatusCode: status, Message: msg}
+}
+
+// ListRunsOptions filters ListRuns. Metadata matches meta.<key>=<value>.
+type ListRunsOptions struct {
+	SessionID string
+	Status    string
+	Limit     int
+	Offset    int
+	Metadata  map[string]string
+}
+
+func (o ListRunsOptions) values() url.Values {
+	q := url.Values{}
+	if o.SessionID != "" {
+		q.Set("session_id", o.SessionID)
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	for k, v := range o.Metadata {
+		q.Set("meta."+k, v)
+	}
+	return q
+}
+
+func (c *Client) ListRuns(ctx context.Context, opts ListRunsOptions) ([]state.RunRecord, error) {
+	var runs []state.RunRecord
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/runs", opts.values(), nil, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func (c *Client) GetRun(ctx context.Context, runID string) (state.RunRecord, error) {
+	var run state.RunRecord
+	err := c.Do(ctx, http.MethodGet, "/api/v1/runs/"+url.PathEscape(runID), nil, nil, &run)
+	return run, err
+}
+
+func (c *Client) ListCheckpoints(ctx context.Context, runID string, limit int) ([]state.CheckpointRecord, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	var rows []state.CheckpointRecord
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/runs/"+url.PathEscape(runID)+"/checkpoints", q, nil, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CancelRun cancels a distributed run.
+func (c *Client) CancelRun(ctx context.Context, runID string) error {
+	return c.Do(ctx, http.MethodPost, "/api/v1/runtime/runs/"+url.PathEscape(runID)+"/cancel", nil, nil, nil)
+}
+
+// RequeueRun requeues a distributed run.
+func (c *Client) RequeueRun(ctx context.Context, runID string) error {
+	return c.Do(ctx, http.MethodPost, "/api/v1/runtime/runs/"+url.PathEscape(runID)+"/requeue", nil, nil, nil)
+}
+
+// ActionResult is the response of RunAction. Status is "success" or
+// "error" for tools and prompts, or the run status for flows.
+type ActionResult struct {
+	Key        string `json:"key"`
+	Status     string `json:"status"`
+	Output     any    `json:"output,omitempty"`
+	Error      string `json:"error,omitempty"`
+	RunID      string `json:"runId,omitempty"`
+	SessionID  string `json:"sessionId,omitempty"`
+	Provider   string `json:"provider,omitempty"`
+	DurationMS int64  `json:"duration"`
+}
+
+// RunAction executes an action by key, e.g. "/tool/file_system" or
+// "/flow/code-reviewer". A failed action is reported in ActionResult.Error,
+// not as a Go error.
+func (c *Client) RunAction(ctx context.Context, key string, input any) (ActionResult, error) {
+	body := map[string]any{"key": key}
+	if input != nil {
+		body["input"] = input
+	}
+	var out ActionResult
+	err := c.Do(ctx, http.MethodPost, "/api/v1/actions/run", nil, body, &out)
+	return out, err
+}
+
+func (c *Client) RunPlayground(ctx context.Context, req api.PlaygroundRequest) (api.PlaygroundResponse, error) {
+	var out api.PlaygroundResponse
+	err := c.Do(ctx, http.MethodPost, "/api/v1/playground/run", nil, req, &out)
+	return out, err
+}
+
+// Skill is an installed skill as listed by the server.
+type Skill struct {
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	License      string            `json:"license,omitempty"`
+	AllowedTools []string          `json:"allowedTools,omitempty"`
+	Requires     []string          `json:"requires,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Instructions string            `json:"instructions,omitempty"`
+	Source       string            `json:"source,omitempty"`
+	Path         string            `json:"path,omitempty"`
+}
+
+func (c *Client) ListSkills(ctx context.Context) ([]Skill, error) {
+	var out struct {
+		Skills []Skill `json:"skills"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/skills", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Skills, nil
+}
+
+func (c *Client) GetSkill(ctx context.Context, name string) (Skill, error) {
+	var out Skill
+	err := c.Do(ctx, http.MethodGet, "/api/v1/skills/"+url.PathEscape(name), nil, nil, &out)
+	return out, err
+}
+
+// SkillInstallResult lists the skills installed from a repository.
+type SkillInstallResult struct {
+	Status  string   `json:"status"`
+	Count   int      `json:"count"`
+	Skills  []string `json:"skills"`
+	RepoURL string   `json:"repoUrl"`
+}
+
+// InstallSkill installs skills from a GitHub repo ("owner/repo" or URL).
+// An empty destDir uses the server default.
+func (c *Client) InstallSkill(ctx context.Context, repoURL, destDir string) (SkillInstallResult, error) {
+	var out SkillInstallResult
+	err := c.Do(ctx, http.MethodPost, "/api/v1/skills", nil, map[string]string{"repoUrl": repoURL, "destDir": destDir}, &out)
+	return out, err
+}
+
+func (c *Client) RemoveSkill(ctx context.Context, name string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/skills/"+url.PathEscape(name), nil, nil, nil)
+}
+
+func (c *Client) ListCronJobs(ctx context.Context) ([]cron.Job, error) {
+	var jobs []cron.Job
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/cron/jobs", nil, nil, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (c *Client) GetCronJob(ctx context.Context, name string) (cron.Job, error) {
+	var job cron.Job
+	err := c.Do(ctx, http.MethodGet, "/api/v1/cron/jobs/"+url.PathEscape(name), nil, nil, &job)
+	return job, err
+}
+
+func (c *Client) CreateCronJob(ctx context.Context, name, cronExpr string, cfg cron.JobConfig) (cron.Job, error) {
+	body := map[string]any{"name": name, "cronExpr": cronExpr, "config": cfg}
+	var job cron.Job
+	err := c.Do(ctx, http.MethodPost, "/api/v1/cron/jobs", nil, body, &job)
+	return job, err
+}
+
+func (c *Client) DeleteCronJob(ctx context.Context, name string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/cron/jobs/"+url.PathEscape(name), nil, nil, nil)
+}
+
+func (c *Client) SetCronJobEnabled(ctx context.Context, name string, enabled bool) (cron.Job, error) {
+	var job cron.Job
+	err := c.Do(ctx, http.MethodPatch, "/api/v1/cron/jobs/"+url.PathEscape(name), nil, map[string]bool{"enabled": enabled}, &job)
+	return job, err
+}
+
+// CronTriggerResult is the outcome of a manual cron trigger.
+type CronTriggerResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Output string `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (c *Client) TriggerCronJob(ctx context.Context, name string) (CronTriggerResult, error) {
+	var out CronTriggerResult
+	err := c.Do(ctx, http.MethodPost, "/api/v1/cron/jobs/"+url.PathEscape(name)+"/trigger", nil, nil, &out)
+	return out, err
+}
+
+func (c *Client) CronJobHistory(ctx context.Context, name string, limit int) ([]cron.JobRun, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	var out struct {
+		Runs []cron.JobRun `json:"runs"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/cron/jobs/"+url.PathEscape(name)+"/history", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Runs, nil
+}
+
+func (c *Client) ToolRegistry(ctx context.Context) ([]tools.ToolDescriptor, error) {
+	var out struct {
+		Tools []tools.ToolDescriptor `json:"tools"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/tools/registry", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Tools, nil
+}
+
+func (c *Client) EnableTool(ctx context.Context, name string) error {
+	return c.Do(ctx, http.MethodPost, "/api/v1/tools/registry/"+url.PathEscape(name)+"/enable", nil, nil, nil)
+}
+
+func (c *Client) DisableTool(ctx context.Context, name string) error {
+	return c.Do(ctx, http.MethodPost, "/api/v1/tools/registry/"+url.PathEscape(name)+"/disable", nil, nil, nil)
+}
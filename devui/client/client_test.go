@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/devui/api"
+	"github.com/PipeOpsHQ/agent-sdk-go/runtime/cron"
+	"github.com/PipeOpsHQ/agent-sdk-go/state"
+	statememory "github.com/PipeOpsHQ/agent-sdk-go/state/memory"
+)
+
+func newTestClient(t *testing.T) (*Client, state.Store) {
+	t.Helper()
+	store := statememory.New()
+	sched := cron.New(func(cfg cron.JobConfig) (string, error) { return "ran: " + cfg.Input, nil })
+	srv := api.NewServer(api.Config{StateStore: store, Scheduler: sched, AllowLocalNoAuth: true})
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+	return New(ts.URL), store
+}
+
+func TestClient_Runs(t *testing.T) {
+	c, store := newTestClient(t)
+	ctx := context.Background()
+	for _, r := range []state.RunRecord{
+		{RunID: "r1", SessionID: "s1", Status: "completed", Metadata: map[string]any{"team": "red"}},
+		{RunID: "r2", SessionID: "s1", Status: "failed", Metadata: map[string]any{"team": "blue"}},
+	} {
+		if err := store.SaveRun(ctx, r); err != nil {
+			t.Fatalf("SaveRun: %v", err)
+		}
+	}
+
+	runs, err := c.ListRuns(ctx, ListRunsOptions{Metadata: map[string]string{"team": "blue"}})
+	if err != nil {
+		t.Fatalf("ListRuns: %v", err)
+	}
+	if len(runs) != 1 || runs[0].RunID != "r2" {
+		t.Fatalf("ListRuns = %+v, want [r2]", runs)
+	}
+	run, err := c.GetRun(ctx, "r1")
+	if err != nil || run.Status != "completed" {
+		t.Fatalf("GetRun = %+v, %v", run, err)
+	}
+	_, err = c.GetRun(ctx, "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("GetRun(missing) err = %v, want 404 APIError", err)
+	}
+}
+
+func TestClient_CronJobs(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	job, err := c.CreateCronJob(ctx, "nightly", "0 3 * * *", cron.JobConfig{Input: "report"})
+	if err != nil {
+		t.Fatalf("CreateCronJob: %v", err)
+	}
+	if job.Name != "nightly" || !job.Enabled {
+		t.Fatalf("created job = %+v", job)
+	}
+	_, err = c.CreateCronJob(ctx, "nightly", "0 3 * * *", cron.JobConfig{Input: "report"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict || apiErr.Message == "" {
+		t.Fatalf("duplicate create err = %v, want 409 APIError", err)
+	}
+
+	res, err := c.TriggerCronJob(ctx, "nightly")
+	if err != nil || res.Status != "completed" || res.Output != "ran: report" {
+		t.Fatalf("TriggerCronJob = %+v, %v", res, err)
+	}
+	jobs, err := c.ListCronJobs(ctx)
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("ListCronJobs = %+v, %v", jobs, err)
+	}
+	if err := c.DeleteCronJob(ctx, "nightly"); err != nil {
+		t.Fatalf("DeleteCronJob: %v", err)
+	}
+	if _, err := c.GetCronJob(ctx, "nightly"); !IsNotFound(err) {
+		t.Fatalf("GetCronJob after delete err = %v", err)
+	}
+}
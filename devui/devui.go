@@ -55,12 +55,12 @@ import (
 	authsqlite "github.com/PipeOpsHQ/agent-sdk-go/devui/auth/sqlite"
 	catalogsqlite "github.com/PipeOpsHQ/agent-sdk-go/devui/catalog/sqlite"
 	"github.com/PipeOpsHQ/agent-sdk-go/flow"
-	"github.com/PipeOpsHQ/agent-sdk-go/graph"
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/basic"     // registers "basic" workflow
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/chain"     // registers "chain" workflow
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/mapreduce" // registers "map-reduce" workflow
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/router"    // registers "router" workflow
 	"github.com/PipeOpsHQ/agent-sdk-go/guardrail"
+	"github.com/PipeOpsHQ/agent-sdk-go/llm"
 	"github.com/PipeOpsHQ/agent-sdk-go/observe"
 	observesqlite "github.com/PipeOpsHQ/agent-sdk-go/observe/store/sqlite"
 	providerfactory "github.com/PipeOpsHQ/agent-sdk-go/providers/factory"
@@ -71,9 +71,9 @@ import (
 	"github.com/PipeOpsHQ/agent-sdk-go/skill"
 	"github.com/PipeOpsHQ/agent-sdk-go/state"
 	statefactory "github.com/PipeOpsHQ/agent-sdk-go/state/factory"
+	"github.com/PipeOpsHQ/agent-sdk-go/storage"
 	"github.com/PipeOpsHQ/agent-sdk-go/tools"
 	fwtypes "github.com/PipeOpsHQ/agent-sdk-go/types"
-	"github.com/PipeOpsHQ/agent-sdk-go/workflow"
 )
 
 // Options configures the DevUI server.
@@ -212,6 +212,9 @@ func Start(ctx context.Context, opts ...Options) error {
 		defer async.Close()
 	}
 
+	// Artifact saves from tools show up in run traces.
+	storage.Default().SetObserver(observer)
+
 	// Playground runner
 	playground := &playgroundRunner{store: store, observer: observer}
 
@@ -466,18 +469,25 @@ type playgroundRunner struct {
 	observer observe.Sink
 }
 
-func (r *playgroundRunner) Run(ctx context.Context, req devuiapi.PlaygroundRequest) (devuiapi.PlaygroundResponse, error) {
-	provider, err := providerfactory.FromEnv(ctx)
-	if err != nil {
-		return devuiapi.PlaygroundResponse{}, fmt.Errorf("provider setup failed: %w", err)
-	}
+// playgroundRun is an agent built for one playground request.
+type playgroundRun struct {
+	agent         *agentfw.Agent
+	def           *flow.Definition
+	ctx           context.Context
+	appliedSkills []string
+}
 
-	// Resolve flow defaults — request fields override flow defaults.
+// prepare merges flow defaults into req and builds the agent with
+// flow.BuildAgent. Request fields override flow defaults; unknown skills are
+// listed as applied but otherwise ignored.
+func (r *playgroundRunner) prepare(ctx context.Context, provider llm.Provider, req *devuiapi.PlaygroundRequest) (*playgroundRun, error) {
 	var flowSkills []string
+	var wfParams map[string]any
 	if name := strings.TrimSpace(req.Flow); name != "" {
 		if f, ok := flow.Get(name); ok {
 			if strings.TrimSpace(req.Workflow) == "" {
 				req.Workflow = f.Workflow
+				wfParams = f.WorkflowParams
 			}
 			if len(req.Tools) == 0 {
 				req.Tools = f.Tools
@@ -489,37 +499,28 @@ func (r *playgroundRunner) Run(ctx context.Context, req devuiapi.PlaygroundReque
 		}
 	}
 
-	wfName := strings.TrimSpace(req.Workflow)
-	systemPrompt := strings.TrimSpace(req.SystemPrompt)
-	if systemPrompt == "" {
-		systemPrompt = "You are a practical AI assistant. Be concise, accurate, and actionable."
-	}
-
-	// Resolve skills — merge flow skills + request skills, then inject instructions + tools
 	allSkills := make(map[string]bool)
-	for _, s := range flowSkills {
-		s = strings.TrimSpace(s)
-		if s != "" {
-			allSkills[s] = true
-		}
-	}
-	for _, s := range req.Skills {
-		s = strings.TrimSpace(s)
-		if s != "" {
+	for _, s := range append(append([]string(nil), flowSkills...), req.Skills...) {
+		if s = strings.TrimSpace(s); s != "" {
 			allSkills[s] = true
 		}
 	}
 	appliedSkills := sortedSkillNames(allSkills)
-	for skillName := range allSkills {
-		if s, ok := skill.Get(skillName); ok {
-			if s.Instructions != "" {
-				systemPrompt += "\n\n## Skill: " + s.Name + "\n" + s.Instructions
-			}
-			if len(s.AllowedTools) > 0 {
-				req.Tools = append(req.Tools, s.AllowedTools...)
-			}
+	var known []string
+	for _, name := range appliedSkills {
+		if _, ok := skill.Get(name); ok {
+			known = append(known, name)
 		}
 	}
+
+	wfName := strings.TrimSpace(req.Workflow)
+	if alias, ok := workflowAlias(wfName); ok {
+		wfName = alias
+	}
+	systemPrompt := strings.TrimSpace(req.SystemPrompt)
+	if systemPrompt == "" {
+		systemPrompt = flow.DefaultSystemPrompt
+	}
 	req.ReplyTo = delivery.Normalize(req.ReplyTo)
 	if req.ReplyTo != nil {
 		systemPrompt = strings.TrimSpace(systemPrompt + "\n\n" + buildReplyChannelHint(req.ReplyTo))
@@ -527,13 +528,19 @@ func (r *playgroundRunner) Run(ctx context.Context, req devuiapi.PlaygroundReque
 	runCtx := delivery.WithTarget(ctx, req.ReplyTo)
 	runCtx = delivery.WithTurnType(runCtx, "user")
 
-	// Build agent
-	agentOpts := []agentfw.Option{
-		agentfw.WithSystemPrompt(systemPrompt),
-		agentfw.WithMaxIterations(25),
+	def := &flow.Definition{
+		Name:           strings.TrimSpace(req.Flow),
+		SystemPrompt:   systemPrompt,
+		Tools:          req.Tools,
+		Skills:         known,
+		Workflow:       wfName,
+		WorkflowParams: wfParams,
+	}
+	if def.Name == "" {
+		def.Name = "playground"
 	}
 
-	// Wire guardrails if requested
+	extra := []agentfw.Option{agentfw.WithMaxIterations(25)}
 	if len(req.Guardrails) > 0 {
 		pipeline := guardrail.NewPipeline()
 		for _, name := range req.Guardrails {
@@ -552,13 +559,12 @@ func (r *playgroundRunner) Run(ctx context.Context, req devuiapi.PlaygroundReque
 				pipeline.Add(&guardrail.SecretGuard{})
 			}
 		}
-		agentOpts = append(agentOpts, agentfw.WithMiddleware(guardrail.NewAgentMiddleware(pipeline)))
+		extra = append(extra, agentfw.WithMiddleware(guardrail.NewAgentMiddleware(pipeline)))
 	}
 
 	// Session continuity — reuse session ID and load previous conversation.
-	sessionID := strings.TrimSpace(req.SessionID)
-	if sessionID != "" {
-		agentOpts = append(agentOpts, agentfw.WithSessionID(sessionID))
+	if sessionID := strings.TrimSpace(req.SessionID); sessionID != "" {
+		extra = append(extra, agentfw.WithSessionID(sessionID))
 		// Load previous messages from the most recent completed run in this session.
 		if r.store != nil {
 			prevRuns, _ := r.store.ListRuns(ctx, state.ListRunsQuery{
@@ -567,69 +573,59 @@ func (r *playgroundRunner) Run(ctx context.Context, req devuiapi.PlaygroundReque
 				Limit:     1,
 			})
 			if len(prevRuns) > 0 && len(prevRuns[0].Messages) > 0 {
-				agentOpts = append(agentOpts, agentfw.WithConversationHistory(prevRuns[0].Messages))
+				extra = append(extra, agentfw.WithConversationHistory(prevRuns[0].Messages))
 			}
 		}
 	}
 
-	if r.store != nil {
-		agentOpts = append(agentOpts, agentfw.WithStore(r.store))
-	}
-	if r.observer != nil {
-		agentOpts = append(agentOpts, agentfw.WithObserver(r.observer))
+	agent, err := flow.BuildAgent(provider, r.store, r.observer, def, extra...)
+	if err != nil {
+		return nil, fmt.Errorf("agent create failed: %w", err)
 	}
+	return &playgroundRun{agent: agent, def: def, ctx: runCtx, appliedSkills: appliedSkills}, nil
+}
 
-	// Attach tools
-	if len(req.Tools) > 0 {
-		selected, err := tools.BuildSelection(req.Tools)
-		if err != nil {
-			return devuiapi.PlaygroundResponse{}, fmt.Errorf("tool selection failed: %w", err)
-		}
-		for _, t := range selected {
-			agentOpts = append(agentOpts, agentfw.WithTool(t))
-		}
+func (pr *playgroundRun) response(provider llm.Provider, req devuiapi.PlaygroundRequest, result fwtypes.RunResult) devuiapi.PlaygroundResponse {
+	return devuiapi.PlaygroundResponse{
+		Status:        "completed",
+		Output:        result.Output,
+		RunID:         result.RunID,
+		SessionID:     result.SessionID,
+		Provider:      provider.Name(),
+		AppliedSkills: pr.appliedSkills,
+		ReplyTo:       req.ReplyTo,
 	}
+}
 
-	agent, err := agentfw.New(provider, agentOpts...)
+func (r *playgroundRunner) Run(ctx context.Context, req devuiapi.PlaygroundRequest) (devuiapi.PlaygroundResponse, error) {
+	provider, err := providerfactory.FromEnv(ctx)
+	if err != nil {
+		return devuiapi.PlaygroundResponse{}, fmt.Errorf("provider setup failed: %w", err)
+	}
+	pr, err := r.prepare(ctx, provider, &req)
 	if err != nil {
-		return devuiapi.PlaygroundResponse{}, fmt.Errorf("agent create failed: %w", err)
+		return devuiapi.PlaygroundResponse{}, err
 	}
 
 	// Direct run (no workflow graph)
-	if wfName == "" {
-		result, runErr := agent.RunDetailed(runCtx, req.Input)
+	if pr.def.Workflow == "" {
+		result, runErr := pr.agent.RunDetailed(pr.ctx, req.Input)
 		if runErr != nil {
 			return devuiapi.PlaygroundResponse{}, runErr
 		}
-		return devuiapi.PlaygroundResponse{
-			Status:        "completed",
-			Output:        result.Output,
-			RunID:         result.RunID,
-			SessionID:     result.SessionID,
-			Provider:      provider.Name(),
-			AppliedSkills: appliedSkills,
-			ReplyTo:       req.ReplyTo,
-		}, nil
+		return pr.response(provider, req, result), nil
 	}
 
 	// Workflow graph run
-	exec, err := buildExecutor(agent, r.store, r.observer, wfName)
+	exec, err := flow.BuildExecutor(pr.agent, r.store, r.observer, pr.def)
 	if err != nil {
 		return devuiapi.PlaygroundResponse{}, fmt.Errorf("executor create failed: %w", err)
 	}
-	result, runErr := exec.Run(runCtx, req.Input)
+	result, runErr := exec.Run(pr.ctx, req.Input)
 	if runErr != nil {
 		return devuiapi.PlaygroundResponse{}, runErr
 	}
-	return devuiapi.PlaygroundResponse{
-		Status:        "completed",
-		Output:        result.Output,
-		RunID:         result.RunID,
-		SessionID:     result.SessionID,
-		Provider:      provider.Name(),
-		AppliedSkills: appliedSkills,
-		ReplyTo:       req.ReplyTo,
-	}, nil
+	return pr.response(provider, req, result), nil
 }
 
 func (r *playgroundRunner) RunStream(ctx context.Context, req devuiapi.PlaygroundRequest, onChunk func(fwtypes.StreamChunk) error) (devuiapi.PlaygroundResponse, error) {
@@ -640,144 +636,24 @@ func (r *playgroundRunner) RunStream(ctx context.Context, req devuiapi.Playgroun
 	if err != nil {
 		return devuiapi.PlaygroundResponse{}, fmt.Errorf("provider setup failed: %w", err)
 	}
-
-	var flowSkills []string
-	if name := strings.TrimSpace(req.Flow); name != "" {
-		if f, ok := flow.Get(name); ok {
-			if strings.TrimSpace(req.Workflow) == "" {
-				req.Workflow = f.Workflow
-			}
-			if len(req.Tools) == 0 {
-				req.Tools = f.Tools
-			}
-			if strings.TrimSpace(req.SystemPrompt) == "" {
-				req.SystemPrompt = f.SystemPrompt
-			}
-			flowSkills = f.Skills
-		}
-	}
-
-	wfName := strings.TrimSpace(req.Workflow)
-	systemPrompt := strings.TrimSpace(req.SystemPrompt)
-	if systemPrompt == "" {
-		systemPrompt = "You are a practical AI assistant. Be concise, accurate, and actionable."
-	}
-
-	allSkills := make(map[string]bool)
-	for _, s := range flowSkills {
-		s = strings.TrimSpace(s)
-		if s != "" {
-			allSkills[s] = true
-		}
-	}
-	for _, s := range req.Skills {
-		s = strings.TrimSpace(s)
-		if s != "" {
-			allSkills[s] = true
-		}
-	}
-	appliedSkills := sortedSkillNames(allSkills)
-	for skillName := range allSkills {
-		if s, ok := skill.Get(skillName); ok {
-			if s.Instructions != "" {
-				systemPrompt += "\n\n## Skill: " + s.Name + "\n" + s.Instructions
-			}
-			if len(s.AllowedTools) > 0 {
-				req.Tools = append(req.Tools, s.AllowedTools...)
-			}
-		}
-	}
-	req.ReplyTo = delivery.Normalize(req.ReplyTo)
-	if req.ReplyTo != nil {
-		systemPrompt = strings.TrimSpace(systemPrompt + "\n\n" + buildReplyChannelHint(req.ReplyTo))
-	}
-	runCtx := delivery.WithTarget(ctx, req.ReplyTo)
-	runCtx = delivery.WithTurnType(runCtx, "user")
-
-	agentOpts := []agentfw.Option{
-		agentfw.WithSystemPrompt(systemPrompt),
-		agentfw.WithMaxIterations(25),
-	}
-
-	if len(req.Guardrails) > 0 {
-		pipeline := guardrail.NewPipeline()
-		for _, name := range req.Guardrails {
-			switch name {
-			case "max_length":
-				pipeline.Add(&guardrail.MaxLength{Limit: 10000})
-			case "prompt_injection":
-				pipeline.AddInput(&guardrail.PromptInjection{})
-			case "content_filter":
-				pipeline.Add(&guardrail.ContentFilter{})
-			case "pii_filter":
-				pipeline.Add(&guardrail.PIIFilter{})
-			case "topic_filter":
-				pipeline.Add(&guardrail.TopicFilter{})
-			case "secret_guard":
-				pipeline.Add(&guardrail.SecretGuard{})
-			}
-		}
-		agentOpts = append(agentOpts, agentfw.WithMiddleware(guardrail.NewAgentMiddleware(pipeline)))
-	}
-
-	sessionID := strings.TrimSpace(req.SessionID)
-	if sessionID != "" {
-		agentOpts = append(agentOpts, agentfw.WithSessionID(sessionID))
-		if r.store != nil {
-			prevRuns, _ := r.store.ListRuns(ctx, state.ListRunsQuery{
-				SessionID: sessionID,
-				Status:    "completed",
-				Limit:     1,
-			})
-			if len(prevRuns) > 0 && len(prevRuns[0].Messages) > 0 {
-				agentOpts = append(agentOpts, agentfw.WithConversationHistory(prevRuns[0].Messages))
-			}
-		}
-	}
-
-	if r.store != nil {
-		agentOpts = append(agentOpts, agentfw.WithStore(r.store))
-	}
-	if r.observer != nil {
-		agentOpts = append(agentOpts, agentfw.WithObserver(r.observer))
-	}
-
-	if len(req.Tools) > 0 {
-		selected, err := tools.BuildSelection(req.Tools)
-		if err != nil {
-			return devuiapi.PlaygroundResponse{}, fmt.Errorf("tool selection failed: %w", err)
-		}
-		for _, t := range selected {
-			agentOpts = append(agentOpts, agentfw.WithTool(t))
-		}
-	}
-
-	agent, err := agentfw.New(provider, agentOpts...)
+	pr, err := r.prepare(ctx, provider, &req)
 	if err != nil {
-		return devuiapi.PlaygroundResponse{}, fmt.Errorf("agent create failed: %w", err)
+		return devuiapi.PlaygroundResponse{}, err
 	}
 
-	if wfName == "" {
-		result, runErr := agent.RunStream(runCtx, req.Input, onChunk)
+	if pr.def.Workflow == "" {
+		result, runErr := pr.agent.RunStream(pr.ctx, req.Input, onChunk)
 		if runErr != nil {
 			return devuiapi.PlaygroundResponse{}, runErr
 		}
-		return devuiapi.PlaygroundResponse{
-			Status:        "completed",
-			Output:        result.Output,
-			RunID:         result.RunID,
-			SessionID:     result.SessionID,
-			Provider:      provider.Name(),
-			AppliedSkills: appliedSkills,
-			ReplyTo:       req.ReplyTo,
-		}, nil
-	}
-
-	exec, err := buildExecutor(agent, r.store, r.observer, wfName)
+		return pr.response(provider, req, result), nil
+	}
+
+	exec, err := flow.BuildExecutor(pr.agent, r.store, r.observer, pr.def)
 	if err != nil {
 		return devuiapi.PlaygroundResponse{}, fmt.Errorf("executor create failed: %w", err)
 	}
-	result, runErr := exec.Run(runCtx, req.Input)
+	result, runErr := exec.Run(pr.ctx, req.Input)
 	if runErr != nil {
 		return devuiapi.PlaygroundResponse{}, runErr
 	}
@@ -790,15 +666,7 @@ func (r *playgroundRunner) RunStream(ctx context.Context, req devuiapi.Playgroun
 			return devuiapi.PlaygroundResponse{}, err
 		}
 	}
-	return devuiapi.PlaygroundResponse{
-		Status:        "completed",
-		Output:        result.Output,
-		RunID:         result.RunID,
-		SessionID:     result.SessionID,
-		Provider:      provider.Name(),
-		AppliedSkills: appliedSkills,
-		ReplyTo:       req.ReplyTo,
-	}, nil
+	return pr.response(provider, req, result), nil
 }
 
 func buildReplyChannelHint(target *delivery.Target) string {
@@ -840,22 +708,6 @@ func sortedSkillNames(skills map[string]bool) []string {
 	return out
 }
 
-func buildExecutor(agent *agentfw.Agent, store state.Store, observer observe.Sink, wfName string) (*graph.Executor, error) {
-	if alias, ok := workflowAlias(wfName); ok {
-		wfName = alias
-	}
-	builder, ok := workflow.Get(wfName)
-	if !ok {
-		return nil, fmt.Errorf("unknown workflow %q (available: %s)", wfName, strings.Join(workflow.Names(), ", "))
-	}
-	exec, err := builder.NewExecutor(agent, store, "")
-	if err != nil {
-		return nil, err
-	}
-	exec.SetObserver(observer)
-	return exec, nil
-}
-
 func workflowAlias(name string) (string, bool) {
 	normalized := strings.TrimSpace(strings.ToLower(name))
 	switch normalized {
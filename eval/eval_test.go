@@ -2,13 +2,17 @@ package eval
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/PipeOpsHQ/agent-sdk-go/llm"
 	"github.com/PipeOpsHQ/agent-sdk-go/types"
 )
 
@@ -132,6 +136,84 @@ func TestRunnerJudgeCheck(t *testing.T) {
 	}
 }
 
+func TestLLMJudgeTruncatesLongFields(t *testing.T) {
+	t.Parallel()
+
+	provider := &fakeJudgeProvider{content: `{"score":0.9,"reason":"fine"}`}
+	judge, err := NewLLMJudge(provider, WithJudgeMaxFieldBytes(64))
+	if err != nil {
+		t.Fatalf("NewLLMJudge failed: %v", err)
+	}
+	output := "BEGIN" + strings.Repeat("x", 1000) + "END"
+	result, err := judge.Score(context.Background(), JudgeInput{CaseID: "long", Input: "short", Output: output})
+	if err != nil {
+		t.Fatalf("Score failed: %v", err)
+	}
+	if !strings.Contains(result.Reason, "fine") || !strings.Contains(result.Reason, "truncated output") {
+		t.Fatalf("expected truncation note in reason, got %q", result.Reason)
+	}
+
+	var sent map[string]any
+	provider.mu.Lock()
+	payload := provider.req.Messages[0].Content
+	provider.mu.Unlock()
+	if err := json.Unmarshal([]byte(payload), &sent); err != nil {
+		t.Fatalf("invalid judge payload: %v", err)
+	}
+	got := sent["output"].(string)
+	if len(got) > 128 || !strings.HasPrefix(got, "BEGIN") || !strings.HasSuffix(got, "END") {
+		t.Fatalf("unexpected truncated output %q", got)
+	}
+	if sent["input"] != "short" {
+		t.Errorf("short input should be sent as-is, got %v", sent["input"])
+	}
+}
+
+func TestLLMJudgeTimeoutAndOutputCap(t *testing.T) {
+	t.Parallel()
+
+	hung := &fakeJudgeProvider{block: make(chan struct{})}
+	defer close(hung.block)
+	judge, err := NewLLMJudge(hung, WithJudgeTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewLLMJudge failed: %v", err)
+	}
+	started := time.Now()
+	if _, err := judge.Score(context.Background(), JudgeInput{CaseID: "c1"}); !errors.Is(err, ErrJudgeTimeout) {
+		t.Fatalf("expected ErrJudgeTimeout, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("expected timeout to return promptly, took %s", elapsed)
+	}
+
+	verbose := &fakeJudgeProvider{content: `{"score":1,"reason":"` + strings.Repeat("x", 512) + `"}`}
+	judge, err = NewLLMJudge(verbose, WithJudgeMaxOutputBytes(128))
+	if err != nil {
+		t.Fatalf("NewLLMJudge failed: %v", err)
+	}
+	if _, err := judge.Score(context.Background(), JudgeInput{CaseID: "c2"}); err == nil || !strings.Contains(err.Error(), "exceeds 128 bytes") {
+		t.Fatalf("expected output size error, got %v", err)
+	}
+
+	agent := &fakeAgent{responses: map[string]fakeResult{
+		"judge": {result: types.RunResult{Output: "answer"}},
+	}}
+	judge, _ = NewLLMJudge(hung, WithJudgeTimeout(20*time.Millisecond))
+	runner, err := NewRunner(RunnerConfig{Agent: agent, Judge: judge})
+	if err != nil {
+		t.Fatalf("NewRunner failed: %v", err)
+	}
+	report, err := runner.Run(context.Background(), []Case{{ID: "j1", Input: "judge", JudgeRubric: "quality"}}, RunOptions{Workers: 1})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	checks := report.Results[0].Checks
+	last := checks[len(checks)-1]
+	if last.Name != "judge_score" || last.Pass || !strings.Contains(last.Detail, "judge timed out") {
+		t.Fatalf("expected recorded judge timeout, got %+v", last)
+	}
+}
+
 func TestRunnerCaseTimeout(t *testing.T) {
 	t.Parallel()
 
@@ -157,6 +239,36 @@ func TestRunnerCaseTimeout(t *testing.T) {
 	if report.Results[0].Error == "" {
 		t.Fatal("expected timeout error text")
 	}
+	if !report.Results[0].TimedOut || report.TimedOut != 1 {
+		t.Fatalf("expected case marked timed out, got %+v", report.Results[0])
+	}
+	if report.Results[0].LatencyMs != 40 || report.LatencyP95Ms != 40 {
+		t.Fatalf("expected latency at the 40ms cap, got %dms (p95 %dms)", report.Results[0].LatencyMs, report.LatencyP95Ms)
+	}
+}
+
+func TestRunnerConfigCaseTimeout(t *testing.T) {
+	t.Parallel()
+
+	agent := &fakeAgent{responses: map[string]fakeResult{
+		"fast": {result: types.RunResult{Output: "ok"}},
+		"slow": {delay: 200 * time.Millisecond, result: types.RunResult{Output: "late"}},
+	}}
+	runner, err := NewRunner(RunnerConfig{Agent: agent, CaseTimeout: 30 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewRunner failed: %v", err)
+	}
+
+	report, err := runner.Run(context.Background(), []Case{{ID: "f", Input: "fast"}, {ID: "s", Input: "slow"}}, RunOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if report.Passed != 1 || report.TimedOut != 1 {
+		t.Fatalf("expected one pass and one timeout, got %+v", report)
+	}
+	if got := report.Results[1].Error; !strings.Contains(got, "case timed out after 30ms") {
+		t.Fatalf("expected timeout reason, got %q", got)
+	}
 }
 
 func TestRunnerGlobalTimeout(t *testing.T) {
@@ -187,6 +299,78 @@ func TestRunnerGlobalTimeout(t *testing.T) {
 	}
 }
 
+func TestRunnerRunSeq(t *testing.T) {
+	t.Parallel()
+
+	agent := &fakeAgent{responses: map[string]fakeResult{
+		"ok": {result: types.RunResult{Output: "done"}},
+	}}
+	runner, err := NewRunner(RunnerConfig{Agent: agent})
+	if err != nil {
+		t.Fatalf("NewRunner failed: %v", err)
+	}
+
+	const n = 50
+	cases := func(yield func(Case) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(Case{ID: fmt.Sprintf("c%d", i), Input: "ok", Tags: []string{"smoke"}}) {
+				return
+			}
+		}
+	}
+	seen := 0
+	report, err := runner.RunSeq(context.Background(), cases, RunOptions{Workers: 4, MaxCases: 40, OnResult: func(CaseResult) { seen++ }})
+	if err != nil {
+		t.Fatalf("RunSeq returned error: %v", err)
+	}
+	if report.Total != 40 || report.Passed != 40 || seen != 40 {
+		t.Fatalf("total=%d passed=%d seen=%d, want 40", report.Total, report.Passed, seen)
+	}
+	if report.Results != nil {
+		t.Errorf("RunSeq must not keep results, got %d", len(report.Results))
+	}
+	if report.PerTag["smoke"].Total != 40 {
+		t.Errorf("per-tag metrics = %+v", report.PerTag)
+	}
+
+	empty := func(func(Case) bool) {}
+	if _, err := runner.RunSeq(context.Background(), empty, RunOptions{}); err == nil {
+		t.Error("expected error for empty sequence")
+	}
+}
+
+func TestLatencyReservoirBounded(t *testing.T) {
+	t.Parallel()
+
+	const n = 200000
+	r := newLatencyReservoir(latencySampleCap)
+	for i := int64(1); i <= n; i++ {
+		// Interleave high and low values so arrival order is not sorted.
+		r.Add((i * 7919) % n)
+	}
+	if len(r.samples) != latencySampleCap {
+		t.Fatalf("expected %d retained samples, got %d", latencySampleCap, len(r.samples))
+	}
+	for _, p := range []int{50, 95} {
+		want := float64(n) * float64(p) / 100
+		got := float64(r.Percentile(p))
+		if diff := (got - want) / want; diff > 0.01 || diff < -0.01 {
+			t.Fatalf("p%d = %.0f, want within 1%% of %.0f", p, got, want)
+		}
+	}
+	if mean := r.Mean(); mean < float64(n)/2-1 || mean > float64(n)/2 {
+		t.Fatalf("unexpected mean %.2f", mean)
+	}
+
+	small := newLatencyReservoir(latencySampleCap)
+	for _, v := range []int64{30, 10, 20} {
+		small.Add(v)
+	}
+	if small.Percentile(50) != 20 || small.Percentile(95) != 20 {
+		t.Fatalf("expected exact percentiles for small input, got p50=%d p95=%d", small.Percentile(50), small.Percentile(95))
+	}
+}
+
 type fakeAgent struct {
 	mu        sync.Mutex
 	responses map[string]fakeResult
@@ -225,3 +409,24 @@ type fakeJudge struct {
 func (f *fakeJudge) Score(_ context.Context, _ JudgeInput) (JudgeResult, error) {
 	return f.result, f.err
 }
+
+// fakeJudgeProvider ignores ctx while blocked, like a stuck provider would.
+type fakeJudgeProvider struct {
+	block   chan struct{}
+	content string
+
+	mu  sync.Mutex
+	req types.Request
+}
+
+func (p *fakeJudgeProvider) Name() string                   { return "fake-judge" }
+func (p *fakeJudgeProvider) Capabilities() llm.Capabilities { return llm.Capabilities{} }
+func (p *fakeJudgeProvider) Generate(_ context.Context, req types.Request) (types.Response, error) {
+	p.mu.Lock()
+	p.req = req
+	p.mu.Unlock()
+	if p.block != nil {
+		<-p.block
+	}
+	return types.Response{Message: types.Message{Role: types.RoleAssistant, Content: p.content}}, nil
+}
@@ -3,9 +3,12 @@ package eval
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/PipeOpsHQ/agent-sdk-go/llm"
 	"github.com/PipeOpsHQ/agent-sdk-go/types"
@@ -32,16 +35,37 @@ type JudgeResult struct {
 	Reason string  `json:"reason,omitempty"`
 }
 
+const (
+	defaultJudgeTimeout        = 60 * time.Second
+	defaultJudgeMaxOutputBytes = 16 << 10
+	defaultJudgeMaxFieldBytes  = 32 << 10
+)
+
+// ErrJudgeTimeout is returned when a judge call exceeds its timeout.
+var ErrJudgeTimeout = errors.New("eval: judge timed out")
+
 type LLMJudge struct {
-	provider llm.Provider
-	model    string
+	provider       llm.Provider
+	model          string
+	timeout        time.Duration
+	maxOutputBytes int
+	maxFieldBytes  int
 }
 
-func NewLLMJudge(provider llm.Provider) (*LLMJudge, error) {
+func NewLLMJudge(provider llm.Provider, opts ...func(*LLMJudge)) (*LLMJudge, error) {
 	if provider == nil {
 		return nil, fmt.Errorf("judge provider is required")
 	}
-	return &LLMJudge{provider: provider}, nil
+	j := &LLMJudge{
+		provider:       provider,
+		timeout:        defaultJudgeTimeout,
+		maxOutputBytes: defaultJudgeMaxOutputBytes,
+		maxFieldBytes:  defaultJudgeMaxFieldBytes,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j, nil
 }
 
 func WithJudgeModel(model string) func(*LLMJudge) {
@@ -52,21 +76,63 @@ func WithJudgeModel(model string) func(*LLMJudge) {
 	}
 }
 
+// WithJudgeTimeout bounds each Score call. Zero or negative disables the
+// limit; the default is 60s.
+func WithJudgeTimeout(d time.Duration) func(*LLMJudge) {
+	return func(j *LLMJudge) {
+		if j != nil {
+			j.timeout = d
+		}
+	}
+}
+
+// WithJudgeMaxOutputBytes caps the judge response accepted for parsing.
+// Zero or negative disables the cap; the default is 16 KiB.
+func WithJudgeMaxOutputBytes(n int) func(*LLMJudge) {
+	return func(j *LLMJudge) {
+		if j != nil {
+			j.maxOutputBytes = n
+		}
+	}
+}
+
+// WithJudgeMaxFieldBytes caps the size of the case input, expected and
+// output text sent to the judge, keeping the head and tail of longer values.
+// Zero or negative disables truncation; the default is 32 KiB per field.
+func WithJudgeMaxFieldBytes(n int) func(*LLMJudge) {
+	return func(j *LLMJudge) {
+		if j != nil {
+			j.maxFieldBytes = n
+		}
+	}
+}
+
 func (j *LLMJudge) Score(ctx context.Context, input JudgeInput) (JudgeResult, error) {
 	if j == nil || j.provider == nil {
 		return JudgeResult{}, fmt.Errorf("judge provider is required")
 	}
+	var truncated []string
+	field := func(name, value string) string {
+		out, cut := truncateMiddle(value, j.maxFieldBytes)
+		if cut {
+			truncated = append(truncated, name)
+		}
+		return out
+	}
 	promptPayload := map[string]any{
 		"caseId":         input.CaseID,
-		"input":          input.Input,
-		"expected":       input.Expected,
-		"output":         input.Output,
+		"input":          field("input", input.Input),
+		"expected":       field("expected", input.Expected),
+		"output":         field("output", input.Output),
 		"rubric":         input.Rubric,
 		"assertions":     input.Assertions,
 		"requiredTools":  input.RequiredTools,
 		"forbiddenTools": input.ForbiddenTools,
 		"usedTools":      input.UsedTools,
 	}
+	if len(truncated) > 0 {
+		promptPayload["truncated"] = truncated
+	}
 	payload, _ := json.Marshal(promptPayload)
 
 	req := types.Request{
@@ -83,9 +149,12 @@ func (j *LLMJudge) Score(ctx context.Context, input JudgeInput) (JudgeResult, er
 			},
 		},
 	}
-	resp, err := j.provider.Generate(ctx, req)
+	resp, err := j.generate(ctx, req)
 	if err != nil {
-		return JudgeResult{}, fmt.Errorf("judge generate failed: %w", err)
+		return JudgeResult{}, err
+	}
+	if j.maxOutputBytes > 0 && len(resp.Message.Content) > j.maxOutputBytes {
+		return JudgeResult{}, fmt.Errorf("judge response exceeds %d bytes", j.maxOutputBytes)
 	}
 	result, err := parseJudgeResult(resp.Message.Content)
 	if err != nil {
@@ -97,9 +166,75 @@ func (j *LLMJudge) Score(ctx context.Context, input JudgeInput) (JudgeResult, er
 	if result.Score > 1 {
 		result.Score = 1
 	}
+	if len(truncated) > 0 {
+		note := fmt.Sprintf("judged on truncated %s (limit %d bytes)", strings.Join(truncated, ", "), j.maxFieldBytes)
+		if result.Reason == "" {
+			result.Reason = note
+		} else {
+			result.Reason += " [" + note + "]"
+		}
+	}
 	return result, nil
 }
 
+// truncateMiddle shortens s to about max bytes, keeping its head and tail
+// around a marker, and reports whether anything was cut.
+func truncateMiddle(s string, max int) (string, bool) {
+	if max <= 0 || len(s) <= max {
+		return s, false
+	}
+	head := max / 2
+	for head > 0 && !utf8.RuneStart(s[head]) {
+		head--
+	}
+	tail := len(s) - (max - head)
+	for tail < len(s) && !utf8.RuneStart(s[tail]) {
+		tail++
+	}
+	return fmt.Sprintf("%s\n...[truncated %d bytes]...\n%s", s[:head], tail-head, s[tail:]), true
+}
+
+// generate calls the provider under the judge timeout. The call runs in its
+// own goroutine so a provider that ignores ctx cannot block the eval worker.
+func (j *LLMJudge) generate(ctx context.Context, req types.Request) (types.Response, error) {
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+	type outcome struct {
+		resp types.Response
+		err  error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		resp, err := j.provider.Generate(ctx, req)
+		done <- outcome{resp: resp, err: err}
+	}()
+	select {
+	case out := <-done:
+		if out.err != nil {
+			if errors.Is(out.err, context.DeadlineExceeded) && ctx.Err() != nil {
+				return types.Response{}, j.timeoutErr()
+			}
+			return types.Response{}, fmt.Errorf("judge generate failed: %w", out.err)
+		}
+		return out.resp, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return types.Response{}, j.timeoutErr()
+		}
+		return types.Response{}, fmt.Errorf("judge generate failed: %w", ctx.Err())
+	}
+}
+
+func (j *LLMJudge) timeoutErr() error {
+	if j.timeout > 0 {
+		return fmt.Errorf("%w after %s", ErrJudgeTimeout, j.timeout)
+	}
+	return ErrJudgeTimeout
+}
+
 func parseJudgeResult(content string) (JudgeResult, error) {
 	trimmed := strings.TrimSpace(content)
 	if trimmed == "" {
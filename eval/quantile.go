@@ -0,0 +1,75 @@
+package eval
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+// latencySampleCap bounds the latency samples kept per report. Up to this
+// many cases percentiles are exact; beyond it they come from a uniform
+// reservoir, whose rank error at p95 is well under one percent.
+const latencySampleCap = 10000
+
+// latencyReservoir tracks latency mean and percentiles in bounded memory
+// using reservoir sampling (Algorithm R). The seed is fixed so reports are
+// reproducible for the same input order.
+type latencyReservoir struct {
+	samples []int64
+	limit   int
+	count   int64
+	sum     int64
+	rng     *rand.Rand
+}
+
+func newLatencyReservoir(limit int) *latencyReservoir {
+	if limit <= 0 {
+		limit = latencySampleCap
+	}
+	return &latencyReservoir{limit: limit, rng: rand.New(rand.NewPCG(1, 2))}
+}
+
+func (r *latencyReservoir) Add(v int64) {
+	r.count++
+	r.sum += v
+	if len(r.samples) < r.limit {
+		r.samples = append(r.samples, v)
+		return
+	}
+	if i := r.rng.Int64N(r.count); i < int64(r.limit) {
+		r.samples[i] = v
+	}
+}
+
+func (r *latencyReservoir) Mean() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	return float64(r.sum) / float64(r.count)
+}
+
+// Percentile returns the p-th percentile (0-100) of the sampled latencies.
+func (r *latencyReservoir) Percentile(p int) int64 {
+	return percentile(r.samples, p)
+}
+
+func percentile(values []int64, p int) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	copyVals := append([]int64(nil), values...)
+	sort.Slice(copyVals, func(i, j int) bool { return copyVals[i] < copyVals[j] })
+	if p <= 0 {
+		return copyVals[0]
+	}
+	if p >= 100 {
+		return copyVals[len(copyVals)-1]
+	}
+	idx := int((float64(p) / 100) * float64(len(copyVals)-1))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(copyVals) {
+		idx = len(copyVals) - 1
+	}
+	return copyVals[idx]
+}
@@ -12,6 +12,9 @@ func FormatMarkdown(report Report) string {
 	b.WriteString(fmt.Sprintf("- dataset: `%s`\n", report.Dataset))
 	b.WriteString(fmt.Sprintf("- provider: `%s`\n", report.Provider))
 	b.WriteString(fmt.Sprintf("- pass rate: `%.2f%%` (%d/%d)\n", report.PassRate, report.Passed, report.Total))
+	if report.TimedOut > 0 {
+		b.WriteString(fmt.Sprintf("- timed out: `%d`\n", report.TimedOut))
+	}
 	b.WriteString(fmt.Sprintf("- latency: avg `%.2fms`, p50 `%dms`, p95 `%dms`\n", report.AvgLatencyMs, report.LatencyP50Ms, report.LatencyP95Ms))
 	b.WriteString(fmt.Sprintf("- tokens: in `%d`, out `%d`, total `%d`\n", report.TotalInputTokens, report.TotalOutputTokens, report.TotalTokens))
 	b.WriteString(fmt.Sprintf("- tool constraint accuracy: `%.2f%%` (%d/%d)\n", report.ToolConstraintAccuracy, report.ToolConstraintPassed, report.ToolConstraintCases))
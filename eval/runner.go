@@ -4,8 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"runtime"
-	"sort"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -18,13 +19,17 @@ type Agent interface {
 }
 
 type Runner struct {
-	agent Agent
-	judge Judge
+	agent       Agent
+	judge       Judge
+	caseTimeout time.Duration
 }
 
 type RunnerConfig struct {
 	Agent Agent
 	Judge Judge
+	// CaseTimeout bounds each case (all attempts included) unless
+	// RunOptions.CaseTimeout overrides it.
+	CaseTimeout time.Duration
 }
 
 type RunOptions struct {
@@ -38,9 +43,13 @@ type RunOptions struct {
 	Timeout       time.Duration
 	JudgeRubric   string
 	MinJudgeScore float64
+	// OnResult, if set, is called with each case result as it completes,
+	// from a single goroutine.
+	OnResult func(CaseResult)
 }
 
 type Report struct {
+	ID                     string                `json:"id,omitempty"`
 	Dataset                string                `json:"dataset,omitempty"`
 	Provider               string                `json:"provider,omitempty"`
 	StartedAt              time.Time             `json:"startedAt"`
@@ -55,6 +64,7 @@ type Report struct {
 	TotalInputTokens       int                   `json:"totalInputTokens"`
 	TotalOutputTokens      int                   `json:"totalOutputTokens"`
 	TotalTokens            int                   `json:"totalTokens"`
+	TimedOut               int                   `json:"timedOut"`
 	ToolConstraintCases    int                   `json:"toolConstraintCases"`
 	ToolConstraintPassed   int                   `json:"toolConstraintPassed"`
 	ToolConstraintAccuracy float64               `json:"toolConstraintAccuracy"`
@@ -82,6 +92,7 @@ type CaseResult struct {
 	Checks    []CheckResult  `json:"checks"`
 	Metadata  map[string]any `json:"metadata,omitempty"`
 	Attempts  int            `json:"attempts,omitempty"`
+	TimedOut  bool           `json:"timedOut,omitempty"`
 	Judge     *JudgeResult   `json:"judge,omitempty"`
 }
 
@@ -89,7 +100,7 @@ func NewRunner(cfg RunnerConfig) (*Runner, error) {
 	if cfg.Agent == nil {
 		return nil, errors.New("runner agent is required")
 	}
-	return &Runner{agent: cfg.Agent, judge: cfg.Judge}, nil
+	return &Runner{agent: cfg.Agent, judge: cfg.Judge, caseTimeout: cfg.CaseTimeout}, nil
 }
 
 func (r *Runner) Run(ctx context.Context, cases []Case, opts RunOptions) (Report, error) {
@@ -102,6 +113,57 @@ func (r *Runner) Run(ctx context.Context, cases []Case, opts RunOptions) (Report
 	if opts.MaxCases > 0 && opts.MaxCases < len(cases) {
 		cases = cases[:opts.MaxCases]
 	}
+	// Every result is kept, so percentiles can be exact.
+	return r.run(ctx, slices.Values(cases), len(cases), true, opts)
+}
+
+// RunSeq evaluates cases as seq produces them, for datasets too large to
+// hold in memory. Results are not kept in Report.Results; set
+// RunOptions.OnResult to consume them. Latency percentiles come from a
+// bounded reservoir and are approximate beyond latencySampleCap cases.
+func (r *Runner) RunSeq(ctx context.Context, seq iter.Seq[Case], opts RunOptions) (Report, error) {
+	if r == nil || r.agent == nil {
+		return Report{}, errors.New("runner agent is required")
+	}
+	if seq == nil {
+		return Report{}, errors.New("case sequence is required")
+	}
+	if opts.MaxCases > 0 {
+		seq = takeCases(seq, opts.MaxCases)
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultWorkers(runtime.NumCPU())
+	}
+	report, err := r.run(ctx, seq, workers, false, opts)
+	if err == nil && report.Total == 0 {
+		return Report{}, errors.New("at least one case is required")
+	}
+	return report, err
+}
+
+func takeCases(seq iter.Seq[Case], n int) iter.Seq[Case] {
+	return func(yield func(Case) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for c := range seq {
+			if !yield(c) {
+				return
+			}
+			if i++; i >= n {
+				return
+			}
+		}
+	}
+}
+
+// run evaluates cases with a worker pool and folds each result into the
+// report as it completes. size is the exact case count when keep is set,
+// otherwise only a cap on workers. With keep, results are stored in case
+// order and percentiles are exact; without it memory stays bounded.
+func (r *Runner) run(ctx context.Context, cases iter.Seq[Case], size int, keep bool, opts RunOptions) (Report, error) {
 	runCtx := ctx
 	cancel := func() {}
 	if opts.Timeout > 0 {
@@ -111,15 +173,18 @@ func (r *Runner) Run(ctx context.Context, cases []Case, opts RunOptions) (Report
 
 	workers := opts.Workers
 	if workers <= 0 {
-		workers = defaultWorkers(len(cases))
+		workers = defaultWorkers(size)
 	}
-	if workers > len(cases) {
-		workers = len(cases)
+	if workers > size {
+		workers = size
 	}
 	retries := opts.Retries
 	if retries < 0 {
 		retries = 0
 	}
+	if opts.CaseTimeout <= 0 {
+		opts.CaseTimeout = r.caseTimeout
+	}
 	backoff := opts.RetryBackoff
 	if backoff <= 0 {
 		backoff = 400 * time.Millisecond
@@ -129,96 +194,69 @@ func (r *Runner) Run(ctx context.Context, cases []Case, opts RunOptions) (Report
 		Dataset:   opts.DatasetPath,
 		Provider:  opts.Provider,
 		StartedAt: time.Now().UTC(),
-		Results:   make([]CaseResult, 0, len(cases)),
 		PerTag:    map[string]TagMetrics{},
 	}
+	sampleCap := latencySampleCap
+	if keep {
+		report.Results = make([]CaseResult, size)
+		sampleCap = size
+	}
 
-	results := make([]CaseResult, len(cases))
 	type job struct {
 		idx int
 		c   Case
 	}
+	type done struct {
+		idx int
+		res CaseResult
+	}
 	jobs := make(chan job)
+	out := make(chan done, workers)
 	var wg sync.WaitGroup
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			for j := range jobs {
-				results[j.idx] = r.runCaseWithRetry(runCtx, j.c, opts, retries, backoff)
+				out <- done{idx: j.idx, res: r.runCaseWithRetry(runCtx, j.c, opts, retries, backoff)}
 			}
 		}()
 	}
-	dispatched := 0
-dispatchLoop:
-	for idx, c := range cases {
-		select {
-		case <-runCtx.Done():
-			for i := idx; i < len(cases); i++ {
-				results[i] = contextFailureResult(cases[i], runCtx.Err(), 0)
-			}
-			break dispatchLoop
-		case jobs <- job{idx: idx, c: c}:
-			dispatched++
-		}
-	}
-	close(jobs)
-	wg.Wait()
-	if dispatched == 0 && len(cases) > 0 {
-		for i := range cases {
-			if results[i].CaseID == "" {
-				results[i] = contextFailureResult(cases[i], runCtx.Err(), 0)
-			}
-		}
-	}
-
-	latencies := make([]int64, 0, len(cases))
-	for _, res := range results {
-		report.Results = append(report.Results, res)
-		report.Total++
-		if res.Pass {
-			report.Passed++
-		} else {
-			report.Failed++
-		}
-		latencies = append(latencies, res.LatencyMs)
-		if res.Usage != nil {
-			report.TotalInputTokens += res.Usage.InputTokens
-			report.TotalOutputTokens += res.Usage.OutputTokens
-			report.TotalTokens += res.Usage.TotalTokens
-		}
-
-		for _, tag := range res.Tags {
-			m := report.PerTag[tag]
-			m.Total++
-			if res.Pass {
-				m.Passed++
+	go func() {
+		idx := 0
+		for c := range cases {
+			if runCtx.Err() != nil {
+				out <- done{idx: idx, res: contextFailureResult(c, runCtx.Err(), 0)}
 			} else {
-				m.Failed++
+				select {
+				case <-runCtx.Done():
+					out <- done{idx: idx, res: contextFailureResult(c, runCtx.Err(), 0)}
+				case jobs <- job{idx: idx, c: c}:
+				}
 			}
-			report.PerTag[tag] = m
+			idx++
 		}
-
-		hasToolConstraint := false
-		for _, check := range res.Checks {
-			if strings.HasPrefix(check.Name, "required_tool:") || strings.HasPrefix(check.Name, "forbidden_tool:") {
-				hasToolConstraint = true
-				break
-			}
+		close(jobs)
+		wg.Wait()
+		close(out)
+	}()
+
+	latencies := newLatencyReservoir(sampleCap)
+	for d := range out {
+		report.add(d.res, latencies)
+		if keep {
+			report.Results[d.idx] = d.res
 		}
-		if hasToolConstraint {
-			report.ToolConstraintCases++
-			if toolChecksPass(res.Checks) {
-				report.ToolConstraintPassed++
-			}
+		if opts.OnResult != nil {
+			opts.OnResult(d.res)
 		}
 	}
 
 	report.CompletedAt = time.Now().UTC()
 	report.PassRate = ratio(report.Passed, report.Total)
-	report.AvgLatencyMs = averageInt64(latencies)
-	report.LatencyP50Ms = percentile(latencies, 50)
-	report.LatencyP95Ms = percentile(latencies, 95)
+	report.AvgLatencyMs = latencies.Mean()
+	report.LatencyP50Ms = latencies.Percentile(50)
+	report.LatencyP95Ms = latencies.Percentile(95)
 	report.ToolConstraintAccuracy = ratio(report.ToolConstraintPassed, report.ToolConstraintCases)
 
 	for tag, m := range report.PerTag {
@@ -229,14 +267,85 @@ dispatchLoop:
 	return report, nil
 }
 
+// add folds one case result into the report totals.
+func (report *Report) add(res CaseResult, latencies *latencyReservoir) {
+	report.Total++
+	if res.Pass {
+		report.Passed++
+	} else {
+		report.Failed++
+	}
+	if res.TimedOut {
+		report.TimedOut++
+	}
+	latencies.Add(res.LatencyMs)
+	if res.Usage != nil {
+		report.TotalInputTokens += res.Usage.InputTokens
+		report.TotalOutputTokens += res.Usage.OutputTokens
+		report.TotalTokens += res.Usage.TotalTokens
+	}
+
+	for _, tag := range res.Tags {
+		m := report.PerTag[tag]
+		m.Total++
+		if res.Pass {
+			m.Passed++
+		} else {
+			m.Failed++
+		}
+		report.PerTag[tag] = m
+	}
+
+	hasToolConstraint := false
+	for _, check := range res.Checks {
+		if strings.HasPrefix(check.Name, "required_tool:") || strings.HasPrefix(check.Name, "forbidden_tool:") {
+			hasToolConstraint = true
+			break
+		}
+	}
+	if hasToolConstraint {
+		report.ToolConstraintCases++
+		if toolChecksPass(res.Checks) {
+			report.ToolConstraintPassed++
+		}
+	}
+}
+
 func (r *Runner) runCaseWithRetry(ctx context.Context, c Case, runOpts RunOptions, retries int, backoff time.Duration) CaseResult {
-	caseCtx := ctx
-	cancel := func() {}
-	if runOpts.CaseTimeout > 0 {
-		caseCtx, cancel = context.WithTimeout(ctx, runOpts.CaseTimeout)
+	if runOpts.CaseTimeout <= 0 {
+		return r.runAttempts(ctx, c, runOpts, retries, backoff)
 	}
+	caseCtx, cancel := context.WithTimeout(ctx, runOpts.CaseTimeout)
 	defer cancel()
+	res := r.runAttempts(caseCtx, c, runOpts, retries, backoff)
+	if res.Error != "" && ctx.Err() == nil && errors.Is(caseCtx.Err(), context.DeadlineExceeded) {
+		res = caseTimeoutResult(res, runOpts.CaseTimeout)
+	}
+	return res
+}
+
+// caseTimeoutResult marks res as timed out. Latency is reported at the cap
+// so percentiles still reflect runaway cases.
+func caseTimeoutResult(res CaseResult, timeout time.Duration) CaseResult {
+	reason := fmt.Sprintf("case timed out after %s", timeout)
+	res.Pass = false
+	res.TimedOut = true
+	res.Error = reason
+	res.LatencyMs = timeout.Milliseconds()
+	replaced := false
+	for i := range res.Checks {
+		if res.Checks[i].Name == "run" {
+			res.Checks[i] = CheckResult{Name: "run", Pass: false, Detail: reason}
+			replaced = true
+		}
+	}
+	if !replaced {
+		res.Checks = append(res.Checks, CheckResult{Name: "run", Pass: false, Detail: reason})
+	}
+	return res
+}
 
+func (r *Runner) runAttempts(caseCtx context.Context, c Case, runOpts RunOptions, retries int, backoff time.Duration) CaseResult {
 	var last CaseResult
 	attempts := retries + 1
 	if attempts < 1 {
@@ -292,7 +401,7 @@ func (r *Runner) runCaseWithOptions(ctx context.Context, c Case, runOpts RunOpti
 		Metadata: c.Metadata,
 	}
 
-	runResult, err := r.agent.RunDetailed(ctx, c.Input)
+	runResult, err := r.runAgent(ctx, c.Input)
 	if err != nil {
 		result.Error = err.Error()
 		result.Pass = false
@@ -341,6 +450,25 @@ func (r *Runner) runCaseWithOptions(ctx context.Context, c Case, runOpts RunOpti
 	return result
 }
 
+// runAgent returns as soon as ctx is done, even if the agent ignores it.
+func (r *Runner) runAgent(ctx context.Context, input string) (types.RunResult, error) {
+	type outcome struct {
+		result types.RunResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := r.agent.RunDetailed(ctx, input)
+		done <- outcome{result: result, err: err}
+	}()
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+		return types.RunResult{}, ctx.Err()
+	}
+}
+
 func (r *Runner) evaluateJudge(ctx context.Context, result CaseResult, c Case, runOpts RunOptions) CaseResult {
 	if r == nil || r.judge == nil {
 		return result
@@ -512,36 +640,3 @@ func ratio(numerator, denominator int) float64 {
 	}
 	return (float64(numerator) / float64(denominator)) * 100
 }
-
-func averageInt64(values []int64) float64 {
-	if len(values) == 0 {
-		return 0
-	}
-	var sum int64
-	for _, v := range values {
-		sum += v
-	}
-	return float64(sum) / float64(len(values))
-}
-
-func percentile(values []int64, p int) int64 {
-	if len(values) == 0 {
-		return 0
-	}
-	copyVals := append([]int64(nil), values...)
-	sort.Slice(copyVals, func(i, j int) bool { return copyVals[i] < copyVals[j] })
-	if p <= 0 {
-		return copyVals[0]
-	}
-	if p >= 100 {
-		return copyVals[len(copyVals)-1]
-	}
-	idx := int((float64(p) / 100) * float64(len(copyVals)-1))
-	if idx < 0 {
-		idx = 0
-	}
-	if idx >= len(copyVals) {
-		idx = len(copyVals) - 1
-	}
-	return copyVals[idx]
-}
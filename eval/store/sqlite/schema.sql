@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS eval_reports (
+  report_id TEXT PRIMARY KEY,
+  dataset TEXT,
+  provider TEXT,
+  started_at TEXT NOT NULL,
+  completed_at TEXT NOT NULL,
+  total INTEGER DEFAULT 0,
+  passed INTEGER DEFAULT 0,
+  failed INTEGER DEFAULT 0,
+  pass_rate REAL DEFAULT 0,
+  summary TEXT NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE IF NOT EXISTS eval_case_results (
+  report_id TEXT NOT NULL,
+  idx INTEGER NOT NULL,
+  case_id TEXT,
+  pass INTEGER DEFAULT 0,
+  result TEXT NOT NULL DEFAULT '{}',
+  PRIMARY KEY (report_id, idx)
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_reports_started_at ON eval_reports(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_eval_reports_dataset ON eval_reports(dataset);
@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/eval"
+	evalstore "github.com/PipeOpsHQ/agent-sdk-go/eval/store"
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed schema.sql
+var schemaSQL string
+
+const defaultLimit = 50
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(path string) (*Store, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("sqlite eval path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create eval db dir: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open eval sqlite db: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	if _, err := db.ExecContext(context.Background(), "PRAGMA journal_mode=WAL;"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to enable wal: %w", err)
+	}
+	if _, err := db.ExecContext(context.Background(), schemaSQL); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize eval schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) SaveReport(ctx context.Context, report eval.Report) (string, error) {
+	if report.ID == "" {
+		report.ID = uuid.NewString()
+	}
+	if report.StartedAt.IsZero() {
+		report.StartedAt = time.Now().UTC()
+	}
+	if report.CompletedAt.IsZero() {
+		report.CompletedAt = report.StartedAt
+	}
+	results := report.Results
+	summary := report
+	summary.Results = nil
+	rawSummary, err := json.Marshal(summary)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode eval report: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin eval report tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const insertReport = `
+INSERT INTO eval_reports (
+  report_id, dataset, provider, started_at, completed_at, total, passed, failed, pass_rate, summary
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(report_id) DO UPDATE SET
+  dataset = excluded.dataset,
+  provider = excluded.provider,
+  started_at = excluded.started_at,
+  completed_at = excluded.completed_at,
+  total = excluded.total,
+  passed = excluded.passed,
+  failed = excluded.failed,
+  pass_rate = excluded.pass_rate,
+  summary = excluded.summary;
+`
+	if _, err := tx.ExecContext(
+		ctx,
+		insertReport,
+		report.ID,
+		report.Dataset,
+		report.Provider,
+		report.StartedAt.UTC().Format(time.RFC3339Nano),
+		report.CompletedAt.UTC().Format(time.RFC3339Nano),
+		report.Total,
+		report.Passed,
+		report.Failed,
+		report.PassRate,
+		string(rawSummary),
+	); err != nil {
+		return "", fmt.Errorf("failed to save eval report: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM eval_case_results WHERE report_id = ?;`, report.ID); err != nil {
+		return "", fmt.Errorf("failed to replace eval case results: %w", err)
+	}
+	for i, res := range results {
+		raw, err := json.Marshal(res)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode eval case %q: %w", res.CaseID, err)
+		}
+		if _, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO eval_case_results (report_id, idx, case_id, pass, result) VALUES (?, ?, ?, ?, ?);`,
+			report.ID, i, res.CaseID, res.Pass, string(raw),
+		); err != nil {
+			return "", fmt.Errorf("failed to save eval case %q: %w", res.CaseID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit eval report: %w", err)
+	}
+	return report.ID, nil
+}
+
+func (s *Store) ListReports(ctx context.Context, query evalstore.ListQuery) ([]eval.Report, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	where := []string{}
+	args := []any{}
+	if query.Dataset != "" {
+		where = append(where, "dataset = ?")
+		args = append(args, query.Dataset)
+	}
+	if query.Provider != "" {
+		where = append(where, "provider = ?")
+		args = append(args, query.Provider)
+	}
+	q := "SELECT summary FROM eval_reports"
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY started_at DESC, rowid DESC LIMIT ? OFFSET ?;"
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list eval reports: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]eval.Report, 0, limit)
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("failed to scan eval report: %w", err)
+		}
+		var report eval.Report
+		if err := json.Unmarshal([]byte(raw), &report); err != nil {
+			return nil, fmt.Errorf("failed to decode eval report: %w", err)
+		}
+		out = append(out, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate eval reports: %w", err)
+	}
+	return out, nil
+}
+
+func (s *Store) GetReport(ctx context.Context, id string) (eval.Report, error) {
+	if strings.TrimSpace(id) == "" {
+		return eval.Report{}, fmt.Errorf("report id is required")
+	}
+	var raw string
+	err := s.db.QueryRowContext(ctx, `SELECT summary FROM eval_reports WHERE report_id = ?;`, id).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return eval.Report{}, evalstore.ErrNotFound
+	}
+	if err != nil {
+		return eval.Report{}, fmt.Errorf("failed to load eval report: %w", err)
+	}
+	var report eval.Report
+	if err := json.Unmarshal([]byte(raw), &report); err != nil {
+		return eval.Report{}, fmt.Errorf("failed to decode eval report: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT result FROM eval_case_results WHERE report_id = ? ORDER BY idx ASC;`, id)
+	if err != nil {
+		return eval.Report{}, fmt.Errorf("failed to load eval case results: %w", err)
+	}
+	defer rows.Close()
+	report.Results = make([]eval.CaseResult, 0, report.Total)
+	for rows.Next() {
+		var rawResult string
+		if err := rows.Scan(&rawResult); err != nil {
+			return eval.Report{}, fmt.Errorf("failed to scan eval case result: %w", err)
+		}
+		var res eval.CaseResult
+		if err := json.Unmarshal([]byte(rawResult), &res); err != nil {
+			return eval.Report{}, fmt.Errorf("failed to decode eval case result: %w", err)
+		}
+		report.Results = append(report.Results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return eval.Report{}, fmt.Errorf("failed to iterate eval case results: %w", err)
+	}
+	return report, nil
+}
+
+func (s *Store) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+var _ evalstore.Store = (*Store)(nil)
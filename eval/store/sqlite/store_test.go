@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/eval"
+	evalstore "github.com/PipeOpsHQ/agent-sdk-go/eval/store"
+)
+
+func TestStore_SaveListAndGetReports(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "evals.db"))
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+	base := time.Now().UTC().Add(-time.Hour)
+	reports := []eval.Report{
+		{Dataset: "security.jsonl", Provider: "openai", StartedAt: base, Total: 2, Passed: 1, Failed: 1, PassRate: 50,
+			Results: []eval.CaseResult{{CaseID: "c1", Pass: true}, {CaseID: "c2", Error: "boom"}}},
+		{Dataset: "other.jsonl", Provider: "openai", StartedAt: base.Add(time.Minute), Total: 1, Passed: 1, PassRate: 100,
+			Results: []eval.CaseResult{{CaseID: "o1", Pass: true}}},
+		{Dataset: "security.jsonl", Provider: "openai", StartedAt: base.Add(2 * time.Minute), Total: 2, Passed: 2, PassRate: 100,
+			Results: []eval.CaseResult{{CaseID: "c1", Pass: true}, {CaseID: "c2", Pass: true, LatencyMs: 12}}},
+	}
+	ids := make([]string, 0, len(reports))
+	for _, r := range reports {
+		id, err := store.SaveReport(ctx, r)
+		if err != nil {
+			t.Fatalf("save report: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	listed, err := store.ListReports(ctx, evalstore.ListQuery{Dataset: "security.jsonl"})
+	if err != nil {
+		t.Fatalf("list reports: %v", err)
+	}
+	if len(listed) != 2 || listed[0].ID != ids[2] || listed[1].ID != ids[0] {
+		t.Fatalf("expected security reports newest first, got %+v", listed)
+	}
+	if listed[0].Results != nil {
+		t.Fatalf("expected list to omit per-case results, got %d", len(listed[0].Results))
+	}
+
+	got, err := store.GetReport(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("get report: %v", err)
+	}
+	if got.PassRate != 50 || len(got.Results) != 2 || got.Results[1].CaseID != "c2" || got.Results[1].Error != "boom" {
+		t.Fatalf("unexpected report: %+v", got)
+	}
+	if !got.StartedAt.Equal(base) {
+		t.Fatalf("startedAt = %s, want %s", got.StartedAt, base)
+	}
+
+	if _, err := store.GetReport(ctx, "missing"); !errors.Is(err, evalstore.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
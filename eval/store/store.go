@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/eval"
+)
+
+var ErrNotFound = errors.New("eval store: report not found")
+
+type ListQuery struct {
+	Dataset  string
+	Provider string
+	Limit    int
+	Offset   int
+}
+
+// Store keeps a history of eval reports.
+type Store interface {
+	// SaveReport persists report with its per-case results and returns the
+	// report ID, generating one when report.ID is empty.
+	SaveReport(ctx context.Context, report eval.Report) (string, error)
+	// ListReports returns reports newest first, without per-case results.
+	ListReports(ctx context.Context, query ListQuery) ([]eval.Report, error)
+	// GetReport returns a full report, or ErrNotFound.
+	GetReport(ctx context.Context, id string) (eval.Report, error)
+	Close() error
+}
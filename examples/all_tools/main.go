@@ -202,7 +202,7 @@ Tool Bundles:
   @network     http_client, web_scraper, curl, dns_lookup, network_utils
   @system      shell_command, file_system, env_vars, tmpdir, process_manager,
                disk_usage, system_info, log_viewer, archive
-  @memory      memory_store
+  @memory      memory_store, memory_compact
   @container   docker, docker_compose
   @kubernetes  kubectl, k3s
   @scheduling  cron_manager
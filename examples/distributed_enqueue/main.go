@@ -79,7 +79,7 @@ func main() {
 	}
 
 	fmt.Printf("submitted run_id=%s session_id=%s message_id=%s\n", res.RunID, res.SessionID, res.MessageID)
-	fmt.Printf("queue stats: stream_length=%d pending=%d dlq_length=%d\n", stats.StreamLength, stats.Pending, stats.DLQLength)
+	fmt.Printf("queue stats: stream_length=%d pending=%d lag=%d oldest_pending_age=%s dlq_length=%d\n", stats.StreamLength, stats.Pending, stats.Lag, stats.OldestPendingAge, stats.DLQLength)
 	fmt.Println("next step: start worker(s) with `go run ./cmd/ai-agent-framework worker-start --worker-id=w1`")
 }
 
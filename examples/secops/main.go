@@ -14,6 +14,7 @@
 //   OPENAI_API_KEY or ANTHROPIC_API_KEY - LLM provider credentials
 //   STATE_BACKEND=sqlite (default) or redis
 //   STATE_SQLITE_PATH=./.ai-agent/state.db
+//   SECOPS_STRUCTURED_OUTPUT=true - return a JSON report (findings, fixes)
 
 package main
 
@@ -21,11 +22,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -40,6 +43,7 @@ import (
 	"github.com/PipeOpsHQ/agent-sdk-go/state"
 	statefactory "github.com/PipeOpsHQ/agent-sdk-go/state/factory"
 	fwtools "github.com/PipeOpsHQ/agent-sdk-go/tools"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
 )
 
 const secOpsSystemPrompt = `You are a senior SecOps analyst.
@@ -68,6 +72,61 @@ type CategorizedVulnerabilities struct {
 	MediumCount  int             `json:"mediumCount"`
 	LowCount     int             `json:"lowCount"`
 	TotalCount   int             `json:"totalCount"`
+	FixableCount int             `json:"fixableCount"`
+}
+
+// Config controls how the SecOps graph builds prompts and final output.
+type Config struct {
+	// StructuredOutput asks the model for a JSON SecOpsReport matching
+	// secOpsReportSchema and validates it before returning, so downstream
+	// systems can consume results without parsing prose.
+	StructuredOutput bool
+}
+
+// SecOpsReport is the structured result returned when Config.StructuredOutput
+// is set.
+type SecOpsReport struct {
+	Summary  string    `json:"summary"`
+	Findings []Finding `json:"findings"`
+	Fixes    []string  `json:"fixes"`
+}
+
+type Finding struct {
+	Title    string `json:"title"`
+	Severity string `json:"severity"`
+	ID       string `json:"id,omitempty"`
+	Package  string `json:"package,omitempty"`
+}
+
+const maxReportItems = 3
+
+var reportSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO"}
+
+var secOpsReportSchema = map[string]any{
+	"type":     "object",
+	"required": []any{"summary", "findings", "fixes"},
+	"properties": map[string]any{
+		"summary": map[string]any{"type": "string"},
+		"findings": map[string]any{
+			"type":     "array",
+			"maxItems": maxReportItems,
+			"items": map[string]any{
+				"type":     "object",
+				"required": []any{"title", "severity"},
+				"properties": map[string]any{
+					"title":    map[string]any{"type": "string"},
+					"severity": map[string]any{"type": "string", "enum": reportSeverities},
+					"id":       map[string]any{"type": "string"},
+					"package":  map[string]any{"type": "string"},
+				},
+			},
+		},
+		"fixes": map[string]any{
+			"type":     "array",
+			"maxItems": maxReportItems,
+			"items":    map[string]any{"type": "string"},
+		},
+	},
 }
 
 type ClassifiedLogs struct {
@@ -104,11 +163,13 @@ const (
 	RouteLogs  = "logs"
 
 	KeyCategorized  = "categorized"
+	KeyTrivyRaw     = "trivyRaw"
 	KeyRedactedLogs = "redactedLogs"
 	KeyClassified   = "classifiedLogs"
 	KeyPromptTrivy  = "trivyPrompt"
 	KeyPromptLogs   = "logsPrompt"
 	KeyFinalOutput  = "output"
+	KeyReport       = "report"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -128,15 +189,17 @@ func main() {
 		log.Fatal(err)
 	}
 
+	cfg := Config{StructuredOutput: envBool("SECOPS_STRUCTURED_OUTPUT")}
+
 	provider, store, observer, closeFn := buildDeps(ctx)
 	defer closeFn()
 
-	a, err := buildAgent(provider, store, observer)
+	a, err := buildAgent(provider, store, observer, cfg)
 	if err != nil {
 		log.Fatalf("agent create failed: %v", err)
 	}
 
-	exec, err := newSecOpsExecutor(a, store)
+	exec, err := newSecOpsExecutor(a, store, cfg)
 	if err != nil {
 		log.Fatalf("secops executor create failed: %v", err)
 	}
@@ -144,12 +207,28 @@ func main() {
 
 	result, err := exec.Run(ctx, input)
 	if err != nil {
+		var nodeErr *graph.NodeError
+		if errors.As(err, &nodeErr) {
+			logSteps(nodeErr.Steps)
+		}
 		log.Fatalf("secops run failed: %v", err)
 	}
+	logSteps(result.Steps)
 
 	fmt.Printf("run_id=%s session_id=%s\n\n%s\n", result.RunID, result.SessionID, strings.TrimSpace(result.Output))
 }
 
+// logSteps prints per-stage timings (parse, analysis, summary) to stderr.
+func logSteps(steps []types.StepResult) {
+	for _, step := range steps {
+		if step.Error != "" {
+			log.Printf("step %s failed after %s: %s", step.Name, step.Duration.Round(time.Millisecond), step.Error)
+			continue
+		}
+		log.Printf("step %s completed in %s (%d chars)", step.Name, step.Duration.Round(time.Millisecond), len(step.Output))
+	}
+}
+
 func runDevUI() {
 	flow.MustRegister(&flow.Definition{
 		Name:         "secops-analyzer",
@@ -209,6 +288,14 @@ func readInput(args []string) (string, error) {
 	return "", fmt.Errorf("usage: go run <target> <trivy-json-or-log-file> OR cat file | go run <target>")
 }
 
+func envBool(key string) bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(key))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Dependencies
 // ─────────────────────────────────────────────────────────────────────────────
@@ -229,7 +316,7 @@ func buildDeps(ctx context.Context) (llm.Provider, state.Store, observe.Sink, fu
 	}
 }
 
-func buildAgent(provider llm.Provider, store state.Store, observer observe.Sink) (*agentfw.Agent, error) {
+func buildAgent(provider llm.Provider, store state.Store, observer observe.Sink, cfg Config) (*agentfw.Agent, error) {
 	selected, err := fwtools.BuildSelection([]string{"@default", "@security"})
 	if err != nil {
 		return nil, err
@@ -242,6 +329,9 @@ func buildAgent(provider llm.Provider, store state.Store, observer observe.Sink)
 		agentfw.WithMaxOutputTokens(600),
 		agentfw.WithRetryPolicy(agentfw.RetryPolicy{MaxAttempts: 2, BaseBackoff: 200 * time.Millisecond, MaxBackoff: 2 * time.Second}),
 	}
+	if cfg.StructuredOutput {
+		opts = append(opts, agentfw.WithResponseSchema(secOpsReportSchema))
+	}
 	for _, t := range selected {
 		opts = append(opts, agentfw.WithTool(t))
 	}
@@ -266,7 +356,7 @@ func buildObserver() (observe.Sink, func()) {
 // SecOps Graph Executor
 // ─────────────────────────────────────────────────────────────────────────────
 
-func newSecOpsExecutor(runner graph.AgentRunner, store state.Store) (*graph.Executor, error) {
+func newSecOpsExecutor(runner graph.AgentRunner, store state.Store, cfg Config) (*graph.Executor, error) {
 	if runner == nil {
 		return nil, fmt.Errorf("runner is required")
 	}
@@ -275,7 +365,7 @@ func newSecOpsExecutor(runner graph.AgentRunner, store state.Store) (*graph.Exec
 	g.AddNode("route", detectInputRouteNode())
 
 	g.AddNode("parse_trivy", parseTrivyNode())
-	g.AddNode("build_trivy_prompt", buildTrivyPromptNode())
+	g.AddNode("build_trivy_prompt", buildTrivyPromptNode(cfg))
 	g.AddNode("assistant_trivy", &graph.AgentNode{
 		Runner: runner,
 		Input: func(s *graph.State) (string, error) {
@@ -290,7 +380,7 @@ func newSecOpsExecutor(runner graph.AgentRunner, store state.Store) (*graph.Exec
 
 	g.AddNode("redact_logs", redactLogsNode())
 	g.AddNode("classify_logs", classifyLogsNode())
-	g.AddNode("build_logs_prompt", buildLogsPromptNode())
+	g.AddNode("build_logs_prompt", buildLogsPromptNode(cfg))
 	g.AddNode("assistant_logs", &graph.AgentNode{
 		Runner: runner,
 		Input: func(s *graph.State) (string, error) {
@@ -303,7 +393,7 @@ func newSecOpsExecutor(runner graph.AgentRunner, store state.Store) (*graph.Exec
 		OutputKey: "logsAgentOutput",
 	})
 
-	g.AddNode("finalize", finalizeNode())
+	g.AddNode("finalize", finalizeNode(cfg))
 	g.SetStart("route")
 
 	g.AddEdge("route", "parse_trivy", graph.RouteEquals(RouteKey, RouteTrivy))
@@ -350,11 +440,14 @@ func detectInputRouteNode() graph.Node {
 func parseTrivyNode() graph.Node {
 	return graph.NewToolNode(func(ctx context.Context, state *graph.State) error {
 		_ = ctx
+		state.EnsureData()
 		categorized, err := parseTrivyReport(json.RawMessage(state.Input))
 		if err != nil {
-			return err
+			// Not a report we understand; let the prompt fall back to raw text.
+			log.Printf("trivy parse failed, using raw input: %v", err)
+			state.Data[KeyTrivyRaw] = state.Input
+			return nil
 		}
-		state.EnsureData()
 		state.Data[KeyCategorized] = categorized
 		return nil
 	})
@@ -381,17 +474,27 @@ func classifyLogsNode() graph.Node {
 	})
 }
 
-func buildTrivyPromptNode() graph.Node {
+func buildTrivyPromptNode(cfg Config) graph.Node {
 	return graph.NewToolNode(func(ctx context.Context, state *graph.State) error {
 		_ = ctx
 		state.EnsureData()
 
-		categorized, err := decodeCategorized(state.Data[KeyCategorized])
-		if err != nil {
-			return err
-		}
+		var prompt string
+		if raw, ok := state.Data[KeyTrivyRaw].(string); ok {
+			prompt = fmt.Sprintf(`Analyze this scan output and return compact, high-signal findings.
+Constraints:
+- Maximum 8 bullets.
+- Prioritize CRITICAL/HIGH first.
+- Keep total under 140 words.
 
-		prompt := fmt.Sprintf(`Analyze this Trivy result and return compact, high-signal findings.
+Scan output:
+%s`, truncateText(raw, maxRawScanChars))
+		} else {
+			categorized, err := decodeCategorized(state.Data[KeyCategorized])
+			if err != nil {
+				return err
+			}
+			prompt = fmt.Sprintf(`Analyze this Trivy result and return compact, high-signal findings.
 Constraints:
 - Maximum 8 bullets.
 - Prioritize CRITICAL/HIGH first.
@@ -399,20 +502,27 @@ Constraints:
 - Include immediate actions only.
 
 Artifact: %s
-Counts: critical=%d high=%d medium=%d low=%d total=%d`,
-			categorized.ArtifactName,
-			len(categorized.Critical),
-			len(categorized.High),
-			categorized.MediumCount,
-			categorized.LowCount,
-			categorized.TotalCount,
-		)
+Counts: critical=%d high=%d medium=%d low=%d total=%d fixable=%d
+%s`,
+				categorized.ArtifactName,
+				len(categorized.Critical),
+				len(categorized.High),
+				categorized.MediumCount,
+				categorized.LowCount,
+				categorized.TotalCount,
+				categorized.FixableCount,
+				formatTrivyDetails(categorized),
+			)
+		}
+		if cfg.StructuredOutput {
+			prompt += structuredOutputInstructions
+		}
 		state.Data[KeyPromptTrivy] = prompt
 		return nil
 	})
 }
 
-func buildLogsPromptNode() graph.Node {
+func buildLogsPromptNode(cfg Config) graph.Node {
 	return graph.NewToolNode(func(ctx context.Context, state *graph.State) error {
 		_ = ctx
 		state.EnsureData()
@@ -441,12 +551,21 @@ Logs snippet:
 			len(classified.Info),
 			redactedLogs,
 		)
+		if cfg.StructuredOutput {
+			prompt += structuredOutputInstructions
+		}
 		state.Data[KeyPromptLogs] = prompt
 		return nil
 	})
 }
 
-func finalizeNode() graph.Node {
+const structuredOutputInstructions = `
+
+Respond with ONLY a JSON object:
+{"summary": "<= 80 words", "findings": [{"title": "...", "severity": "CRITICAL|HIGH|MEDIUM|LOW|INFO", "id": "CVE or rule id, optional", "package": "optional"}], "fixes": ["..."]}
+Use at most 3 findings and at most 3 fixes.`
+
+func finalizeNode(cfg Config) graph.Node {
 	return graph.NewToolNode(func(ctx context.Context, state *graph.State) error {
 		_ = ctx
 		state.EnsureData()
@@ -461,6 +580,18 @@ func finalizeNode() graph.Node {
 				}
 			}
 		}
+		if cfg.StructuredOutput {
+			report, err := parseSecOpsReport(state.Output)
+			if err != nil {
+				return err
+			}
+			encoded, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			state.Data[KeyReport] = report
+			state.Output = string(encoded)
+		}
 		state.Data[KeyFinalOutput] = state.Output
 		return nil
 	})
@@ -511,6 +642,9 @@ func parseTrivyReport(raw json.RawMessage) (CategorizedVulnerabilities, error) {
 				Title:            strings.TrimSpace(vuln.Title),
 			}
 			out.TotalCount++
+			if item.FixedVersion != "" {
+				out.FixableCount++
+			}
 			switch item.Severity {
 			case "CRITICAL":
 				out.Critical = append(out.Critical, item)
@@ -526,6 +660,147 @@ func parseTrivyReport(raw json.RawMessage) (CategorizedVulnerabilities, error) {
 	return out, nil
 }
 
+// parseSecOpsReport decodes the model's JSON answer and checks it against
+// secOpsReportSchema. Code fences around the JSON are tolerated.
+func parseSecOpsReport(output string) (SecOpsReport, error) {
+	text := strings.TrimSpace(output)
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	var report SecOpsReport
+	if err := json.Unmarshal([]byte(text), &report); err != nil {
+		return SecOpsReport{}, fmt.Errorf("structured output is not valid JSON: %w", err)
+	}
+	if strings.TrimSpace(report.Summary) == "" {
+		return SecOpsReport{}, fmt.Errorf("structured output: summary is required")
+	}
+	if len(report.Findings) > maxReportItems || len(report.Fixes) > maxReportItems {
+		return SecOpsReport{}, fmt.Errorf("structured output: at most %d findings and %d fixes allowed", maxReportItems, maxReportItems)
+	}
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+	if report.Fixes == nil {
+		report.Fixes = []string{}
+	}
+	for i := range report.Findings {
+		f := &report.Findings[i]
+		f.Severity = strings.ToUpper(strings.TrimSpace(f.Severity))
+		if strings.TrimSpace(f.Title) == "" {
+			return SecOpsReport{}, fmt.Errorf("structured output: finding %d has no title", i)
+		}
+		if !slices.Contains(reportSeverities, f.Severity) {
+			return SecOpsReport{}, fmt.Errorf("structured output: finding %d has invalid severity %q", i, f.Severity)
+		}
+	}
+	return report, nil
+}
+
+const (
+	maxPromptCVEs     = 10
+	maxPromptPackages = 8
+	maxRawScanChars   = 4000
+)
+
+// formatTrivyDetails renders the top CRITICAL/HIGH CVEs and the packages
+// they affect, so the prompt carries the facts the model needs without the
+// full report.
+func formatTrivyDetails(c CategorizedVulnerabilities) string {
+	top := topVulnerabilities(c, maxPromptCVEs)
+	if len(top) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nTop CVEs:\n")
+	for _, v := range top {
+		fix := "no fix"
+		if v.FixedVersion != "" {
+			fix = "fix " + v.FixedVersion
+		}
+		pkg := v.PkgName
+		if v.InstalledVersion != "" {
+			pkg += "@" + v.InstalledVersion
+		}
+		fmt.Fprintf(&b, "- %s %s %s (%s)", v.Severity, v.VulnerabilityID, pkg, fix)
+		if v.Title != "" {
+			fmt.Fprintf(&b, ": %s", truncateText(v.Title, 80))
+		}
+		b.WriteString("\n")
+	}
+	if pkgs := affectedPackages(c, maxPromptPackages); len(pkgs) > 0 {
+		fmt.Fprintf(&b, "Affected packages: %s\n", strings.Join(pkgs, ", "))
+	}
+	return b.String()
+}
+
+// topVulnerabilities returns up to n unique CRITICAL then HIGH findings,
+// fixable ones first within each severity.
+func topVulnerabilities(c CategorizedVulnerabilities, n int) []Vulnerability {
+	seen := map[string]bool{}
+	var out []Vulnerability
+	for _, group := range [][]Vulnerability{c.Critical, c.High} {
+		sorted := slices.Clone(group)
+		slices.SortStableFunc(sorted, func(a, b Vulnerability) int {
+			return boolRank(a.FixedVersion != "") - boolRank(b.FixedVersion != "")
+		})
+		for _, v := range sorted {
+			key := v.VulnerabilityID + "|" + v.PkgName
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, v)
+			if len(out) == n {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 0
+	}
+	return 1
+}
+
+// affectedPackages lists packages with CRITICAL/HIGH findings, most
+// affected first, as "name (count)".
+func affectedPackages(c CategorizedVulnerabilities, n int) []string {
+	counts := map[string]int{}
+	for _, v := range append(slices.Clone(c.Critical), c.High...) {
+		if v.PkgName != "" {
+			counts[v.PkgName]++
+		}
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = fmt.Sprintf("%s (%d)", name, counts[name])
+	}
+	return out
+}
+
+func truncateText(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
+
 func redactSensitiveData(logs string) string {
 	redacted := strings.TrimSpace(logs)
 	if redacted == "" {
@@ -0,0 +1,102 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/agent"
+	"github.com/PipeOpsHQ/agent-sdk-go/llm"
+	"github.com/PipeOpsHQ/agent-sdk-go/observe"
+	"github.com/PipeOpsHQ/agent-sdk-go/skill"
+	"github.com/PipeOpsHQ/agent-sdk-go/state"
+	"github.com/PipeOpsHQ/agent-sdk-go/tools"
+	"github.com/PipeOpsHQ/agent-sdk-go/workflow"
+)
+
+// DefaultSystemPrompt is used when a flow does not set one.
+const DefaultSystemPrompt = "You are a practical AI assistant. Be concise, accurate, and actionable."
+
+// BuildAgent turns a flow definition into a runnable agent. Skills (and the
+// skills they require) append their instructions to the system prompt and
+// their allowed tools to the flow's tool selection. store and observer may
+// be nil. Extra options are applied last, so they override the flow.
+//
+// The agent itself does not run the flow's workflow; use BuildExecutor for
+// flows that set one. The workflow and its params are still checked here so
+// a misconfigured flow fails before anything runs.
+func BuildAgent(provider llm.Provider, store state.Store, observer observe.Sink, def *Definition, extra ...agent.Option) (*agent.Agent, error) {
+	if def == nil {
+		return nil, fmt.Errorf("flow definition is nil")
+	}
+	if name := strings.TrimSpace(def.Workflow); name != "" {
+		b, ok := workflow.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("flow %q: unknown workflow %q (available: %s)", def.Name, name, strings.Join(workflow.Names(), ", "))
+		}
+		if _, err := workflow.Configure(b, def.WorkflowParams); err != nil {
+			return nil, fmt.Errorf("flow %q: %w", def.Name, err)
+		}
+	} else if len(def.WorkflowParams) > 0 {
+		return nil, fmt.Errorf("flow %q: workflow params set without a workflow", def.Name)
+	}
+
+	systemPrompt := strings.TrimSpace(def.SystemPrompt)
+	if systemPrompt == "" {
+		systemPrompt = DefaultSystemPrompt
+	}
+	selection := append([]string(nil), def.Tools...)
+	skills, err := skill.ResolveDependencies(def.Skills)
+	if err != nil {
+		return nil, fmt.Errorf("flow %q: %w", def.Name, err)
+	}
+	for _, s := range skills {
+		if s.Instructions != "" {
+			systemPrompt += "\n\n## Skill: " + s.Name + "\n" + s.Instructions
+		}
+		selection = append(selection, s.AllowedTools...)
+	}
+
+	opts := []agent.Option{agent.WithSystemPrompt(systemPrompt)}
+	if store != nil {
+		opts = append(opts, agent.WithStore(store))
+	}
+	if observer != nil {
+		opts = append(opts, agent.WithObserver(observer))
+	}
+	if len(selection) > 0 {
+		selected, err := tools.BuildSelection(selection)
+		if err != nil {
+			return nil, fmt.Errorf("flow %q: tool selection failed: %w", def.Name, err)
+		}
+		for _, t := range selected {
+			opts = append(opts, agent.WithTool(t))
+		}
+	}
+	opts = append(opts, extra...)
+
+	a, err := agent.New(provider, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("flow %q: %w", def.Name, err)
+	}
+	return a, nil
+}
+
+// BuildExecutor wraps an agent built by BuildAgent in the flow's workflow
+// graph. It fails when the flow has no workflow.
+func BuildExecutor(a *agent.Agent, store state.Store, observer observe.Sink, def *Definition) (workflow.Executor, error) {
+	if def == nil {
+		return nil, fmt.Errorf("flow definition is nil")
+	}
+	if strings.TrimSpace(def.Workflow) == "" {
+		return nil, fmt.Errorf("flow %q has no workflow", def.Name)
+	}
+	exec, err := workflow.NewExecutor(a, def.Workflow, workflow.Config{
+		Store:    store,
+		Observer: observer,
+		Params:   def.WorkflowParams,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("flow %q: %w", def.Name, err)
+	}
+	return exec, nil
+}
@@ -0,0 +1,149 @@
+package flow_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/flow"
+	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/basic"
+	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/router"
+	"github.com/PipeOpsHQ/agent-sdk-go/llm"
+	"github.com/PipeOpsHQ/agent-sdk-go/skill"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
+)
+
+// promptProvider records the system prompt of the last request.
+type promptProvider struct{ systemPrompt string }
+
+func (p *promptProvider) Name() string { return "prompt" }
+
+func (p *promptProvider) Capabilities() llm.Capabilities { return llm.Capabilities{Tools: true} }
+
+func (p *promptProvider) Generate(_ context.Context, req types.Request) (types.Response, error) {
+	p.systemPrompt = req.SystemPrompt
+	return types.Response{Message: types.Message{Role: types.RoleAssistant, Content: "ok"}}, nil
+}
+
+func TestBuildAgent(t *testing.T) {
+	skill.Reset()
+	defer skill.Reset()
+	for _, s := range []*skill.Skill{
+		{Name: "base", Description: "base", Instructions: "Base rules.", AllowedTools: []string{"calculator"}},
+		{Name: "extra", Description: "extra", Instructions: "Extra rules.", Requires: []string{"base"}},
+	} {
+		if err := skill.Register(s); err != nil {
+			t.Fatalf("register skill %q: %v", s.Name, err)
+		}
+	}
+
+	tests := map[string]struct {
+		def        *flow.Definition
+		wantErr    string
+		wantPrompt []string
+		wantTools  []string
+	}{
+		"nil definition": {
+			wantErr: "definition is nil",
+		},
+		"default prompt": {
+			def:        &flow.Definition{Name: "plain"},
+			wantPrompt: []string{flow.DefaultSystemPrompt},
+		},
+		"custom prompt and tools": {
+			def:        &flow.Definition{Name: "custom", SystemPrompt: "Be brief.", Tools: []string{"calculator"}},
+			wantPrompt: []string{"Be brief."},
+			wantTools:  []string{"calculator"},
+		},
+		"skills and their requirements": {
+			def:        &flow.Definition{Name: "skilled", SystemPrompt: "Be brief.", Skills: []string{"extra"}},
+			wantPrompt: []string{"Be brief.", "## Skill: base\nBase rules.", "## Skill: extra\nExtra rules."},
+			wantTools:  []string{"calculator"},
+		},
+		"unknown skill": {
+			def:     &flow.Definition{Name: "bad-skill", Skills: []string{"missing"}},
+			wantErr: "missing",
+		},
+		"unknown tool": {
+			def:     &flow.Definition{Name: "bad-tool", Tools: []string{"no_such_tool"}},
+			wantErr: "tool selection failed",
+		},
+		"unknown workflow": {
+			def:     &flow.Definition{Name: "bad-workflow", Workflow: "no-such-workflow"},
+			wantErr: "unknown workflow",
+		},
+		"params without workflow": {
+			def:     &flow.Definition{Name: "params", WorkflowParams: map[string]any{"k": "v"}},
+			wantErr: "without a workflow",
+		},
+		"invalid workflow params": {
+			def:     &flow.Definition{Name: "bad-params", Workflow: "router", WorkflowParams: map[string]any{"routez": 1}},
+			wantErr: "bad-params",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider := &promptProvider{}
+			a, err := flow.BuildAgent(provider, nil, nil, tc.def)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("BuildAgent error = %v, want it to contain %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildAgent: %v", err)
+			}
+			if _, err := a.Run(context.Background(), "hi"); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			for _, want := range tc.wantPrompt {
+				if !strings.Contains(provider.systemPrompt, want) {
+					t.Errorf("system prompt %q does not contain %q", provider.systemPrompt, want)
+				}
+			}
+			if got := a.ListTools(); !slices.Equal(got, tc.wantTools) {
+				t.Errorf("tools = %v, want %v", got, tc.wantTools)
+			}
+		})
+	}
+}
+
+func TestBuildExecutor(t *testing.T) {
+	provider := &promptProvider{}
+	tests := map[string]struct {
+		def     *flow.Definition
+		wantErr string
+	}{
+		"nil definition":   {wantErr: "definition is nil"},
+		"no workflow":      {def: &flow.Definition{Name: "plain"}, wantErr: "has no workflow"},
+		"unknown workflow": {def: &flow.Definition{Name: "bad", Workflow: "no-such-workflow"}, wantErr: "bad"},
+		"basic workflow":   {def: &flow.Definition{Name: "basic", Workflow: "basic"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, err := flow.BuildAgent(provider, nil, nil, &flow.Definition{Name: "agent"})
+			if err != nil {
+				t.Fatalf("BuildAgent: %v", err)
+			}
+			exec, err := flow.BuildExecutor(a, nil, nil, tc.def)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("BuildExecutor error = %v, want it to contain %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildExecutor: %v", err)
+			}
+			out, err := exec.Run(context.Background(), "hello")
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if out.Output == "" {
+				t.Fatal("expected output from workflow")
+			}
+		})
+	}
+}
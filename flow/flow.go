@@ -11,15 +11,17 @@ import (
 
 // Definition describes a named agent flow that can be executed from the DevUI.
 type Definition struct {
-	Name         string         `json:"name"`
-	Description  string         `json:"description,omitempty"`
-	Workflow     string         `json:"workflow,omitempty"`
-	Tools        []string       `json:"tools,omitempty"`
-	Skills       []string       `json:"skills,omitempty"`
-	SystemPrompt string         `json:"systemPrompt,omitempty"`
-	InputExample string         `json:"inputExample,omitempty"`
-	InputSchema  map[string]any `json:"inputSchema,omitempty"`
-	OutputSchema map[string]any `json:"outputSchema,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Workflow    string `json:"workflow,omitempty"`
+	// WorkflowParams configure the workflow; see workflow.Configurable.
+	WorkflowParams map[string]any `json:"workflowParams,omitempty"`
+	Tools          []string       `json:"tools,omitempty"`
+	Skills         []string       `json:"skills,omitempty"`
+	SystemPrompt   string         `json:"systemPrompt,omitempty"`
+	InputExample   string         `json:"inputExample,omitempty"`
+	InputSchema    map[string]any `json:"inputSchema,omitempty"`
+	OutputSchema   map[string]any `json:"outputSchema,omitempty"`
 }
 
 var (
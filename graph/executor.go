@@ -8,10 +8,23 @@ import (
 
 	"github.com/PipeOpsHQ/agent-sdk-go/observe"
 	"github.com/PipeOpsHQ/agent-sdk-go/state"
+	"github.com/PipeOpsHQ/agent-sdk-go/storage"
 	"github.com/PipeOpsHQ/agent-sdk-go/types"
 	"github.com/google/uuid"
 )
 
+// NodeError is returned when a node fails. Steps holds the steps executed
+// so far, ending with the failed one.
+type NodeError struct {
+	NodeID string
+	Steps  []types.StepResult
+	Err    error
+}
+
+func (e *NodeError) Error() string { return fmt.Sprintf("node %q failed: %v", e.NodeID, e.Err) }
+
+func (e *NodeError) Unwrap() error { return e.Err }
+
 type Executor struct {
 	graph     *Graph
 	store     state.Store
@@ -125,7 +138,7 @@ func (e *Executor) Resume(ctx context.Context, runID string) (types.RunResult, e
 	checkpoint, err := e.store.LoadLatestCheckpoint(ctx, runID)
 	if err != nil {
 		if errors.Is(err, state.ErrNotFound) {
-			if run.Status == "completed" {
+			if run.Status == state.RunCompleted {
 				return types.RunResult{
 					Output:      run.Output,
 					Provider:    run.Provider,
@@ -172,7 +185,7 @@ func (e *Executor) Resume(ctx context.Context, runID string) (types.RunResult, e
 	}
 	if nextNodeID == "" {
 		completedAt := time.Now().UTC()
-		if err := e.persistRun(ctx, runtimeState, "completed", run.Output, nil, &completedAt); err != nil {
+		if err := e.persistRun(ctx, runtimeState, state.RunCompleted, run.Output, nil, &completedAt); err != nil {
 			return types.RunResult{}, err
 		}
 		return types.RunResult{
@@ -192,11 +205,15 @@ func (e *Executor) execute(ctx context.Context, runtimeState State, startNodeID
 	if startNodeID == "" {
 		return types.RunResult{}, fmt.Errorf("start node is empty")
 	}
-	if err := e.persistRun(ctx, runtimeState, "running", "", nil, nil); err != nil {
+	if err := e.persistRun(ctx, runtimeState, state.RunRunning, "", nil, nil); err != nil {
 		return types.RunResult{}, err
 	}
+	if storage.RunIDFromContext(ctx) == "" {
+		ctx = storage.WithRunID(ctx, runtimeState.RunID)
+	}
 
 	nodeTrace := []string{}
+	var steps []types.StepResult
 	events := []types.Event{
 		{
 			Type:      types.EventRunStarted,
@@ -228,10 +245,24 @@ func (e *Executor) execute(ctx context.Context, runtimeState State, startNodeID
 		})
 		e.emitRuntimeEvent(ctx, events[len(events)-1])
 
+		stepStarted := time.Now().UTC()
+		outputBefore := runtimeState.Output
 		if err := node.Execute(ctx, &runtimeState); err != nil {
+			steps = append(steps, types.StepResult{
+				Name:      currentNodeID,
+				StartedAt: stepStarted,
+				Duration:  time.Since(stepStarted),
+				Error:     err.Error(),
+			})
 			_ = e.persistFailure(ctx, runtimeState, err)
-			return types.RunResult{}, fmt.Errorf("node %q failed: %w", currentNodeID, err)
+			return types.RunResult{}, &NodeError{NodeID: currentNodeID, Steps: steps, Err: err}
 		}
+		steps = append(steps, types.StepResult{
+			Name:      currentNodeID,
+			Output:    stepOutput(node, outputBefore, &runtimeState),
+			StartedAt: stepStarted,
+			Duration:  time.Since(stepStarted),
+		})
 
 		runtimeState.LastNodeID = currentNodeID
 		runtimeState.UpdatedAt = time.Now().UTC()
@@ -258,7 +289,7 @@ func (e *Executor) execute(ctx context.Context, runtimeState State, startNodeID
 		})
 		e.emitRuntimeEvent(ctx, events[len(events)-1])
 
-		if err := e.persistRun(ctx, runtimeState, "running", "", nil, nil); err != nil {
+		if err := e.persistRun(ctx, runtimeState, state.RunRunning, "", nil, nil); err != nil {
 			return types.RunResult{}, err
 		}
 		currentNodeID = nextNodeID
@@ -273,7 +304,7 @@ func (e *Executor) execute(ctx context.Context, runtimeState State, startNodeID
 			}
 		}
 	}
-	if err := e.persistRun(ctx, runtimeState, "completed", output, nil, &completedAt); err != nil {
+	if err := e.persistRun(ctx, runtimeState, state.RunCompleted, output, nil, &completedAt); err != nil {
 		return types.RunResult{}, err
 	}
 	events = append(events, types.Event{
@@ -297,9 +328,31 @@ func (e *Executor) execute(ctx context.Context, runtimeState State, startNodeID
 		CompletedAt: &completedAt,
 		Events:      events,
 		NodeTrace:   nodeTrace,
+		Steps:       steps,
 	}, nil
 }
 
+// stepOutput is what a node produced: the agent's reply for agent nodes, the
+// chosen route for router nodes, and otherwise the run output if the node
+// changed it.
+func stepOutput(node Node, outputBefore string, s *State) string {
+	switch n := node.(type) {
+	case *AgentNode:
+		return s.Output
+	case *RouterNode:
+		key := n.RouteKey
+		if key == "" {
+			key = "route"
+		}
+		route, _ := s.Data[key].(string)
+		return route
+	}
+	if s.Output != outputBefore {
+		return s.Output
+	}
+	return ""
+}
+
 func (e *Executor) selectNextNode(ctx context.Context, from string, runtimeState *State) (string, error) {
 	edges := e.graph.edges[from]
 	for _, edge := range edges {
@@ -366,7 +419,7 @@ func (e *Executor) persistFailure(ctx context.Context, runtimeState State, runEr
 		Error:     errText,
 		Message:   "graph run failed",
 	})
-	return e.persistRun(ctx, runtimeState, "failed", "", &errText, &completedAt)
+	return e.persistRun(ctx, runtimeState, state.RunFailed, "", &errText, &completedAt)
 }
 
 func (e *Executor) persistRun(
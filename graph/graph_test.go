@@ -205,6 +205,65 @@ func TestExecutor_Run_DeterministicThreeNodes(t *testing.T) {
 	}
 }
 
+func TestExecutor_Run_RecordsSteps(t *testing.T) {
+	g := New("steps")
+	g.AddNode("prepare", NewToolNode(func(ctx context.Context, s *State) error {
+		s.ensureData()
+		s.Data["prepared"] = true
+		return nil
+	}))
+	g.AddNode("analyze", NewAgentNode(&fakeRunner{output: "analysis"}, nil))
+	g.AddNode("summarize", NewToolNode(func(ctx context.Context, s *State) error {
+		s.Output = "summary of " + s.Output
+		return nil
+	}))
+	g.SetStart("prepare")
+	g.AddEdge("prepare", "analyze", nil)
+	g.AddEdge("analyze", "summarize", nil)
+
+	executor, err := NewExecutor(g)
+	if err != nil {
+		t.Fatalf("failed to build executor: %v", err)
+	}
+	result, err := executor.Run(context.Background(), "in")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	want := []struct{ name, output string }{
+		{"prepare", ""},
+		{"analyze", "analysis:in"},
+		{"summarize", "summary of analysis:in"},
+	}
+	if len(result.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got %#v", len(want), result.Steps)
+	}
+	for i, w := range want {
+		step := result.Steps[i]
+		if step.Name != w.name || step.Output != w.output || step.Error != "" || step.StartedAt.IsZero() {
+			t.Errorf("step %d = %+v, want name=%q output=%q", i, step, w.name, w.output)
+		}
+	}
+
+	boom := errors.New("boom")
+	failing := New("failing")
+	failing.AddNode("ok", NewAgentNode(&fakeRunner{output: "fine"}, nil))
+	failing.AddNode("bad", NewToolNode(func(ctx context.Context, s *State) error { return boom }))
+	failing.SetStart("ok")
+	failing.AddEdge("ok", "bad", nil)
+	executor, err = NewExecutor(failing)
+	if err != nil {
+		t.Fatalf("failed to build executor: %v", err)
+	}
+	_, err = executor.Run(context.Background(), "in")
+	var nodeErr *NodeError
+	if !errors.Is(err, boom) || !errors.As(err, &nodeErr) {
+		t.Fatalf("expected NodeError wrapping boom, got %v", err)
+	}
+	if nodeErr.NodeID != "bad" || len(nodeErr.Steps) != 2 || nodeErr.Steps[0].Output != "fine:in" || nodeErr.Steps[1].Error != "boom" {
+		t.Fatalf("expected partial steps on failure, got %#v", nodeErr)
+	}
+}
+
 func TestExecutor_Resume_FromCheckpoint(t *testing.T) {
 	store := newMemoryStore()
 	var midCalls int
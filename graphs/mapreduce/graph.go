@@ -3,6 +3,7 @@ package mapreduce
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/PipeOpsHQ/agent-sdk-go/graph"
@@ -12,21 +13,102 @@ import (
 
 const Name = "map-reduce"
 
-type Builder struct{}
+type Builder struct {
+	opts []Option
+}
 
 func (Builder) Name() string { return Name }
 func (Builder) Description() string {
 	return "Map-reduce: split input → process parts → combine results."
 }
 
-func (Builder) NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string) (*graph.Executor, error) {
-	return NewExecutor(runner, store, sessionID)
+func (b Builder) NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string) (*graph.Executor, error) {
+	return NewExecutor(runner, store, sessionID, b.opts...)
+}
+
+// Params are the workflow params accepted by Configure.
+type Params struct {
+	// Strategy is a built-in reduce strategy; see WithReduceStrategy.
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// Configure applies Params to a copy of b.
+func (b Builder) Configure(params map[string]any) (workflow.Builder, error) {
+	var p Params
+	if err := workflow.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	opt := WithReduceStrategy(p.Strategy)
+	var cfg config
+	opt(&cfg)
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
+	return Builder{opts: append(append([]Option(nil), b.opts...), opt)}, nil
+}
+
+// Reducer merges the per-sub-task map outputs into the final output.
+type Reducer func(parts []string) (string, error)
+
+// Built-in reduce strategies.
+const (
+	// StrategySummarize asks the agent to combine the map results (default).
+	StrategySummarize = "summarize"
+	// StrategyConcat joins the map outputs in sub-task order.
+	StrategyConcat = "concat"
+)
+
+// Concat joins parts with blank lines, skipping empty ones.
+func Concat(parts []string) (string, error) {
+	kept := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "\n\n"), nil
+}
+
+type config struct {
+	reducer Reducer
+	err     error
 }
 
-func NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string) (*graph.Executor, error) {
+type Option func(*config)
+
+// WithReducer replaces the LLM reduce step with a deterministic merge. The
+// map step then runs the agent once per sub-task so reducer receives one
+// output per sub-task, in order.
+func WithReducer(r Reducer) Option {
+	return func(c *config) { c.reducer = r }
+}
+
+// WithReduceStrategy selects a built-in strategy by name: "summarize" or
+// "concat". Unknown names are rejected by NewExecutor.
+func WithReduceStrategy(name string) Option {
+	return func(c *config) {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "", StrategySummarize:
+			c.reducer = nil
+		case StrategyConcat:
+			c.reducer = Concat
+		default:
+			c.err = fmt.Errorf("unknown reduce strategy %q", name)
+		}
+	}
+}
+
+func NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string, opts ...Option) (*graph.Executor, error) {
 	if runner == nil {
 		return nil, fmt.Errorf("runner is required")
 	}
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
 	g := graph.New(Name)
 
 	// Split — break input into logical parts for parallel-style processing
@@ -41,37 +123,71 @@ Request: %s`, strings.TrimSpace(s.Input)), nil
 		OutputKey: "subtasks",
 	})
 
-	// Map — process all sub-tasks sequentially (the agent handles them as a batch)
-	g.AddNode("map", &graph.AgentNode{
-		Runner: runner,
-		Input: func(s *graph.State) (string, error) {
-			s.EnsureData()
-			subtasks, _ := s.Data["subtasks"].(string)
-			return fmt.Sprintf(`Complete each of the following sub-tasks. For each one, provide a clear, thorough response. Label each response with its sub-task number.
+	if cfg.reducer == nil {
+		// Map — process all sub-tasks sequentially (the agent handles them as a batch)
+		g.AddNode("map", &graph.AgentNode{
+			Runner: runner,
+			Input: func(s *graph.State) (string, error) {
+				s.EnsureData()
+				subtasks, _ := s.Data["subtasks"].(string)
+				return fmt.Sprintf(`Complete each of the following sub-tasks. For each one, provide a clear, thorough response. Label each response with its sub-task number.
 
 Original request: %s
 
 Sub-tasks:
 %s`, strings.TrimSpace(s.Input), subtasks), nil
-		},
-		OutputKey: "mapped_results",
-	})
-
-	// Reduce — combine all results into a coherent final output
-	g.AddNode("reduce", &graph.AgentNode{
-		Runner: runner,
-		Input: func(s *graph.State) (string, error) {
-			s.EnsureData()
-			results, _ := s.Data["mapped_results"].(string)
-			return fmt.Sprintf(`Combine the following sub-task results into a single coherent, well-structured response. Remove redundancy, ensure consistency, and present the final answer clearly.
+			},
+			OutputKey: "mapped_results",
+		})
+
+		// Reduce — combine all results into a coherent final output
+		g.AddNode("reduce", &graph.AgentNode{
+			Runner: runner,
+			Input: func(s *graph.State) (string, error) {
+				s.EnsureData()
+				results, _ := s.Data["mapped_results"].(string)
+				return fmt.Sprintf(`Combine the following sub-task results into a single coherent, well-structured response. Remove redundancy, ensure consistency, and present the final answer clearly.
 
 Original request: %s
 
 Sub-task results:
 %s`, strings.TrimSpace(s.Input), results), nil
-		},
-		OutputKey: "final_output",
-	})
+			},
+			OutputKey: "final_output",
+		})
+	} else {
+		// Map — run each sub-task on its own so outputs can be merged exactly
+		g.AddNode("map", graph.NewToolNode(func(ctx context.Context, s *graph.State) error {
+			s.EnsureData()
+			raw, _ := s.Data["subtasks"].(string)
+			subtasks := parseSubtasks(raw)
+			parts := make([]string, 0, len(subtasks))
+			for i, task := range subtasks {
+				res, err := runner.RunDetailed(ctx, fmt.Sprintf(`Complete the following sub-task and respond with only its result.
+
+Original request: %s
+
+Sub-task: %s`, strings.TrimSpace(s.Input), task))
+				if err != nil {
+					return fmt.Errorf("sub-task %d: %w", i+1, err)
+				}
+				parts = append(parts, res.Output)
+			}
+			s.Data["mapped_parts"] = parts
+			return nil
+		}))
+
+		// Reduce — merge with the configured reducer, no LLM pass
+		g.AddNode("reduce", graph.NewToolNode(func(_ context.Context, s *graph.State) error {
+			s.EnsureData()
+			out, err := cfg.reducer(stringSlice(s.Data["mapped_parts"]))
+			if err != nil {
+				return fmt.Errorf("reduce: %w", err)
+			}
+			s.Data["final_output"] = out
+			return nil
+		}))
+	}
 
 	// Finalize — set output
 	g.AddNode("finalize", graph.NewToolNode(func(ctx context.Context, s *graph.State) error {
@@ -88,11 +204,45 @@ Sub-task results:
 	g.AddEdge("map", "reduce", nil)
 	g.AddEdge("reduce", "finalize", nil)
 
-	opts := []graph.ExecutorOption{graph.WithStore(store)}
+	execOpts := []graph.ExecutorOption{graph.WithStore(store)}
 	if sessionID != "" {
-		opts = append(opts, graph.WithSessionID(sessionID))
+		execOpts = append(execOpts, graph.WithSessionID(sessionID))
+	}
+	return graph.NewExecutor(g, execOpts...)
+}
+
+var subtaskPrefix = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*•])\s*`)
+
+// parseSubtasks extracts one sub-task per non-empty line of the split
+// output, stripping list markers.
+func parseSubtasks(raw string) []string {
+	var out []string
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(subtaskPrefix.ReplaceAllString(line, ""))
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+	if len(out) == 0 && strings.TrimSpace(raw) != "" {
+		out = []string{strings.TrimSpace(raw)}
+	}
+	return out
+}
+
+// stringSlice reads mapped parts, which decode as []any after a resume
+// from checkpoint.
+func stringSlice(v any) []string {
+	switch parts := v.(type) {
+	case []string:
+		return parts
+	case []any:
+		out := make([]string, 0, len(parts))
+		for _, p := range parts {
+			out = append(out, fmt.Sprint(p))
+		}
+		return out
 	}
-	return graph.NewExecutor(g, opts...)
+	return nil
 }
 
 func init() {
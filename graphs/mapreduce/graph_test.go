@@ -0,0 +1,171 @@
+package mapreduce
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/state"
+	statememory "github.com/PipeOpsHQ/agent-sdk-go/state/memory"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
+)
+
+// scriptedRunner answers the split prompt with a fixed list and every other
+// prompt with "done: <sub-task>".
+type scriptedRunner struct{ split string }
+
+func (r scriptedRunner) RunDetailed(_ context.Context, input string) (types.RunResult, error) {
+	if strings.HasPrefix(input, "Break the following request") {
+		return types.RunResult{Output: r.split}, nil
+	}
+	_, task, _ := strings.Cut(input, "Sub-task: ")
+	return types.RunResult{Output: "done: " + task}, nil
+}
+
+func TestConcat(t *testing.T) {
+	tests := map[string]struct {
+		parts []string
+		want  string
+	}{
+		"nil":          {nil, ""},
+		"single":       {[]string{"a"}, "a"},
+		"skips empty":  {[]string{" a ", "", "  ", "b"}, "a\n\nb"},
+		"keeps order":  {[]string{"3", "1", "2"}, "3\n\n1\n\n2"},
+		"trims blanks": {[]string{"\nx\n"}, "x"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Concat(tc.parts)
+			if err != nil || got != tc.want {
+				t.Fatalf("Concat(%q) = %q, %v; want %q", tc.parts, got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithReduceStrategy(t *testing.T) {
+	tests := map[string]struct {
+		name       string
+		wantConcat bool
+		wantErr    bool
+	}{
+		"default":   {name: ""},
+		"summarize": {name: "summarize"},
+		"concat":    {name: " Concat ", wantConcat: true},
+		"unknown":   {name: "vote", wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config{reducer: Concat}
+			WithReduceStrategy(tc.name)(&cfg)
+			if (cfg.err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", cfg.err, tc.wantErr)
+			}
+			if tc.wantErr {
+				if _, err := NewExecutor(scriptedRunner{}, nil, "", WithReduceStrategy(tc.name)); err == nil {
+					t.Fatal("NewExecutor accepted an unknown strategy")
+				}
+				return
+			}
+			if got := cfg.reducer != nil; got != tc.wantConcat {
+				t.Fatalf("reducer set = %v, want %v", got, tc.wantConcat)
+			}
+		})
+	}
+}
+
+func TestParseSubtasks(t *testing.T) {
+	tests := map[string]struct {
+		raw  string
+		want []string
+	}{
+		"empty":          {"  \n ", nil},
+		"numbered":       {"1. first\n2) second", []string{"first", "second"}},
+		"bullets":        {"- one\n* two\n• three", []string{"one", "two", "three"}},
+		"blank lines":    {"1. a\n\n\n2. b\n", []string{"a", "b"}},
+		"plain lines":    {"alpha\nbeta", []string{"alpha", "beta"}},
+		"only a marker":  {"1.", []string{"1."}},
+		"indented items": {"   3. deep", []string{"deep"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseSubtasks(tc.raw); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("parseSubtasks(%q) = %q, want %q", tc.raw, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringSlice(t *testing.T) {
+	tests := map[string]struct {
+		in   any
+		want []string
+	}{
+		"nil":               {nil, nil},
+		"strings":           {[]string{"a", "b"}, []string{"a", "b"}},
+		"decoded from json": {[]any{"a", 2.0}, []string{"a", "2"}},
+		"other type":        {"a", nil},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := stringSlice(tc.in); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("stringSlice(%#v) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewExecutor_ConcatStrategy(t *testing.T) {
+	exec, err := NewExecutor(scriptedRunner{split: "1. first\n2. second"}, nil, "", WithReduceStrategy(StrategyConcat))
+	if err != nil {
+		t.Fatalf("NewExecutor: %v", err)
+	}
+	res, err := exec.Run(context.Background(), "do both")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if want := "done: first\n\ndone: second"; res.Output != want {
+		t.Fatalf("output = %q, want %q", res.Output, want)
+	}
+}
+
+func TestNewExecutor_ReducerResumesFromCheckpoint(t *testing.T) {
+	store := statememory.New()
+	var (
+		calls int
+		got   []string
+	)
+	reducer := func(parts []string) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("transient reduce error")
+		}
+		got = parts
+		return strings.Join(parts, "|"), nil
+	}
+	exec, err := NewExecutor(scriptedRunner{split: "- a\n- b"}, store, "sess-mr", WithReducer(reducer))
+	if err != nil {
+		t.Fatalf("NewExecutor: %v", err)
+	}
+	if _, err := exec.Run(context.Background(), "input"); err == nil {
+		t.Fatal("expected the first run to fail in reduce")
+	}
+	runs, err := store.ListRuns(context.Background(), state.ListRunsQuery{SessionID: "sess-mr"})
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("ListRuns = %v, %v; want one run", runs, err)
+	}
+
+	// The mapped parts come back from the checkpoint as []any.
+	res, err := exec.Resume(context.Background(), runs[0].RunID)
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if want := []string{"done: a", "done: b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("reducer parts after resume = %q, want %q", got, want)
+	}
+	if res.Output != "done: a|done: b" {
+		t.Fatalf("output = %q", res.Output)
+	}
+}
@@ -12,21 +12,137 @@ import (
 
 const Name = "router"
 
-type Builder struct{}
+type Builder struct {
+	opts []Option
+}
 
 func (Builder) Name() string { return Name }
 func (Builder) Description() string {
 	return "Classification router: classify input → route to specialized handler."
 }
 
-func (Builder) NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string) (*graph.Executor, error) {
-	return NewExecutor(runner, store, sessionID)
+func (b Builder) NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string) (*graph.Executor, error) {
+	return NewExecutor(runner, store, sessionID, b.opts...)
+}
+
+// Route is one category the classifier can pick. Description is shown to the
+// classifier; Prompt is the system context for the route's handler.
+type Route struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Prompt      string `json:"prompt"`
+}
+
+// DefaultRoutes are used when no routes are configured.
+var DefaultRoutes = []Route{
+	{Name: "code", Description: "Writing, debugging, reviewing, or explaining code",
+		Prompt: "You are an expert software engineer. Write clean, efficient, well-documented code. Include error handling and tests when appropriate."},
+	{Name: "data", Description: "Data analysis, transformation, querying, or visualization",
+		Prompt: "You are an expert data analyst. Provide clear analysis, use appropriate statistical methods, and present findings clearly."},
+	{Name: "writing", Description: "Creative writing, editing, summarizing, or translating text",
+		Prompt: "You are an expert writer and editor. Produce clear, engaging, well-structured content tailored to the audience."},
+	{Name: "ops", Description: "DevOps, infrastructure, deployment, monitoring, or system administration",
+		Prompt: "You are an expert DevOps/SRE engineer. Provide production-ready solutions with security, scalability, and reliability in mind."},
+	{Name: "general", Description: "Anything that doesn't fit the above categories",
+		Prompt: "You are a helpful, knowledgeable assistant. Provide accurate, well-reasoned answers."},
 }
 
-func NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string) (*graph.Executor, error) {
+// DefaultRoute is the fallback route name when none is configured.
+const DefaultRoute = "general"
+
+type config struct {
+	routes   []Route
+	fallback string
+}
+
+type Option func(*config)
+
+// WithRoutes replaces the built-in routes. fallback names the route used
+// when the classification matches none; empty means the last route.
+func WithRoutes(routes []Route, fallback string) Option {
+	return func(c *config) {
+		c.routes = routes
+		c.fallback = fallback
+	}
+}
+
+// Params are the workflow params accepted by Configure.
+type Params struct {
+	Routes  []Route `json:"routes,omitempty"`
+	Default string  `json:"default,omitempty"`
+}
+
+// Configure applies Params to a copy of b.
+func (b Builder) Configure(params map[string]any) (workflow.Builder, error) {
+	var p Params
+	if err := workflow.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	cfg := config{routes: DefaultRoutes, fallback: DefaultRoute}
+	if len(p.Routes) > 0 {
+		cfg.routes, cfg.fallback = p.Routes, ""
+	}
+	if p.Default != "" {
+		cfg.fallback = p.Default
+	}
+	if _, _, err := normalize(cfg); err != nil {
+		return nil, err
+	}
+	opt := WithRoutes(cfg.routes, cfg.fallback)
+	return Builder{opts: append(append([]Option(nil), b.opts...), opt)}, nil
+}
+
+// normalize validates route names and resolves the fallback route.
+func normalize(cfg config) ([]Route, string, error) {
+	if len(cfg.routes) == 0 {
+		return nil, "", fmt.Errorf("at least one route is required")
+	}
+	routes := make([]Route, 0, len(cfg.routes))
+	seen := map[string]bool{}
+	for _, r := range cfg.routes {
+		r.Name = strings.ToLower(strings.TrimSpace(r.Name))
+		if r.Name == "" {
+			return nil, "", fmt.Errorf("route name is required")
+		}
+		if seen[r.Name] {
+			return nil, "", fmt.Errorf("duplicate route %q", r.Name)
+		}
+		if strings.TrimSpace(r.Prompt) == "" {
+			return nil, "", fmt.Errorf("route %q: prompt is required", r.Name)
+		}
+		seen[r.Name] = true
+		routes = append(routes, r)
+	}
+	fallback := strings.ToLower(strings.TrimSpace(cfg.fallback))
+	if fallback == "" {
+		fallback = routes[len(routes)-1].Name
+	}
+	if !seen[fallback] {
+		return nil, "", fmt.Errorf("default route %q is not a configured route", fallback)
+	}
+	return routes, fallback, nil
+}
+
+func NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string, opts ...Option) (*graph.Executor, error) {
 	if runner == nil {
 		return nil, fmt.Errorf("runner is required")
 	}
+	cfg := config{routes: DefaultRoutes, fallback: DefaultRoute}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	routes, fallback, err := normalize(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var categories strings.Builder
+	for _, r := range routes {
+		desc := r.Description
+		if desc == "" {
+			desc = r.Name
+		}
+		fmt.Fprintf(&categories, "\n- %s: %s", r.Name, desc)
+	}
 	g := graph.New(Name)
 
 	// Classify — determine the category of the input
@@ -36,14 +152,9 @@ func NewExecutor(runner graph.AgentRunner, store state.Store, sessionID string)
 			s.EnsureData()
 			return fmt.Sprintf(`Classify the following request into exactly ONE category. Respond with ONLY the category name, nothing else.
 
-Categories:
-- code: Writing, debugging, reviewing, or explaining code
-- data: Data analysis, transformation, querying, or visualization
-- writing: Creative writing, editing, summarizing, or translating text
-- ops: DevOps, infrastructure, deployment, monitoring, or system administration
-- general: Anything that doesn't fit the above categories
+Categories:%s
 
-Request: %s`, strings.TrimSpace(s.Input)), nil
+Request: %s`, categories.String(), strings.TrimSpace(s.Input)), nil
 		},
 		OutputKey: "category",
 	})
@@ -52,32 +163,20 @@ Request: %s`, strings.TrimSpace(s.Input)), nil
 	g.AddNode("route", graph.NewRouterNode(func(ctx context.Context, s *graph.State) (string, error) {
 		_ = ctx
 		s.EnsureData()
-		category := strings.ToLower(strings.TrimSpace(s.Data["category"].(string)))
-		switch {
-		case strings.Contains(category, "code"):
-			return "code", nil
-		case strings.Contains(category, "data"):
-			return "data", nil
-		case strings.Contains(category, "writing"):
-			return "writing", nil
-		case strings.Contains(category, "ops"):
-			return "ops", nil
-		default:
-			return "general", nil
+		category, _ := s.Data["category"].(string)
+		category = strings.ToLower(strings.TrimSpace(category))
+		for _, r := range routes {
+			if r.Name != fallback && strings.Contains(category, r.Name) {
+				return r.Name, nil
+			}
 		}
+		return fallback, nil
 	}))
 
 	// Specialized handlers
-	addHandler(g, runner, "handle_code", "code",
-		"You are an expert software engineer. Write clean, efficient, well-documented code. Include error handling and tests when appropriate.")
-	addHandler(g, runner, "handle_data", "data",
-		"You are an expert data analyst. Provide clear analysis, use appropriate statistical methods, and present findings clearly.")
-	addHandler(g, runner, "handle_writing", "writing",
-		"You are an expert writer and editor. Produce clear, engaging, well-structured content tailored to the audience.")
-	addHandler(g, runner, "handle_ops", "ops",
-		"You are an expert DevOps/SRE engineer. Provide production-ready solutions with security, scalability, and reliability in mind.")
-	addHandler(g, runner, "handle_general", "general",
-		"You are a helpful, knowledgeable assistant. Provide accurate, well-reasoned answers.")
+	for _, r := range routes {
+		addHandler(g, runner, "handle_"+r.Name, r.Name, r.Prompt)
+	}
 
 	// Finalize — collect output
 	g.AddNode("finalize", graph.NewToolNode(func(ctx context.Context, s *graph.State) error {
@@ -94,22 +193,16 @@ Request: %s`, strings.TrimSpace(s.Input)), nil
 
 	g.SetStart("classify")
 	g.AddEdge("classify", "route", nil)
-	g.AddEdge("route", "handle_code", graph.RouteEquals("route", "code"))
-	g.AddEdge("route", "handle_data", graph.RouteEquals("route", "data"))
-	g.AddEdge("route", "handle_writing", graph.RouteEquals("route", "writing"))
-	g.AddEdge("route", "handle_ops", graph.RouteEquals("route", "ops"))
-	g.AddEdge("route", "handle_general", graph.RouteEquals("route", "general"))
-	g.AddEdge("handle_code", "finalize", nil)
-	g.AddEdge("handle_data", "finalize", nil)
-	g.AddEdge("handle_writing", "finalize", nil)
-	g.AddEdge("handle_ops", "finalize", nil)
-	g.AddEdge("handle_general", "finalize", nil)
-
-	opts := []graph.ExecutorOption{graph.WithStore(store)}
+	for _, r := range routes {
+		g.AddEdge("route", "handle_"+r.Name, graph.RouteEquals("route", r.Name))
+		g.AddEdge("handle_"+r.Name, "finalize", nil)
+	}
+
+	execOpts := []graph.ExecutorOption{graph.WithStore(store)}
 	if sessionID != "" {
-		opts = append(opts, graph.WithSessionID(sessionID))
+		execOpts = append(execOpts, graph.WithSessionID(sessionID))
 	}
-	return graph.NewExecutor(g, opts...)
+	return graph.NewExecutor(g, execOpts...)
 }
 
 func addHandler(g *graph.Graph, runner graph.AgentRunner, nodeID, category, systemContext string) {
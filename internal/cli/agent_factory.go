@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	agentfw "github.com/PipeOpsHQ/agent-sdk-go/agent"
+	"github.com/PipeOpsHQ/agent-sdk-go/flow"
 	"github.com/PipeOpsHQ/agent-sdk-go/graph"
 	"github.com/PipeOpsHQ/agent-sdk-go/llm"
 	"github.com/PipeOpsHQ/agent-sdk-go/observe"
@@ -69,22 +70,15 @@ func buildAgent(provider llm.Provider, store state.Store, observer observe.Sink,
 		}
 	}
 
-	agentOpts := []agentfw.Option{
-		agentfw.WithSystemPrompt(prompt),
-		agentfw.WithObserver(observer),
-		agentfw.WithStore(store),
-		agentfw.WithMaxIterations(maxIterationsFromEnv()),
-	}
+	def := &flow.Definition{Name: "cli", SystemPrompt: prompt, Tools: selection, Skills: opts.skills}
+	extra := []agentfw.Option{agentfw.WithMaxIterations(maxIterationsFromEnv())}
 	if len(opts.conversation) > 0 {
-		agentOpts = append(agentOpts, agentfw.WithConversationHistory(opts.conversation))
-	}
-	for _, tool := range toolset {
-		agentOpts = append(agentOpts, agentfw.WithTool(tool))
+		extra = append(extra, agentfw.WithConversationHistory(opts.conversation))
 	}
 	if len(opts.middlewares) > 0 {
-		agentOpts = append(agentOpts, agentfw.WithMiddleware(opts.middlewares...))
+		extra = append(extra, agentfw.WithMiddleware(opts.middlewares...))
 	}
-	return agentfw.New(provider, agentOpts...)
+	return flow.BuildAgent(provider, store, observer, def, extra...)
 }
 
 func maxIterationsFromEnv() int {
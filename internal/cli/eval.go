@@ -10,6 +10,7 @@ import (
 	"time"
 
 	evalfw "github.com/PipeOpsHQ/agent-sdk-go/eval"
+	evalsqlite "github.com/PipeOpsHQ/agent-sdk-go/eval/store/sqlite"
 	"github.com/PipeOpsHQ/agent-sdk-go/observe"
 )
 
@@ -26,6 +27,8 @@ type evalCLIOptions struct {
 	judgeRubric  string
 	judgeMin     float64
 	judgeEnabled bool
+	judgeTimeout time.Duration
+	reportDB     string
 	agentOpts    []string
 }
 
@@ -51,7 +54,11 @@ func runEvalCLI(ctx context.Context, args []string) {
 
 	var judge evalfw.Judge
 	if opts.judgeEnabled {
-		j, err := evalfw.NewLLMJudge(provider)
+		var judgeOpts []func(*evalfw.LLMJudge)
+		if opts.judgeTimeout > 0 {
+			judgeOpts = append(judgeOpts, evalfw.WithJudgeTimeout(opts.judgeTimeout))
+		}
+		j, err := evalfw.NewLLMJudge(provider, judgeOpts...)
 		if err != nil {
 			log.Fatalf("failed to create judge: %v", err)
 		}
@@ -78,6 +85,11 @@ func runEvalCLI(ctx context.Context, args []string) {
 	if err != nil {
 		log.Fatalf("eval run failed: %v", err)
 	}
+	if opts.reportDB != "" {
+		if err := saveEvalReport(ctx, opts.reportDB, &report); err != nil {
+			log.Printf("eval report not persisted: %v", err)
+		}
+	}
 
 	output := strings.ToLower(strings.TrimSpace(opts.output))
 	switch output {
@@ -98,6 +110,20 @@ func runEvalCLI(ctx context.Context, args []string) {
 	}
 }
 
+func saveEvalReport(ctx context.Context, path string, report *evalfw.Report) error {
+	store, err := evalsqlite.New(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+	id, err := store.SaveReport(ctx, *report)
+	if err != nil {
+		return err
+	}
+	report.ID = id
+	return nil
+}
+
 func parseEvalArgs(args []string) evalCLIOptions {
 	opts := evalCLIOptions{
 		output:       "markdown",
@@ -148,6 +174,8 @@ func parseEvalArgs(args []string) evalCLIOptions {
 			if v, err := strconv.Atoi(raw); err == nil && v > 0 {
 				opts.timeout = time.Duration(v) * time.Millisecond
 			}
+		case strings.HasPrefix(arg, "--report-db="):
+			opts.reportDB = strings.TrimSpace(strings.TrimPrefix(arg, "--report-db="))
 		case strings.HasPrefix(arg, "--judge-rubric="):
 			opts.judgeRubric = strings.TrimSpace(strings.TrimPrefix(arg, "--judge-rubric="))
 		case strings.HasPrefix(arg, "--judge-min-score="):
@@ -155,6 +183,11 @@ func parseEvalArgs(args []string) evalCLIOptions {
 			if v, err := strconv.ParseFloat(raw, 64); err == nil && v >= 0 && v <= 1 {
 				opts.judgeMin = v
 			}
+		case strings.HasPrefix(arg, "--judge-timeout-ms="):
+			raw := strings.TrimSpace(strings.TrimPrefix(arg, "--judge-timeout-ms="))
+			if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+				opts.judgeTimeout = time.Duration(v) * time.Millisecond
+			}
 		case arg == "--judge", arg == "--judge=true":
 			opts.judgeEnabled = true
 		case arg == "--judge=false":
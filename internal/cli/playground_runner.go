@@ -57,15 +57,10 @@ func (r *localPlaygroundRunner) Run(ctx context.Context, req devuiapi.Playground
 		}
 	}
 	appliedSkills := sortedSkillNames(allSkills)
-	systemPrompt := strings.TrimSpace(req.SystemPrompt)
-	for skillName := range allSkills {
-		if s, ok := skill.Get(skillName); ok {
-			if s.Instructions != "" {
-				systemPrompt += "\n\n## Skill: " + s.Name + "\n" + s.Instructions
-			}
-			if len(s.AllowedTools) > 0 {
-				req.Tools = append(req.Tools, s.AllowedTools...)
-			}
+	var knownSkills []string
+	for _, name := range appliedSkills {
+		if _, ok := skill.Get(name); ok {
+			knownSkills = append(knownSkills, name)
 		}
 	}
 	if explicitSystemPrompt != "" {
@@ -80,8 +75,6 @@ func (r *localPlaygroundRunner) Run(ctx context.Context, req devuiapi.Playground
 			return devuiapi.PlaygroundResponse{}, renderErr
 		}
 		req.SystemPrompt = rendered
-	} else if systemPrompt != "" {
-		req.SystemPrompt = systemPrompt
 	}
 	req.ReplyTo = delivery.Normalize(req.ReplyTo)
 	if req.ReplyTo != nil {
@@ -121,6 +114,7 @@ func (r *localPlaygroundRunner) Run(ctx context.Context, req devuiapi.Playground
 		sessionID:    strings.TrimSpace(req.SessionID),
 		tools:        append([]string(nil), req.Tools...),
 		systemPrompt: strings.TrimSpace(req.SystemPrompt),
+		skills:       knownSkills,
 	}
 	if len(req.History) > 0 {
 		opts.conversation = sanitizeConversationHistory(req.History)
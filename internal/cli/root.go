@@ -32,7 +32,7 @@ func Run(ctx context.Context, args []string) {
 	case "cron":
 		runCronCLI(ctx, args[1:])
 	case "skill":
-		runSkillCLI(args[1:])
+		runSkillCLI(ctx, args[1:])
 	case "eval":
 		runEvalCLI(ctx, args[1:])
 	case "help", "-h", "--help":
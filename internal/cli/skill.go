@@ -1,16 +1,19 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PipeOpsHQ/agent-sdk-go/skill"
 )
 
-func runSkillCLI(args []string) {
+func runSkillCLI(ctx context.Context, args []string) {
 	if len(args) == 0 {
 		printSkillUsage()
 		os.Exit(1)
@@ -52,7 +55,7 @@ func runSkillCLI(args []string) {
 		if len(s.AllowedTools) > 0 {
 			fmt.Printf("AllowedTools: %s\n", strings.Join(s.AllowedTools, ", "))
 		}
-		for k, v := range s.Metadata {
+		for k, v := range s.RedactedMetadata() {
 			fmt.Printf("Metadata.%s: %s\n", k, v)
 		}
 		if s.Instructions != "" {
@@ -62,16 +65,34 @@ func runSkillCLI(args []string) {
 
 	case "install":
 		if len(args) < 2 {
-			log.Fatal("usage: skill install <github-repo> [--dest=./skills]")
+			log.Fatal("usage: skill install <github-repo> [--dest=./skills] [--timeout-ms=300000]")
 		}
 		destDir := "./skills"
 		repoRef := args[1]
+		timeout := 5 * time.Minute
 		for _, a := range args[2:] {
-			if strings.HasPrefix(a, "--dest=") {
+			switch {
+			case strings.HasPrefix(a, "--dest="):
 				destDir = strings.TrimPrefix(a, "--dest=")
+			case strings.HasPrefix(a, "--timeout-ms="):
+				raw := strings.TrimSpace(strings.TrimPrefix(a, "--timeout-ms="))
+				v, err := strconv.Atoi(raw)
+				if err != nil || v <= 0 {
+					log.Fatalf("invalid --timeout-ms %q: must be a positive number of milliseconds", raw)
+				}
+				timeout = time.Duration(v) * time.Millisecond
 			}
 		}
-		n, err := skill.InstallFromGitHub(repoRef, destDir)
+		installCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		n, err := skill.InstallFromGitHub(installCtx, repoRef, destDir, skill.WithProgress(func(ev skill.InstallProgress) {
+			switch ev.Stage {
+			case skill.InstallStageInstalled:
+				fmt.Printf("  [%d] installed %s (%s)\n", ev.Installed, ev.Skill, ev.RepoPath)
+			case skill.InstallStageFailed:
+				fmt.Printf("  skipped %s: %v\n", ev.RepoPath, ev.Err)
+			}
+		}))
 		if err != nil {
 			log.Fatalf("install failed: %v", err)
 		}
@@ -116,7 +137,8 @@ func printSkillUsage() {
 	fmt.Println("Commands:")
 	fmt.Println("  list                        List installed skills")
 	fmt.Println("  show <name>                 Show skill details and instructions")
-	fmt.Println("  install <repo> [--dest=DIR] Install skills from GitHub repo")
+	fmt.Println("  install <repo> [--dest=DIR] [--timeout-ms=N]")
+	fmt.Println("                              Install skills from GitHub repo")
 	fmt.Println("  remove <name>               Remove a skill from registry")
 	fmt.Println("  create <name> <description> Create a new skill scaffold")
 }
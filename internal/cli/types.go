@@ -24,6 +24,7 @@ type cliOptions struct {
 	conversation   []types.Message
 	systemPrompt   string
 	promptTemplate string
+	skills         []string
 	middlewares    []agentfw.Middleware
 }
 
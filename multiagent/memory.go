@@ -1,6 +1,7 @@
 package multiagent
 
 import (
+	"sort"
 	"sync"
 	"time"
 )
@@ -21,6 +22,45 @@ type MemoryEntry struct {
 	UpdatedBy string         `json:"updatedBy,omitempty"`
 	UpdatedAt time.Time      `json:"updatedAt"`
 	TTL       time.Duration  `json:"ttl,omitempty"`
+	// RefreshedAt restarts the TTL window; see Touch and SetWithSlidingTTL.
+	RefreshedAt time.Time `json:"refreshedAt,omitempty"`
+	// Sliding entries refresh their TTL whenever they are read or written.
+	Sliding bool `json:"sliding,omitempty"`
+}
+
+// EntryStatus distinguishes a missing key from one whose TTL has lapsed.
+type EntryStatus int
+
+const (
+	// EntryAbsent means the key was never set, or was deleted or cleaned up.
+	EntryAbsent EntryStatus = iota
+	// EntryPresent means the key holds a live value.
+	EntryPresent
+	// EntryExpired means the key was set with a TTL that has passed but has
+	// not been removed by CleanupExpired yet.
+	EntryExpired
+)
+
+func (s EntryStatus) String() string {
+	switch s {
+	case EntryPresent:
+		return "present"
+	case EntryExpired:
+		return "expired"
+	default:
+		return "absent"
+	}
+}
+
+func (e *MemoryEntry) expired(now time.Time) bool {
+	if e.TTL <= 0 {
+		return false
+	}
+	from := e.CreatedAt
+	if e.RefreshedAt.After(from) {
+		from = e.RefreshedAt
+	}
+	return now.Sub(from) > e.TTL
 }
 
 // NewSharedMemory creates a new shared memory instance.
@@ -36,10 +76,13 @@ func (m *SharedMemory) Set(key string, value any, agentID string) {
 	defer m.mu.Unlock()
 
 	now := time.Now().UTC()
-	if existing, ok := m.entries[key]; ok {
+	if existing, ok := m.entries[key]; ok && !existing.expired(now) {
 		existing.Value = value
 		existing.UpdatedBy = agentID
 		existing.UpdatedAt = now
+		if existing.Sliding {
+			existing.RefreshedAt = now
+		}
 	} else {
 		m.entries[key] = &MemoryEntry{
 			Key:       key,
@@ -67,46 +110,93 @@ func (m *SharedMemory) SetWithTTL(key string, value any, agentID string, ttl tim
 	}
 }
 
-// Get retrieves a value from shared memory.
-func (m *SharedMemory) Get(key string) (any, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// SetWithSlidingTTL stores a value that expires ttl after its last use:
+// every Get, GetEntry, GetEntryStatus, or Set on the key restarts the window.
+func (m *SharedMemory) SetWithSlidingTTL(key string, value any, agentID string, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	entry, ok := m.entries[key]
-	if !ok {
-		return nil, false
+	now := time.Now().UTC()
+	m.entries[key] = &MemoryEntry{
+		Key:       key,
+		Value:     value,
+		CreatedBy: agentID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		TTL:       ttl,
+		Sliding:   true,
 	}
+}
 
-	// Check TTL
-	if entry.TTL > 0 {
-		if time.Since(entry.CreatedAt) > entry.TTL {
-			return nil, false
-		}
+// Touch restarts the TTL window of a live entry. It returns false when the
+// key is absent or already expired.
+func (m *SharedMemory) Touch(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now().UTC()
+	entry, ok := m.entries[key]
+	if !ok || entry.expired(now) {
+		return false
 	}
+	entry.RefreshedAt = now
+	return true
+}
 
+// Get retrieves a value from shared memory.
+func (m *SharedMemory) Get(key string) (any, bool) {
+	entry, status := m.lookup(key)
+	if status != EntryPresent {
+		return nil, false
+	}
 	return entry.Value, true
 }
 
 // GetEntry retrieves the full entry from shared memory.
 func (m *SharedMemory) GetEntry(key string) (*MemoryEntry, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	entry, status := m.GetEntryStatus(key)
+	if status != EntryPresent {
+		return nil, false
+	}
+	return entry, true
+}
+
+// GetEntryStatus reports whether key is present, expired, or absent. The
+// entry copy is returned for present and expired keys, so callers can see
+// what lapsed.
+func (m *SharedMemory) GetEntryStatus(key string) (*MemoryEntry, EntryStatus) {
+	return m.lookup(key)
+}
 
+// lookup returns a copy of the entry and its status, refreshing sliding
+// entries. Reads of non-sliding entries only take the read lock.
+func (m *SharedMemory) lookup(key string) (*MemoryEntry, EntryStatus) {
+	m.mu.RLock()
 	entry, ok := m.entries[key]
-	if !ok {
-		return nil, false
+	if !ok || !entry.Sliding || entry.expired(time.Now()) {
+		defer m.mu.RUnlock()
+		return entrySnapshot(entry, ok)
 	}
+	m.mu.RUnlock()
 
-	// Check TTL
-	if entry.TTL > 0 {
-		if time.Since(entry.CreatedAt) > entry.TTL {
-			return nil, false
-		}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok = m.entries[key]
+	if ok && entry.Sliding && !entry.expired(time.Now()) {
+		entry.RefreshedAt = time.Now().UTC()
 	}
+	return entrySnapshot(entry, ok)
+}
 
-	// Return a copy
+func entrySnapshot(entry *MemoryEntry, ok bool) (*MemoryEntry, EntryStatus) {
+	if !ok {
+		return nil, EntryAbsent
+	}
 	copy := *entry
-	return &copy, true
+	if entry.expired(time.Now()) {
+		return &copy, EntryExpired
+	}
+	return &copy, EntryPresent
 }
 
 // Delete removes a value from shared memory.
@@ -116,31 +206,34 @@ func (m *SharedMemory) Delete(key string) {
 	delete(m.entries, key)
 }
 
-// Keys returns all keys in shared memory.
+// Keys returns all live keys in shared memory, sorted.
 func (m *SharedMemory) Keys() []string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	now := time.Now()
 	keys := make([]string, 0, len(m.entries))
 	for k, entry := range m.entries {
 		// Skip expired entries
-		if entry.TTL > 0 && time.Since(entry.CreatedAt) > entry.TTL {
+		if entry.expired(now) {
 			continue
 		}
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys
 }
 
-// All returns all entries in shared memory.
+// All returns all entries in shared memory. Use Sorted for a stable order.
 func (m *SharedMemory) All() map[string]any {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	now := time.Now()
 	result := make(map[string]any)
 	for k, entry := range m.entries {
 		// Skip expired entries
-		if entry.TTL > 0 && time.Since(entry.CreatedAt) > entry.TTL {
+		if entry.expired(now) {
 			continue
 		}
 		result[k] = entry.Value
@@ -148,6 +241,29 @@ func (m *SharedMemory) All() map[string]any {
 	return result
 }
 
+// KeyValue is one shared memory entry returned by Sorted.
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// Sorted returns the same entries as All, ordered by key.
+func (m *SharedMemory) Sorted() []KeyValue {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	out := make([]KeyValue, 0, len(m.entries))
+	for k, entry := range m.entries {
+		if entry.expired(now) {
+			continue
+		}
+		out = append(out, KeyValue{Key: k, Value: entry.Value})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
 // Clear removes all entries from shared memory.
 func (m *SharedMemory) Clear() {
 	m.mu.Lock()
@@ -162,7 +278,7 @@ func (m *SharedMemory) CleanupExpired() int {
 
 	count := 0
 	for k, entry := range m.entries {
-		if entry.TTL > 0 && time.Since(entry.CreatedAt) > entry.TTL {
+		if entry.expired(time.Now()) {
 			delete(m.entries, k)
 			count++
 		}
@@ -199,7 +315,7 @@ func (m *SharedMemory) GetByCreator(agentID string) map[string]any {
 	for k, entry := range m.entries {
 		if entry.CreatedBy == agentID {
 			// Skip expired entries
-			if entry.TTL > 0 && time.Since(entry.CreatedAt) > entry.TTL {
+			if entry.expired(time.Now()) {
 				continue
 			}
 			result[k] = entry.Value
@@ -61,15 +61,89 @@ func TestSharedMemory(t *testing.T) {
 		}
 	})
 
+	t.Run("entry status", func(t *testing.T) {
+		if _, status := mem.GetEntryStatus("never_set"); status != EntryAbsent {
+			t.Errorf("expected absent, got %s", status)
+		}
+
+		mem.SetWithTTL("lease", "held", "agent1", 20*time.Millisecond)
+		if _, status := mem.GetEntryStatus("lease"); status != EntryPresent {
+			t.Errorf("expected present, got %s", status)
+		}
+
+		time.Sleep(40 * time.Millisecond)
+		entry, status := mem.GetEntryStatus("lease")
+		if status != EntryExpired || entry == nil || entry.Value != "held" {
+			t.Errorf("expected expired entry with its last value, got %v %+v", status, entry)
+		}
+		if _, found := mem.GetEntry("lease"); found {
+			t.Error("GetEntry should not return expired entries")
+		}
+
+		// Re-creating expired state yields a fresh live entry.
+		mem.Set("lease", "renewed", "agent2")
+		entry, status = mem.GetEntryStatus("lease")
+		if status != EntryPresent || entry.Value != "renewed" || entry.CreatedBy != "agent2" {
+			t.Errorf("expected renewed entry, got %v %+v", status, entry)
+		}
+
+		mem.Delete("lease")
+		if _, status := mem.GetEntryStatus("lease"); status != EntryAbsent {
+			t.Errorf("expected absent after delete, got %s", status)
+		}
+	})
+
+	t.Run("touch and sliding TTL", func(t *testing.T) {
+		mem.SetWithTTL("session", "alive", "agent1", 60*time.Millisecond)
+		for i := 0; i < 3; i++ {
+			time.Sleep(30 * time.Millisecond)
+			if !mem.Touch("session") {
+				t.Fatalf("touch %d: expected live entry", i)
+			}
+		}
+		if _, found := mem.Get("session"); !found {
+			t.Error("expected touched entry to outlive its original TTL")
+		}
+		time.Sleep(90 * time.Millisecond)
+		if mem.Touch("session") {
+			t.Error("touch should not revive an expired entry")
+		}
+
+		mem.SetWithSlidingTTL("keepalive", 1, "agent1", 60*time.Millisecond)
+		for i := 0; i < 3; i++ {
+			time.Sleep(30 * time.Millisecond)
+			if _, found := mem.Get("keepalive"); !found {
+				t.Fatalf("read %d: sliding entry expired while in use", i)
+			}
+		}
+		time.Sleep(90 * time.Millisecond)
+		if _, status := mem.GetEntryStatus("keepalive"); status != EntryExpired {
+			t.Errorf("expected idle sliding entry to expire, got %s", status)
+		}
+		if mem.Touch("missing") {
+			t.Error("touch should report false for absent keys")
+		}
+	})
+
 	t.Run("list keys", func(t *testing.T) {
 		mem.Clear()
+		mem.Set("c", 3, "agent2")
 		mem.Set("a", 1, "agent1")
 		mem.Set("b", 2, "agent1")
-		mem.Set("c", 3, "agent2")
 
 		keys := mem.Keys()
 		if len(keys) != 3 {
-			t.Errorf("expected 3 keys, got %d", len(keys))
+			t.Fatalf("expected 3 keys, got %d", len(keys))
+		}
+		for i, want := range []string{"a", "b", "c"} {
+			if keys[i] != want {
+				t.Errorf("keys[%d] = %s, want %s", i, keys[i], want)
+			}
+		}
+
+		sorted := mem.Sorted()
+		if len(sorted) != 3 || sorted[0].Key != "a" || sorted[0].Value != 1 || sorted[2].Key != "c" {
+			t.Errorf("unexpected sorted entries: %+v", sorted)
 		}
 	})
 
@@ -169,6 +243,41 @@ func TestRegistry(t *testing.T) {
 		}
 	})
 
+	t.Run("find best match", func(t *testing.T) {
+		reg := NewRegistry()
+		reg.Register(AgentInfo{ID: "partial", Capabilities: []string{"go", "review"}})
+		reg.Register(AgentInfo{ID: "full_busy", Capabilities: []string{"go", "review", "deploy"}, Status: "busy"})
+		reg.Register(AgentInfo{ID: "generalist", Capabilities: []string{"go", "python", "docs", "sql"}})
+
+		best, ok := reg.FindBestMatch([]string{"go", "review", "deploy"})
+		if !ok || best.ID != "partial" {
+			t.Errorf("expected available partial match, got %q (ok=%v)", best.ID, ok)
+		}
+
+		reg.UpdateStatus("full_busy", "available")
+		if best, _ = reg.FindBestMatch([]string{"go", "review", "deploy"}); best.ID != "full_busy" {
+			t.Errorf("expected full match once available, got %q", best.ID)
+		}
+		if best, _ = reg.FindBestMatch([]string{"go"}); best.ID != "partial" {
+			t.Errorf("expected most specialised agent on tie, got %q", best.ID)
+		}
+		if _, ok := reg.FindBestMatch([]string{"rust"}); ok {
+			t.Error("expected no match for unknown capability")
+		}
+
+		// Coverage only decides between agents of the same availability.
+		reg.UpdateStatus("partial", "busy")
+		reg.UpdateStatus("full_busy", "busy")
+		reg.UpdateStatus("generalist", "busy")
+		if best, _ = reg.FindBestMatch([]string{"go", "review", "deploy"}); best.ID != "full_busy" {
+			t.Errorf("expected widest coverage among busy agents, got %q", best.ID)
+		}
+		reg.UpdateStatus("generalist", "available")
+		if best, _ = reg.FindBestMatch([]string{"go", "review", "deploy"}); best.ID != "generalist" {
+			t.Errorf("expected the only available agent despite lower coverage, got %q", best.ID)
+		}
+	})
+
 	t.Run("update status", func(t *testing.T) {
 		reg.Register(AgentInfo{ID: "status_test", Status: "available"})
 		reg.UpdateStatus("status_test", "busy")
@@ -1,6 +1,7 @@
 package multiagent
 
 import (
+	"sort"
 	"sync"
 )
 
@@ -92,6 +93,60 @@ func (r *Registry) FindByCapability(capability string) []AgentInfo {
 	return results
 }
 
+// FindBestMatch returns the best agent for the required capabilities.
+// Candidates are ranked first by availability, so an available agent wins
+// over a busy one even if it covers fewer capabilities; then by how many of
+// the required capabilities they cover; then by fewest unrelated
+// capabilities; then by lowest ID. It returns false when no agent has any
+// of the required capabilities.
+func (r *Registry) FindBestMatch(required []string) (AgentInfo, bool) {
+	want := make(map[string]struct{}, len(required))
+	for _, c := range required {
+		want[c] = struct{}{}
+	}
+	if len(want) == 0 {
+		return AgentInfo{}, false
+	}
+
+	type scored struct {
+		info      AgentInfo
+		matched   int
+		available bool
+	}
+	r.mu.RLock()
+	candidates := make([]scored, 0, len(r.agents))
+	for _, info := range r.agents {
+		seen := make(map[string]struct{}, len(info.Capabilities))
+		for _, c := range info.Capabilities {
+			if _, ok := want[c]; ok {
+				seen[c] = struct{}{}
+			}
+		}
+		if len(seen) > 0 {
+			candidates = append(candidates, scored{info: info, matched: len(seen), available: info.Status == "available"})
+		}
+	}
+	r.mu.RUnlock()
+	if len(candidates) == 0 {
+		return AgentInfo{}, false
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.available != b.available {
+			return a.available
+		}
+		if a.matched != b.matched {
+			return a.matched > b.matched
+		}
+		if len(a.info.Capabilities) != len(b.info.Capabilities) {
+			return len(a.info.Capabilities) < len(b.info.Capabilities)
+		}
+		return a.info.ID < b.info.ID
+	})
+	return candidates[0].info, true
+}
+
 // UpdateStatus updates an agent's status.
 func (r *Registry) UpdateStatus(id, status string) {
 	r.mu.Lock()
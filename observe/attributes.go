@@ -0,0 +1,56 @@
+package observe
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SetAttr sets a structured attribute on the event and returns the event
+// for chaining. Values should be JSON-serializable; errors are stored as
+// their message and durations as milliseconds so sinks can encode them.
+func (e *Event) SetAttr(key string, value any) *Event {
+	if e == nil {
+		return nil
+	}
+	if e.Attributes == nil {
+		e.Attributes = map[string]any{}
+	}
+	switch v := value.(type) {
+	case error:
+		e.Attributes[key] = v.Error()
+	case time.Duration:
+		e.Attributes[key] = v.Milliseconds()
+	default:
+		e.Attributes[key] = value
+	}
+	return e
+}
+
+// Attr returns the raw attribute value for key.
+func (e Event) Attr(key string) (any, bool) {
+	v, ok := e.Attributes[key]
+	return v, ok
+}
+
+// AttrAs returns attribute key decoded as T. It works both for values set
+// in-process and for values that round-tripped through a JSON store, where
+// structs become maps and numbers become float64.
+func AttrAs[T any](e Event, key string) (T, bool) {
+	var zero T
+	raw, ok := e.Attributes[key]
+	if !ok || raw == nil {
+		return zero, false
+	}
+	if v, ok := raw.(T); ok {
+		return v, true
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return zero, false
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return zero, false
+	}
+	return out, true
+}
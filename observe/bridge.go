@@ -26,6 +26,14 @@ func FromRuntimeEvent(in types.Event) Event {
 	if in.ToolCallID != "" {
 		e.Attributes["toolCallId"] = in.ToolCallID
 	}
+	if in.Model != "" {
+		e.Attributes["model"] = in.Model
+	}
+	if in.Retries.Any() {
+		e.Attributes["retries"] = in.Retries.Retries
+		e.Attributes["rateLimitRetries"] = in.Retries.RateLimitRetries
+		e.Attributes["backoffMs"] = in.Retries.Backoff.Milliseconds()
+	}
 
 	eventType := string(in.Type)
 	switch {
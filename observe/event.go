@@ -12,6 +12,7 @@ const (
 	KindTool       Kind = "tool"
 	KindGraph      Kind = "graph"
 	KindCheckpoint Kind = "checkpoint"
+	KindArtifact   Kind = "artifact"
 	KindCustom     Kind = "custom"
 )
 
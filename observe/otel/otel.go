@@ -7,6 +7,7 @@ package otel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -82,7 +83,7 @@ func (s *Sink) Emit(_ context.Context, event observe.Event) error {
 
 	// Custom attributes from event
 	for k, v := range event.Attributes {
-		attrs = append(attrs, attribute.String("agent.attr."+k, fmt.Sprintf("%v", v)))
+		attrs = append(attrs, toAttribute("agent.attr."+k, v))
 	}
 
 	span.SetAttributes(attrs...)
@@ -106,6 +107,31 @@ func (s *Sink) Emit(_ context.Context, event observe.Event) error {
 	return nil
 }
 
+// toAttribute maps scalar values to typed span attributes and encodes
+// structured values as JSON.
+func toAttribute(key string, v any) attribute.KeyValue {
+	switch tv := v.(type) {
+	case string:
+		return attribute.String(key, tv)
+	case bool:
+		return attribute.Bool(key, tv)
+	case int:
+		return attribute.Int(key, tv)
+	case int64:
+		return attribute.Int64(key, tv)
+	case float64:
+		return attribute.Float64(key, tv)
+	case []string:
+		return attribute.StringSlice(key, tv)
+	case fmt.Stringer:
+		return attribute.String(key, tv.String())
+	}
+	if data, err := json.Marshal(v); err == nil {
+		return attribute.String(key, string(data))
+	}
+	return attribute.String(key, fmt.Sprintf("%v", v))
+}
+
 func spanNameFor(event observe.Event) string {
 	switch event.Kind {
 	case observe.KindRun:
@@ -123,3 +123,29 @@ func attrToMap(attrs []attribute.KeyValue) map[string]string {
 	}
 	return m
 }
+
+func TestSinkTypedAttributes(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	event := observe.Event{Kind: observe.KindTool, ToolName: "scan", Timestamp: time.Now()}
+	event.SetAttr("findings", 3).SetAttr("ok", true).SetAttr("detail", map[string]any{"cve": "CVE-1"})
+	if err := NewSink(tp).Emit(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := map[string]attribute.Value{}
+	for _, kv := range exporter.GetSpans()[0].Attributes {
+		attrs[string(kv.Key)] = kv.Value
+	}
+	if v := attrs["agent.attr.findings"]; v.Type() != attribute.INT64 || v.AsInt64() != 3 {
+		t.Errorf("findings = %v (%v)", v.Emit(), v.Type())
+	}
+	if v := attrs["agent.attr.ok"]; v.Type() != attribute.BOOL || !v.AsBool() {
+		t.Errorf("ok = %v (%v)", v.Emit(), v.Type())
+	}
+	if v := attrs["agent.attr.detail"]; v.AsString() != `{"cve":"CVE-1"}` {
+		t.Errorf("detail = %q, want JSON", v.Emit())
+	}
+}
@@ -0,0 +1,77 @@
+package observe
+
+import "github.com/PipeOpsHQ/agent-sdk-go/redact"
+
+// RedactPolicy decides which parts of an event reach persistent storage, so
+// traces can keep structural metadata (run IDs, tool names, latencies)
+// without raw prompts or outputs.
+type RedactPolicy struct {
+	// AllowAttributes, when non-empty, keeps only these attribute keys.
+	AllowAttributes []string
+	// DenyAttributes drops these attribute keys. It applies after
+	// AllowAttributes.
+	DenyAttributes []string
+	// DropMessage clears Message, which often carries model or tool text.
+	DropMessage bool
+	// DropError clears Error.
+	DropError bool
+	// Secrets scrubs credentials matched by the redact package from Message,
+	// Error, and string attributes that are kept.
+	Secrets bool
+	// Hook runs after the rules above and may modify the event further.
+	Hook func(*Event)
+}
+
+// IsZero reports whether the policy leaves events untouched.
+func (p RedactPolicy) IsZero() bool {
+	return len(p.AllowAttributes) == 0 && len(p.DenyAttributes) == 0 &&
+		!p.DropMessage && !p.DropError && !p.Secrets && p.Hook == nil
+}
+
+// Apply redacts e in place. Attributes are copied first, since the map may
+// be shared with other sinks.
+func (p RedactPolicy) Apply(e *Event) {
+	if e == nil || p.IsZero() {
+		return
+	}
+	allow := toSet(p.AllowAttributes)
+	deny := toSet(p.DenyAttributes)
+	attrs := make(map[string]any, len(e.Attributes))
+	for k, v := range e.Attributes {
+		if allow != nil && !allow[k] {
+			continue
+		}
+		if deny[k] {
+			continue
+		}
+		if p.Secrets {
+			v = redact.Value(v, redact.Replacement)
+		}
+		attrs[k] = v
+	}
+	e.Attributes = attrs
+	if p.DropMessage {
+		e.Message = ""
+	}
+	if p.DropError {
+		e.Error = ""
+	}
+	if p.Secrets {
+		e.Message = redact.Redact(e.Message)
+		e.Error = redact.Redact(e.Error)
+	}
+	if p.Hook != nil {
+		p.Hook(e)
+	}
+}
+
+func toSet(keys []string) map[string]bool {
+	if len(keys) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
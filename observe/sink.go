@@ -2,6 +2,7 @@ package observe
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 )
@@ -27,11 +28,38 @@ func (NoopSink) Emit(ctx context.Context, event Event) error {
 	return nil
 }
 
+type multiMode int
+
+const (
+	multiFailFast multiMode = iota
+	multiBestEffort
+	multiConcurrent
+)
+
 type MultiSink struct {
 	sinks []Sink
+	mode  multiMode
 }
 
+// NewMultiSink emits to each sink in order and stops at the first error.
 func NewMultiSink(sinks ...Sink) Sink {
+	return newMultiSink(multiFailFast, sinks)
+}
+
+// NewMultiSinkBestEffort emits to each sink in order, continuing past
+// failures, and returns errors.Join of everything that failed.
+func NewMultiSinkBestEffort(sinks ...Sink) Sink {
+	return newMultiSink(multiBestEffort, sinks)
+}
+
+// NewMultiSinkConcurrent emits to all sinks at once, so a slow sink does not
+// delay the others. Every sink receives the event; failures are combined
+// with errors.Join.
+func NewMultiSinkConcurrent(sinks ...Sink) Sink {
+	return newMultiSink(multiConcurrent, sinks)
+}
+
+func newMultiSink(mode multiMode, sinks []Sink) Sink {
 	filtered := make([]Sink, 0, len(sinks))
 	for _, s := range sinks {
 		if s == nil {
@@ -45,13 +73,25 @@ func NewMultiSink(sinks ...Sink) Sink {
 	if len(filtered) == 1 {
 		return filtered[0]
 	}
-	return &MultiSink{sinks: filtered}
+	return &MultiSink{sinks: filtered, mode: mode}
 }
 
 func (m *MultiSink) Emit(ctx context.Context, event Event) error {
 	if m == nil {
 		return nil
 	}
+	switch m.mode {
+	case multiConcurrent:
+		return m.emitConcurrent(ctx, event)
+	case multiBestEffort:
+		var errs []error
+		for _, sink := range m.sinks {
+			if err := sink.Emit(ctx, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
 	for _, sink := range m.sinks {
 		if err := sink.Emit(ctx, event); err != nil {
 			return err
@@ -60,6 +100,20 @@ func (m *MultiSink) Emit(ctx context.Context, event Event) error {
 	return nil
 }
 
+func (m *MultiSink) emitConcurrent(ctx context.Context, event Event) error {
+	errs := make([]error, len(m.sinks))
+	var wg sync.WaitGroup
+	for i, sink := range m.sinks {
+		wg.Add(1)
+		go func(i int, sink Sink) {
+			defer wg.Done()
+			errs[i] = sink.Emit(ctx, event)
+		}(i, sink)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
 type AsyncSink struct {
 	downstream Sink
 	queue      chan Event
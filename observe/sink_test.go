@@ -0,0 +1,81 @@
+package observe
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMultiSinkConcurrent(t *testing.T) {
+	errA := errors.New("sink a failed")
+	errC := errors.New("sink c failed")
+
+	// Each sink waits until all of them have started, so Emit only returns
+	// if the sinks run at the same time.
+	var started sync.WaitGroup
+	started.Add(3)
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	var mu sync.Mutex
+	got := map[string]string{}
+	sink := func(name string, err error) Sink {
+		return SinkFunc(func(ctx context.Context, event Event) error {
+			started.Done()
+			select {
+			case <-allStarted:
+			case <-time.After(2 * time.Second):
+				return errors.New(name + " ran alone")
+			}
+			mu.Lock()
+			got[name] = event.RunID
+			mu.Unlock()
+			return err
+		})
+	}
+
+	multi := NewMultiSinkConcurrent(sink("a", errA), nil, sink("b", nil), sink("c", errC))
+	err := multi.Emit(context.Background(), Event{RunID: "run-1"})
+	if !errors.Is(err, errA) || !errors.Is(err, errC) {
+		t.Fatalf("Emit error = %v, want both sink errors joined", err)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if got[name] != "run-1" {
+			t.Errorf("sink %s received run %q, want run-1", name, got[name])
+		}
+	}
+}
+
+func TestMultiSinkBestEffort(t *testing.T) {
+	errFirst := errors.New("first failed")
+	var calls []string
+	record := func(name string, err error) Sink {
+		return SinkFunc(func(ctx context.Context, event Event) error {
+			calls = append(calls, name)
+			return err
+		})
+	}
+
+	multi := NewMultiSinkBestEffort(record("first", errFirst), record("second", nil), record("third", nil))
+	err := multi.Emit(context.Background(), Event{RunID: "run-1"})
+	if !errors.Is(err, errFirst) {
+		t.Fatalf("Emit error = %v, want %v", err, errFirst)
+	}
+	if len(calls) != 3 || calls[1] != "second" || calls[2] != "third" {
+		t.Fatalf("calls = %v, want every sink called in order", calls)
+	}
+
+	// The fail-fast variant stops at the first error.
+	calls = nil
+	if err := NewMultiSink(record("first", errFirst), record("second", nil)).Emit(context.Background(), Event{}); !errors.Is(err, errFirst) {
+		t.Fatalf("fail-fast Emit error = %v, want %v", err, errFirst)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("fail-fast calls = %v, want only the first sink", calls)
+	}
+}
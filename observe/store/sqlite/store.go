@@ -23,10 +23,21 @@ var schemaSQL string
 const defaultLimit = 200
 
 type Store struct {
-	db *sql.DB
+	db     *sql.DB
+	redact observe.RedactPolicy
 }
 
-func New(path string) (*Store, error) {
+type Option func(*Store)
+
+// WithRedaction applies policy to every event before it is written, e.g. to
+// keep raw prompts and outputs out of the trace database.
+func WithRedaction(policy observe.RedactPolicy) Option {
+	return func(s *Store) {
+		s.redact = policy
+	}
+}
+
+func New(path string, opts ...Option) (*Store, error) {
 	if strings.TrimSpace(path) == "" {
 		return nil, fmt.Errorf("sqlite trace path is required")
 	}
@@ -47,7 +58,11 @@ func New(path string) (*Store, error) {
 		_ = db.Close()
 		return nil, fmt.Errorf("failed to initialize trace schema: %w", err)
 	}
-	return &Store{db: db}, nil
+	s := &Store{db: db}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 func (s *Store) SaveEvent(ctx context.Context, event observe.Event) error {
@@ -55,6 +70,7 @@ func (s *Store) SaveEvent(ctx context.Context, event observe.Event) error {
 		return nil
 	}
 	event.Normalize()
+	s.redact.Apply(&event)
 	if event.ID == "" {
 		event.ID = uuid.NewString()
 	}
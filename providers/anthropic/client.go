@@ -164,9 +164,14 @@ func (c *Client) Generate(ctx context.Context, req types.Request) (types.Respons
 		}
 	}
 
+	if apiResp.Model != "" {
+		model = apiResp.Model
+	}
 	return types.Response{
-		Message: out,
-		Usage:   usage,
+		Message:  out,
+		Usage:    usage,
+		Provider: c.Name(),
+		Model:    model,
 	}, nil
 }
 
@@ -322,6 +327,7 @@ type anthropicTool struct {
 }
 
 type anthropicResponse struct {
+	Model   string                  `json:"model"`
 	Content []anthropicContentBlock `json:"content"`
 	Usage   struct {
 		InputTokens  int `json:"input_tokens"`
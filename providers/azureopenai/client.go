@@ -169,7 +169,10 @@ func (c *Client) Generate(ctx context.Context, req types.Request) (types.Respons
 		}
 	}
 
-	return types.Response{Message: out, Usage: usage}, nil
+	if apiResp.Model != "" {
+		model = apiResp.Model
+	}
+	return types.Response{Message: out, Usage: usage, Provider: c.Name(), Model: model}, nil
 }
 
 func (c *Client) endpointForDeployment() string {
@@ -301,6 +304,7 @@ type azureFunctionCall struct {
 }
 
 type azureChatResponse struct {
+	Model   string `json:"model"`
 	Choices []struct {
 		Message azureChatMessage `json:"message"`
 	} `json:"choices"`
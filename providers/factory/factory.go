@@ -23,7 +23,7 @@ func FromEnv(ctx context.Context) (llm.Provider, error) {
 			return nil, fmt.Errorf("OPENAI_API_KEY is required when AGENT_PROVIDER=openai")
 		}
 		model := getenv("OPENAI_MODEL", "gpt-4o-mini")
-		baseURL := strings.TrimSpace(os.Getenv("OPENAI_BASE_URL"))
+		baseURL := OpenAIBaseURL()
 
 		opts := []openaiprov.Option{openaiprov.WithModel(model)}
 		if baseURL != "" {
@@ -91,6 +91,19 @@ func FromEnv(ctx context.Context) (llm.Provider, error) {
 	return nil, fmt.Errorf("unsupported AGENT_PROVIDER %q (use gemini, openai, anthropic, ollama, or azureopenai)", provider)
 }
 
+// OpenAIBaseURL returns the OpenAI-compatible endpoint override from
+// AGENT_OPENAI_BASE_URL (or the older OPENAI_BASE_URL), or "" for the
+// default. A trailing "/v1", as vendors usually document their URLs, is
+// dropped because the client appends it.
+func OpenAIBaseURL() string {
+	base := strings.TrimSpace(os.Getenv("AGENT_OPENAI_BASE_URL"))
+	if base == "" {
+		base = strings.TrimSpace(os.Getenv("OPENAI_BASE_URL"))
+	}
+	base = strings.TrimRight(base, "/")
+	return strings.TrimSuffix(base, "/v1")
+}
+
 func getenv(key, fallback string) string {
 	val := strings.TrimSpace(os.Getenv(key))
 	if val == "" {
@@ -2,7 +2,11 @@ package factory
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
 )
 
 func TestFromEnv_OpenAI(t *testing.T) {
@@ -70,3 +74,33 @@ func TestFromEnv_AzureOpenAI(t *testing.T) {
 		t.Fatalf("expected azureopenai provider, got %q", p.Name())
 	}
 }
+
+func TestFromEnv_OpenAICompatibleBaseURL(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi"}}]}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("AGENT_PROVIDER", "openai")
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	t.Setenv("OPENAI_BASE_URL", "http://ignored.invalid")
+	t.Setenv("AGENT_OPENAI_BASE_URL", srv.URL+"/v1/")
+
+	if got := OpenAIBaseURL(); got != srv.URL {
+		t.Fatalf("OpenAIBaseURL() = %q, want %q", got, srv.URL)
+	}
+	p, err := FromEnv(context.Background())
+	if err != nil {
+		t.Fatalf("FromEnv returned error: %v", err)
+	}
+	resp, err := p.Generate(context.Background(), types.Request{Messages: []types.Message{{Role: types.RoleUser, Content: "hello"}}})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if resp.Message.Content != "hi" || gotPath != "/v1/chat/completions" {
+		t.Fatalf("content=%q path=%q", resp.Message.Content, gotPath)
+	}
+}
@@ -84,7 +84,10 @@ func (c *Client) Generate(ctx context.Context, req types.Request) (types.Respons
 	if err != nil {
 		return types.Response{}, fmt.Errorf("gemini generation failed: %w", err)
 	}
-	return parseGeminiResponse(resp), nil
+	out := parseGeminiResponse(resp)
+	out.Provider = c.Name()
+	out.Model = model
+	return out, nil
 }
 
 func (c *Client) GenerateStream(ctx context.Context, req types.Request, onChunk func(types.StreamChunk) error) (types.Response, error) {
@@ -143,6 +146,8 @@ func (c *Client) GenerateStream(ctx context.Context, req types.Request, onChunk
 		return types.Response{}, fmt.Errorf("gemini generation failed: empty stream")
 	}
 	resp := parseGeminiResponse(last)
+	resp.Provider = c.Name()
+	resp.Model = model
 	if err := onChunk(types.StreamChunk{Done: true}); err != nil {
 		return types.Response{}, err
 	}
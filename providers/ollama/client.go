@@ -154,7 +154,10 @@ func (c *Client) Generate(ctx context.Context, req types.Request) (types.Respons
 		}
 	}
 
-	return types.Response{Message: out, Usage: usage}, nil
+	if apiResp.Model != "" {
+		model = apiResp.Model
+	}
+	return types.Response{Message: out, Usage: usage, Provider: c.Name(), Model: model}, nil
 }
 
 func toChatMessages(in []types.Message) []chatMessage {
@@ -280,6 +283,7 @@ type chatFunctionCall struct {
 }
 
 type chatResponse struct {
+	Model   string `json:"model"`
 	Choices []struct {
 		Message chatMessage `json:"message"`
 	} `json:"choices"`
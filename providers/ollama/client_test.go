@@ -75,6 +75,9 @@ func TestClientGenerate_OpenAICompatibleRoundTrip(t *testing.T) {
 	if resp.Usage == nil || resp.Usage.TotalTokens != 10 {
 		t.Fatalf("unexpected usage: %#v", resp.Usage)
 	}
+	if resp.Provider != "ollama" || resp.Model != "llama3.2" {
+		t.Fatalf("unexpected provider/model: %q/%q", resp.Provider, resp.Model)
+	}
 }
 
 func TestClientGenerate_ErrorNormalization(t *testing.T) {
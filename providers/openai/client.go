@@ -163,9 +163,14 @@ func (c *Client) Generate(ctx context.Context, req types.Request) (types.Respons
 		}
 	}
 
+	if apiResp.Model != "" {
+		model = apiResp.Model
+	}
 	return types.Response{
-		Message: out,
-		Usage:   usage,
+		Message:  out,
+		Usage:    usage,
+		Provider: c.Name(),
+		Model:    model,
 	}, nil
 }
 
@@ -307,6 +312,7 @@ type openAIFunctionCall struct {
 }
 
 type openAIResponse struct {
+	Model   string `json:"model"`
 	Choices []struct {
 		Message openAIMessage `json:"message"`
 	} `json:"choices"`
@@ -0,0 +1,157 @@
+package rag
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Metadata keys set on chunks. Source-level keys (source, title, and any
+// other parent metadata) are copied from the parent document; the chunk
+// keys locate the chunk inside the parent's Content.
+const (
+	MetaSource     = "source"
+	MetaTitle      = "title"
+	MetaParentID   = "parent_id"
+	MetaChunkIndex = "chunk_index"
+	MetaChunkCount = "chunk_count"
+	// MetaStartOffset and MetaEndOffset are byte offsets into the parent
+	// Content, so parent.Content[start:end] is the chunk text.
+	MetaStartOffset = "start_offset"
+	MetaEndOffset   = "end_offset"
+)
+
+// DefaultChunkSize is the chunk length in bytes used when ChunkOptions.Size
+// is unset.
+const DefaultChunkSize = 1000
+
+// ChunkOptions controls ChunkToDocuments.
+type ChunkOptions struct {
+	// Size is the maximum chunk length in bytes.
+	Size int
+	// Overlap is how many bytes consecutive chunks share.
+	Overlap int
+}
+
+// ChunkInfo is the position of a chunk within its parent document.
+type ChunkInfo struct {
+	ParentID    string
+	Source      string
+	Title       string
+	Index       int
+	Count       int
+	StartOffset int
+	EndOffset   int
+}
+
+// ChunkToDocuments splits doc into chunks of at most opts.Size bytes,
+// breaking at whitespace where possible. Each chunk gets ID "<parent>#<n>"
+// and metadata from ChunkMetadata. Empty content yields no chunks.
+func ChunkToDocuments(doc Document, opts ChunkOptions) []Document {
+	size := opts.Size
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	overlap := opts.Overlap
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	content := doc.Content
+	for start := 0; start < len(content); {
+		end := min(start+size, len(content))
+		if end < len(content) {
+			end = breakPoint(content, start, end)
+		}
+		if strings.TrimSpace(content[start:end]) != "" {
+			spans = append(spans, span{start, end})
+		}
+		if end >= len(content) {
+			break
+		}
+		next := end - overlap
+		if next <= start {
+			next = end
+		}
+		for next < len(content) && !utf8.RuneStart(content[next]) {
+			next++
+		}
+		start = next
+	}
+
+	out := make([]Document, 0, len(spans))
+	for i, sp := range spans {
+		out = append(out, Document{
+			ID:       fmt.Sprintf("%s#%d", doc.ID, i),
+			Content:  content[sp.start:sp.end],
+			Metadata: ChunkMetadata(doc, i, len(spans), sp.start, sp.end),
+		})
+	}
+	return out
+}
+
+// ChunkMetadata builds chunk metadata for manual chunking: a copy of
+// parent's metadata plus the parent ID and the chunk's index, count, and
+// byte offsets.
+func ChunkMetadata(parent Document, index, count, start, end int) map[string]any {
+	meta := make(map[string]any, len(parent.Metadata)+6)
+	for k, v := range parent.Metadata {
+		meta[k] = v
+	}
+	if parent.ID != "" {
+		meta[MetaParentID] = parent.ID
+	}
+	meta[MetaChunkIndex] = index
+	meta[MetaChunkCount] = count
+	meta[MetaStartOffset] = start
+	meta[MetaEndOffset] = end
+	return meta
+}
+
+// ChunkInfoOf reads chunk position metadata from doc. It reports false when
+// doc has no chunk index. Numbers decoded from JSON are accepted.
+func ChunkInfoOf(doc Document) (ChunkInfo, bool) {
+	index, ok := metaInt(doc.Metadata, MetaChunkIndex)
+	if !ok {
+		return ChunkInfo{}, false
+	}
+	info := ChunkInfo{Index: index}
+	info.ParentID, _ = doc.Metadata[MetaParentID].(string)
+	info.Source, _ = doc.Metadata[MetaSource].(string)
+	info.Title, _ = doc.Metadata[MetaTitle].(string)
+	info.Count, _ = metaInt(doc.Metadata, MetaChunkCount)
+	info.StartOffset, _ = metaInt(doc.Metadata, MetaStartOffset)
+	info.EndOffset, _ = metaInt(doc.Metadata, MetaEndOffset)
+	return info, true
+}
+
+func metaInt(meta map[string]any, key string) (int, bool) {
+	switch v := meta[key].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// breakPoint moves end back to just after the last whitespace in
+// content[start:end], keeping at least half the chunk. Without suitable
+// whitespace it only backs off to a rune boundary.
+func breakPoint(content string, start, end int) int {
+	for i := end; i > start+(end-start)/2; i-- {
+		r, _ := utf8.DecodeLastRuneInString(content[:i])
+		if unicode.IsSpace(r) {
+			return i
+		}
+	}
+	for end > start+1 && !utf8.RuneStart(content[end]) {
+		end--
+	}
+	return end
+}
@@ -0,0 +1,64 @@
+package rag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Citation is a compact reference to a retrieved chunk. Number matches the
+// [n] marker the chunk was shown with in the injected context.
+type Citation struct {
+	Number      int     `json:"number"`
+	DocumentID  string  `json:"documentId,omitempty"`
+	Source      string  `json:"source,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	StartOffset int     `json:"startOffset,omitempty"`
+	EndOffset   int     `json:"endOffset,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// Citations extracts source, title, and offset metadata (see ChunkMetadata)
+// from each result, in result order.
+func Citations(results []SearchResult) []Citation {
+	out := make([]Citation, 0, len(results))
+	for i, r := range results {
+		c := Citation{Number: i + 1, DocumentID: r.Document.ID, Score: r.Score}
+		c.Source, _ = r.Document.Metadata[MetaSource].(string)
+		c.Title, _ = r.Document.Metadata[MetaTitle].(string)
+		if info, ok := ChunkInfoOf(r.Document); ok {
+			c.StartOffset, c.EndOffset = info.StartOffset, info.EndOffset
+			if c.DocumentID == "" {
+				c.DocumentID = info.ParentID
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// FormatSources renders citations as a markdown "Sources" footer, one line
+// per citation. It returns "" for no citations.
+func FormatSources(citations []Citation) string {
+	if len(citations) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("Sources:")
+	for _, c := range citations {
+		label := c.Title
+		if label == "" {
+			label = c.Source
+		}
+		if label == "" {
+			label = c.DocumentID
+		}
+		sb.WriteString(fmt.Sprintf("\n[%d] %s", c.Number, label))
+		if c.Title != "" && c.Source != "" {
+			sb.WriteString(" (" + c.Source + ")")
+		}
+		if c.EndOffset > c.StartOffset {
+			sb.WriteString(fmt.Sprintf(", chars %d-%d", c.StartOffset, c.EndOffset))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,73 @@
+package rag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FallbackEmbedder tries each embedder in order until one succeeds, so a
+// secondary keeps retrieval working while the primary is down.
+//
+// Vectors from different models are not comparable. Every embedder should
+// produce vectors in the same space as the indexed documents (for example
+// the same model served from two endpoints); otherwise searches made during
+// a fallback return poor matches.
+type FallbackEmbedder struct {
+	embedders []Embedder
+}
+
+// NewFallbackEmbedder returns an embedder over embedders, in priority order.
+// Nil entries are skipped.
+func NewFallbackEmbedder(embedders ...Embedder) *FallbackEmbedder {
+	f := &FallbackEmbedder{}
+	for _, e := range embedders {
+		if e != nil {
+			f.embedders = append(f.embedders, e)
+		}
+	}
+	return f
+}
+
+func (f *FallbackEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	var errs []error
+	for i, e := range f.embedders {
+		vec, err := e.Embed(ctx, text)
+		if err == nil {
+			return vec, nil
+		}
+		errs = append(errs, fmt.Errorf("embedder %d: %w", i, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, f.failed(errs)
+}
+
+// EmbedBatch embeds the whole batch with one embedder, moving to the next
+// on error or a short result, so vectors within a batch always come from
+// the same model.
+func (f *FallbackEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	var errs []error
+	for i, e := range f.embedders {
+		vecs, err := e.EmbedBatch(ctx, texts)
+		if err == nil && len(vecs) != len(texts) {
+			err = fmt.Errorf("returned %d embeddings for %d texts", len(vecs), len(texts))
+		}
+		if err == nil {
+			return vecs, nil
+		}
+		errs = append(errs, fmt.Errorf("embedder %d: %w", i, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, f.failed(errs)
+}
+
+func (f *FallbackEmbedder) failed(errs []error) error {
+	if len(f.embedders) == 0 {
+		return errors.New("rag: no embedders configured")
+	}
+	return fmt.Errorf("rag: all embedders failed: %w", errors.Join(errs...))
+}
@@ -0,0 +1,159 @@
+package rag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultIndexBatchSize is how many documents BuildIndex embeds and stores
+// per EmbedBatch/Add call.
+const DefaultIndexBatchSize = 32
+
+// ErrPartialIndex is returned by BuildIndex when some documents could not be
+// embedded or stored. The report lists which ones.
+var ErrPartialIndex = errors.New("rag: some documents failed to index")
+
+// IndexFailure records a document that BuildIndex could not index.
+type IndexFailure struct {
+	Index int    `json:"index"`
+	DocID string `json:"docId"`
+	Err   error  `json:"-"`
+}
+
+// IndexReport summarizes a BuildIndex call.
+type IndexReport struct {
+	Indexed int            `json:"indexed"`
+	Failed  []IndexFailure `json:"failed,omitempty"`
+}
+
+// BuildIndex embeds docs with up to concurrency batches in flight and adds
+// them to store batch by batch. Documents that already carry an embedding
+// are stored as-is. When a batch embedding fails, its documents are retried
+// one at a time so a single bad document does not fail its neighbours.
+//
+// The returned error wraps ErrPartialIndex when any document failed, or is
+// the context error if ctx was cancelled; the report is valid in both cases.
+func BuildIndex(ctx context.Context, store VectorStore, embedder Embedder, docs []Document, concurrency int) (IndexReport, error) {
+	if store == nil {
+		return IndexReport{}, errors.New("rag: store is required")
+	}
+	if embedder == nil {
+		return IndexReport{}, errors.New("rag: embedder is required")
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		mu     sync.Mutex
+		report IndexReport
+		wg     sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for start := 0; start < len(docs); start += DefaultIndexBatchSize {
+		end := min(start+DefaultIndexBatchSize, len(docs))
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			mu.Lock()
+			report.Failed = append(report.Failed, failRange(docs, start, len(docs), ctx.Err())...)
+			mu.Unlock()
+			break
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			indexed, failed := indexBatch(ctx, store, embedder, docs, start, end)
+			mu.Lock()
+			report.Indexed += indexed
+			report.Failed = append(report.Failed, failed...)
+			mu.Unlock()
+		}(start, end)
+	}
+	wg.Wait()
+
+	sort.Slice(report.Failed, func(i, j int) bool { return report.Failed[i].Index < report.Failed[j].Index })
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	if len(report.Failed) > 0 {
+		return report, fmt.Errorf("%w: %d of %d", ErrPartialIndex, len(report.Failed), len(docs))
+	}
+	return report, nil
+}
+
+func indexBatch(ctx context.Context, store VectorStore, embedder Embedder, docs []Document, start, end int) (int, []IndexFailure) {
+	batch := make([]Document, 0, end-start)
+	positions := make([]int, 0, end-start)
+	var pending []int // offsets within batch that still need an embedding
+	for i := start; i < end; i++ {
+		if len(docs[i].Embedding) == 0 {
+			pending = append(pending, len(batch))
+		}
+		batch = append(batch, docs[i])
+		positions = append(positions, i)
+	}
+
+	failedAt := make(map[int]error)
+	if len(pending) > 0 {
+		texts := make([]string, len(pending))
+		for i, off := range pending {
+			texts[i] = batch[off].Content
+		}
+		vecs, err := embedder.EmbedBatch(ctx, texts)
+		if err == nil && len(vecs) != len(texts) {
+			err = fmt.Errorf("rag: embedder returned %d vectors for %d texts", len(vecs), len(texts))
+		}
+		if err == nil {
+			for i, off := range pending {
+				batch[off].Embedding = vecs[i]
+			}
+		} else {
+			for _, off := range pending {
+				vec, embedErr := embedder.Embed(ctx, batch[off].Content)
+				if embedErr != nil {
+					failedAt[off] = embedErr
+					continue
+				}
+				batch[off].Embedding = vec
+			}
+		}
+	}
+
+	var failed []IndexFailure
+	ready := make([]Document, 0, len(batch))
+	readyPositions := make([]int, 0, len(batch))
+	for off, doc := range batch {
+		if err, ok := failedAt[off]; ok {
+			failed = append(failed, IndexFailure{Index: positions[off], DocID: doc.ID, Err: err})
+			continue
+		}
+		ready = append(ready, doc)
+		readyPositions = append(readyPositions, positions[off])
+	}
+	if len(ready) == 0 {
+		return 0, failed
+	}
+	if err := store.Add(ctx, ready); err != nil {
+		for i, doc := range ready {
+			failed = append(failed, IndexFailure{Index: readyPositions[i], DocID: doc.ID, Err: err})
+		}
+		return 0, failed
+	}
+	return len(ready), failed
+}
+
+func failRange(docs []Document, start, end int, err error) []IndexFailure {
+	out := make([]IndexFailure, 0, end-start)
+	for i := start; i < end; i++ {
+		out = append(out, IndexFailure{Index: i, DocID: docs[i].ID, Err: err})
+	}
+	return out
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	agentfw "github.com/PipeOpsHQ/agent-sdk-go/agent"
 	"github.com/PipeOpsHQ/agent-sdk-go/types"
@@ -15,8 +16,26 @@ type AgentMiddleware struct {
 	retriever Retriever
 	topK      int
 	prefix    string // prefix for injected context; defaults to "Relevant context:"
+	placement Placement
+	sources   bool
+
+	mu        sync.Mutex
+	citations map[string][]Citation // by run ID, the chunks last injected
 }
 
+// Placement controls where retrieved context is injected into the request.
+type Placement string
+
+const (
+	// PlacementSystem prepends context to the system prompt (the default).
+	PlacementSystem Placement = "system"
+	// PlacementMessage inserts context as its own user message just before
+	// the user turn it was retrieved for.
+	PlacementMessage Placement = "message"
+	// PlacementUserTurn appends context to the user turn itself.
+	PlacementUserTurn Placement = "user_turn"
+)
+
 // MiddlewareOption configures the RAG middleware.
 type MiddlewareOption func(*AgentMiddleware)
 
@@ -29,18 +48,37 @@ func WithTopK(k int) MiddlewareOption {
 	}
 }
 
-// WithPrefix sets the label for injected context in the system prompt.
+// WithPrefix sets the label for the injected context block.
 func WithPrefix(prefix string) MiddlewareOption {
 	return func(m *AgentMiddleware) { m.prefix = prefix }
 }
 
+// WithPlacement sets where retrieved context is injected. Models weight the
+// system prompt and conversation turns differently, so placement can affect
+// how well answers stay grounded.
+func WithPlacement(p Placement) MiddlewareOption {
+	return func(m *AgentMiddleware) {
+		if p != "" {
+			m.placement = p
+		}
+	}
+}
+
+// WithSourcesFooter appends a "Sources" footer (see FormatSources) to the
+// final answer, listing the chunks that were injected for it.
+func WithSourcesFooter() MiddlewareOption {
+	return func(m *AgentMiddleware) { m.sources = true }
+}
+
 // NewAgentMiddleware creates a middleware that retrieves relevant documents
-// and injects them into the system prompt before each LLM call.
+// and injects them into each LLM request, by default into the system prompt.
 func NewAgentMiddleware(retriever Retriever, opts ...MiddlewareOption) *AgentMiddleware {
 	m := &AgentMiddleware{
 		retriever: retriever,
 		topK:      3,
 		prefix:    "Relevant context:",
+		placement: PlacementSystem,
+		citations: map[string][]Citation{},
 	}
 	for _, opt := range opts {
 		opt(m)
@@ -49,7 +87,7 @@ func NewAgentMiddleware(retriever Retriever, opts ...MiddlewareOption) *AgentMid
 }
 
 // BeforeGenerate retrieves documents relevant to the last user message
-// and prepends them to the system prompt.
+// and injects them according to the configured Placement.
 func (m *AgentMiddleware) BeforeGenerate(ctx context.Context, event *agentfw.GenerateMiddlewareEvent) error {
 	if err := m.NoopMiddleware.BeforeGenerate(ctx, event); err != nil {
 		return err
@@ -59,16 +97,22 @@ func (m *AgentMiddleware) BeforeGenerate(ctx context.Context, event *agentfw.Gen
 	}
 
 	// Find the last user message as the query
-	query := lastUserMessage(event.Request.Messages)
-	if query == "" {
+	userIdx := lastUserMessage(event.Request.Messages)
+	if userIdx < 0 {
 		return nil
 	}
+	query := event.Request.Messages[userIdx].Content
 
 	results, err := m.retriever.Retrieve(ctx, query, m.topK)
 	if err != nil {
 		// Non-fatal: log and continue without context
 		return nil
 	}
+	if m.sources {
+		m.mu.Lock()
+		m.citations[event.RunID] = Citations(results)
+		m.mu.Unlock()
+	}
 	if len(results) == 0 {
 		return nil
 	}
@@ -81,16 +125,69 @@ func (m *AgentMiddleware) BeforeGenerate(ctx context.Context, event *agentfw.Gen
 		sb.WriteString(fmt.Sprintf("\n[%d] (score: %.2f)\n%s\n", i+1, r.Score, r.Document.Content))
 	}
 
-	// Prepend to system prompt
-	event.Request.SystemPrompt = sb.String() + "\n" + event.Request.SystemPrompt
+	block := sb.String()
+	switch m.placement {
+	case PlacementMessage:
+		// Copy so the agent's own history is not modified.
+		msgs := make([]types.Message, 0, len(event.Request.Messages)+1)
+		msgs = append(msgs, event.Request.Messages[:userIdx]...)
+		msgs = append(msgs, types.Message{Role: types.RoleUser, Content: block})
+		msgs = append(msgs, event.Request.Messages[userIdx:]...)
+		event.Request.Messages = msgs
+	case PlacementUserTurn:
+		msgs := append([]types.Message(nil), event.Request.Messages...)
+		msgs[userIdx].Content = msgs[userIdx].Content + "\n\n" + block
+		event.Request.Messages = msgs
+	default:
+		event.Request.SystemPrompt = block + "\n" + event.Request.SystemPrompt
+	}
+	return nil
+}
+
+// AfterGenerate adds the sources footer to a final (tool-free) answer when
+// WithSourcesFooter is set. The footer is skipped when the request carries a
+// response schema, since it would break the structured output. A run's
+// citations are dropped on its final answer or on error (see OnError), so
+// they do not outlive the run.
+func (m *AgentMiddleware) AfterGenerate(ctx context.Context, event *agentfw.GenerateMiddlewareEvent) error {
+	if err := m.NoopMiddleware.AfterGenerate(ctx, event); err != nil {
+		return err
+	}
+	if !m.sources || event.Response == nil {
+		return nil
+	}
+	msg := &event.Response.Message
+	if len(msg.ToolCalls) > 0 {
+		return nil
+	}
+	m.mu.Lock()
+	citations := m.citations[event.RunID]
+	delete(m.citations, event.RunID)
+	m.mu.Unlock()
+	if strings.TrimSpace(msg.Content) == "" || len(event.Request.ResponseSchema) > 0 {
+		return nil
+	}
+	if footer := FormatSources(citations); footer != "" {
+		msg.Content = strings.TrimRight(msg.Content, "\n") + "\n\n" + footer
+	}
 	return nil
 }
 
-func lastUserMessage(msgs []types.Message) string {
+func (m *AgentMiddleware) OnError(ctx context.Context, event *agentfw.ErrorMiddlewareEvent) {
+	m.NoopMiddleware.OnError(ctx, event)
+	if m.sources && event != nil {
+		m.mu.Lock()
+		delete(m.citations, event.RunID)
+		m.mu.Unlock()
+	}
+}
+
+// lastUserMessage returns the index of the last non-empty user message, or -1.
+func lastUserMessage(msgs []types.Message) int {
 	for i := len(msgs) - 1; i >= 0; i-- {
 		if msgs[i].Role == "user" && msgs[i].Content != "" {
-			return msgs[i].Content
+			return i
 		}
 	}
-	return ""
+	return -1
 }
@@ -2,6 +2,7 @@ package rag
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	agentfw "github.com/PipeOpsHQ/agent-sdk-go/agent"
@@ -48,6 +49,135 @@ func TestAgentMiddleware_InjectsContext(t *testing.T) {
 	}
 }
 
+func TestAgentMiddleware_Placement(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	store.Add(ctx, []Document{{ID: "go", Content: "Go is a compiled language", Embedding: []float64{1, 0, 0, 0}}})
+	retriever := &SimpleRetriever{Embedder: &fakeEmbedder{}, Store: store}
+
+	history := []types.Message{
+		{Role: types.RoleUser, Content: "hi"},
+		{Role: types.RoleAssistant, Content: "hello"},
+		{Role: types.RoleUser, Content: "Tell me about Go"},
+	}
+	newEvent := func() *agentfw.GenerateMiddlewareEvent {
+		return &agentfw.GenerateMiddlewareEvent{Request: &types.Request{SystemPrompt: "sys", Messages: history}}
+	}
+
+	event := newEvent()
+	if err := NewAgentMiddleware(retriever, WithPlacement(PlacementMessage)).BeforeGenerate(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+	msgs := event.Request.Messages
+	if event.Request.SystemPrompt != "sys" || len(msgs) != 4 {
+		t.Fatalf("message placement: system=%q messages=%d", event.Request.SystemPrompt, len(msgs))
+	}
+	if !strings.Contains(msgs[2].Content, "compiled language") || msgs[3].Content != "Tell me about Go" {
+		t.Errorf("context message not inserted before the user turn: %+v", msgs)
+	}
+
+	event = newEvent()
+	if err := NewAgentMiddleware(retriever, WithPlacement(PlacementUserTurn)).BeforeGenerate(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+	msgs = event.Request.Messages
+	if len(msgs) != 3 || !strings.HasPrefix(msgs[2].Content, "Tell me about Go\n\nRelevant context:") {
+		t.Errorf("user turn placement = %+v", msgs)
+	}
+
+	if history[2].Content != "Tell me about Go" {
+		t.Errorf("placement modified the caller's messages: %q", history[2].Content)
+	}
+}
+
+func TestAgentMiddleware_SourcesFooter(t *testing.T) {
+	store := NewMemoryStore()
+	embedder := &fakeEmbedder{}
+	ctx := context.Background()
+
+	parent := Document{ID: "go-faq", Content: "Go is a compiled language", Metadata: map[string]any{MetaSource: "docs/go.md", MetaTitle: "Go FAQ"}}
+	chunks := ChunkToDocuments(parent, ChunkOptions{})
+	vec, _ := embedder.Embed(ctx, chunks[0].Content)
+	chunks[0].Embedding = vec
+	store.Add(ctx, chunks)
+
+	mw := NewAgentMiddleware(&SimpleRetriever{Embedder: embedder, Store: store}, WithTopK(1), WithSourcesFooter())
+	event := &agentfw.GenerateMiddlewareEvent{
+		RunID: "run-1",
+		Request: &types.Request{
+			Messages: []types.Message{{Role: "user", Content: "Tell me about Go"}},
+		},
+	}
+	if err := mw.BeforeGenerate(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+
+	// Intermediate tool-calling turns are left alone.
+	event.Response = &types.Response{Message: types.Message{ToolCalls: []types.ToolCall{{Name: "lookup"}}}}
+	if err := mw.AfterGenerate(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Response.Message.Content != "" {
+		t.Fatalf("tool-call turn got footer: %q", event.Response.Message.Content)
+	}
+
+	event.Response = &types.Response{Message: types.Message{Content: "Go compiles to native code [1]."}}
+	if err := mw.AfterGenerate(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+	want := "Go compiles to native code [1].\n\nSources:\n[1] Go FAQ (docs/go.md), chars 0-25"
+	if got := event.Response.Message.Content; got != want {
+		t.Fatalf("content = %q, want %q", got, want)
+	}
+
+	if n := len(mw.citations); n != 0 {
+		t.Fatalf("citations kept for %d runs after the final answer", n)
+	}
+
+	// Structured answers get no footer; errors and empty answers still
+	// release the run's citations.
+	schemaEvent := &agentfw.GenerateMiddlewareEvent{
+		RunID: "run-schema",
+		Request: &types.Request{
+			Messages:       []types.Message{{Role: "user", Content: "Tell me about Go"}},
+			ResponseSchema: map[string]any{"type": "object"},
+		},
+	}
+	if err := mw.BeforeGenerate(ctx, schemaEvent); err != nil {
+		t.Fatal(err)
+	}
+	schemaEvent.Response = &types.Response{Message: types.Message{Content: `{"answer":"compiled"}`}}
+	if err := mw.AfterGenerate(ctx, schemaEvent); err != nil {
+		t.Fatal(err)
+	}
+	if got := schemaEvent.Response.Message.Content; got != `{"answer":"compiled"}` {
+		t.Fatalf("structured content = %q, want it unchanged", got)
+	}
+	for _, runID := range []string{"run-empty", "run-error"} {
+		e := &agentfw.GenerateMiddlewareEvent{RunID: runID, Request: &types.Request{
+			Messages: []types.Message{{Role: "user", Content: "Tell me about Go"}},
+		}}
+		if err := mw.BeforeGenerate(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mw.AfterGenerate(ctx, &agentfw.GenerateMiddlewareEvent{RunID: "run-empty", Response: &types.Response{}}); err != nil {
+		t.Fatal(err)
+	}
+	mw.OnError(ctx, &agentfw.ErrorMiddlewareEvent{RunID: "run-error", Stage: "max_iterations"})
+	if n := len(mw.citations); n != 0 {
+		t.Fatalf("citations kept for %d runs after they ended", n)
+	}
+
+	citations := Citations([]SearchResult{{Document: chunks[0], Score: 0.9}})
+	if len(citations) != 1 || citations[0].DocumentID != "go-faq#0" || citations[0].EndOffset != 25 || citations[0].Score != 0.9 {
+		t.Fatalf("citations = %+v", citations)
+	}
+	if !strings.HasPrefix(FormatSources(citations), "Sources:") || FormatSources(nil) != "" {
+		t.Error("unexpected FormatSources output")
+	}
+}
+
 func TestAgentMiddleware_NoUserMessage(t *testing.T) {
 	retriever := &SimpleRetriever{Embedder: &fakeEmbedder{}, Store: NewMemoryStore()}
 	mw := NewAgentMiddleware(retriever)
@@ -65,29 +65,92 @@ func (r *SimpleRetriever) Retrieve(ctx context.Context, query string, topK int)
 }
 
 // MemoryStore is an in-memory vector store using cosine similarity.
+// An ID index keeps Delete and SearchWithin proportional to the number of
+// IDs involved rather than the store size; Delete does not preserve
+// insertion order.
 type MemoryStore struct {
-	mu   sync.RWMutex
-	docs []Document
+	mu       sync.RWMutex
+	docs     []Document
+	index    map[string][]int // document ID -> positions in docs
+	tieBreak func(a, b Document) bool
+}
+
+// MemoryStoreOption configures a MemoryStore.
+type MemoryStoreOption func(*MemoryStore)
+
+// WithTieBreaker orders documents with equal scores; less reports whether a
+// ranks before b. The default orders by document ID.
+func WithTieBreaker(less func(a, b Document) bool) MemoryStoreOption {
+	return func(m *MemoryStore) {
+		if less != nil {
+			m.tieBreak = less
+		}
+	}
 }
 
 // NewMemoryStore creates an empty in-memory vector store.
-func NewMemoryStore() *MemoryStore {
-	return &MemoryStore{}
+func NewMemoryStore(opts ...MemoryStoreOption) *MemoryStore {
+	m := &MemoryStore{index: map[string][]int{}}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
+func byID(a, b Document) bool { return a.ID < b.ID }
+
 func (m *MemoryStore) Add(_ context.Context, docs []Document) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.docs = append(m.docs, docs...)
+	if m.index == nil {
+		m.index = map[string][]int{}
+	}
+	for _, doc := range docs {
+		m.index[doc.ID] = append(m.index[doc.ID], len(m.docs))
+		m.docs = append(m.docs, doc)
+	}
 	return nil
 }
 
 func (m *MemoryStore) Search(_ context.Context, queryVec []float64, topK int) ([]SearchResult, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	return rank(queryVec, m.docs, topK, m.tieBreaker()), nil
+}
+
+// SearchWithin ranks only the documents whose IDs are listed, for two-stage
+// retrieval where a cheaper filter has already picked the candidates.
+// Unknown IDs are ignored.
+func (m *MemoryStore) SearchWithin(_ context.Context, queryVec []float64, ids []string, topK int) ([]SearchResult, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	seen := make(map[string]bool, len(ids))
+	candidates := make([]Document, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		for _, pos := range m.index[id] {
+			candidates = append(candidates, m.docs[pos])
+		}
+	}
+	return rank(queryVec, candidates, topK, m.tieBreaker()), nil
+}
 
-	results := make([]SearchResult, 0, len(m.docs))
-	for _, doc := range m.docs {
+func (m *MemoryStore) tieBreaker() func(a, b Document) bool {
+	if m.tieBreak != nil {
+		return m.tieBreak
+	}
+	return byID
+}
+
+// rank scores docs against queryVec and returns the best topK (all when
+// topK <= 0), breaking score ties with less so results are deterministic.
+// Documents without embeddings are skipped.
+func rank(queryVec []float64, docs []Document, topK int, less func(a, b Document) bool) []SearchResult {
+	results := make([]SearchResult, 0, len(docs))
+	for _, doc := range docs {
 		if len(doc.Embedding) == 0 {
 			continue
 		}
@@ -95,33 +158,56 @@ func (m *MemoryStore) Search(_ context.Context, queryVec []float64, topK int) ([
 		results = append(results, SearchResult{Document: doc, Score: score})
 	}
 
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return less(results[i].Document, results[j].Document)
 	})
 
 	if topK > 0 && len(results) > topK {
 		results = results[:topK]
 	}
-	return results, nil
+	return results
 }
 
 func (m *MemoryStore) Delete(_ context.Context, ids []string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	idSet := make(map[string]bool, len(ids))
 	for _, id := range ids {
-		idSet[id] = true
-	}
-	filtered := m.docs[:0]
-	for _, doc := range m.docs {
-		if !idSet[doc.ID] {
-			filtered = append(filtered, doc)
+		positions, ok := m.index[id]
+		if !ok {
+			continue
+		}
+		delete(m.index, id)
+		// Remove from the back so each swap moves a document with another ID.
+		sort.Sort(sort.Reverse(sort.IntSlice(positions)))
+		for _, pos := range positions {
+			m.removeAt(pos)
 		}
 	}
-	m.docs = filtered
 	return nil
 }
 
+// removeAt drops the document at pos by moving the last document into its
+// place. The caller has already removed pos from the index.
+func (m *MemoryStore) removeAt(pos int) {
+	last := len(m.docs) - 1
+	if pos != last {
+		moved := m.docs[last]
+		m.docs[pos] = moved
+		positions := m.index[moved.ID]
+		for i, p := range positions {
+			if p == last {
+				positions[i] = pos
+				break
+			}
+		}
+	}
+	m.docs[last] = Document{}
+	m.docs = m.docs[:last]
+}
+
 func (m *MemoryStore) Count() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
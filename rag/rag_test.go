@@ -2,8 +2,13 @@ package rag
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // fakeEmbedder returns a deterministic embedding for testing.
@@ -73,6 +78,54 @@ func TestMemoryStoreAddAndSearch(t *testing.T) {
 	}
 }
 
+func TestMemoryStoreSearchWithin(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	store.Add(ctx, []Document{
+		{ID: "1", Content: "Go", Embedding: []float64{1, 0, 0}},
+		{ID: "2", Content: "Rust", Embedding: []float64{0.8, 0.2, 0}},
+		{ID: "3", Content: "Python", Embedding: []float64{0.6, 0.4, 0}},
+		{ID: "4", Content: "Cooking", Embedding: []float64{0, 0, 1}},
+	})
+
+	results, err := store.SearchWithin(ctx, []float64{1, 0, 0}, []string{"4", "3", "2", "missing"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Document.ID != "2" || results[1].Document.ID != "3" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if results, _ := store.SearchWithin(ctx, []float64{1, 0, 0}, nil, 5); len(results) != 0 {
+		t.Errorf("expected no results for empty id set, got %d", len(results))
+	}
+}
+
+func TestMemoryStoreTieBreak(t *testing.T) {
+	ctx := context.Background()
+	docs := []Document{
+		{ID: "c", Embedding: []float64{1, 0}},
+		{ID: "a", Embedding: []float64{2, 0}},
+		{ID: "b", Embedding: []float64{3, 0}},
+		{ID: "z", Embedding: []float64{0, 1}},
+	}
+
+	store := NewMemoryStore()
+	store.Add(ctx, docs)
+	results, _ := store.Search(ctx, []float64{1, 0}, 3)
+	if got := results[0].Document.ID + results[1].Document.ID + results[2].Document.ID; got != "abc" {
+		t.Errorf("default tie-break order = %s, want abc", got)
+	}
+
+	store = NewMemoryStore(WithTieBreaker(func(a, b Document) bool { return a.ID > b.ID }))
+	store.Add(ctx, docs)
+	results, _ = store.SearchWithin(ctx, []float64{1, 0}, []string{"a", "b", "c"}, 2)
+	if len(results) != 2 || results[0].Document.ID != "c" || results[1].Document.ID != "b" {
+		t.Errorf("custom tie-break results = %+v", results)
+	}
+}
+
 func TestMemoryStoreDelete(t *testing.T) {
 	store := NewMemoryStore()
 	ctx := context.Background()
@@ -98,6 +151,49 @@ func TestMemoryStoreDelete(t *testing.T) {
 	}
 }
 
+func TestMemoryStoreDeleteChurn(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	var docs []Document
+	for i := 0; i < 50; i++ {
+		docs = append(docs, Document{ID: fmt.Sprintf("d%d", i), Embedding: []float64{1, float64(i)}})
+	}
+	// Duplicate IDs are kept as separate documents and deleted together.
+	docs = append(docs, Document{ID: "d7", Embedding: []float64{1, 7}})
+	store.Add(ctx, docs)
+
+	for i := 0; i < 50; i += 3 {
+		if err := store.Delete(ctx, []string{fmt.Sprintf("d%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Delete(ctx, []string{"d7", "d8", "missing"})
+
+	want := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		if i%3 != 0 && i != 7 && i != 8 {
+			want[fmt.Sprintf("d%d", i)] = true
+		}
+	}
+	if store.Count() != len(want) {
+		t.Fatalf("Count = %d, want %d", store.Count(), len(want))
+	}
+	results, _ := store.Search(ctx, []float64{1, 0}, 0)
+	for _, r := range results {
+		if !want[r.Document.ID] {
+			t.Errorf("unexpected document %s after deletes", r.Document.ID)
+		}
+	}
+	ids := make([]string, 0, len(want))
+	for id := range want {
+		ids = append(ids, id)
+	}
+	if within, _ := store.SearchWithin(ctx, []float64{1, 0}, ids, 0); len(within) != len(want) {
+		t.Errorf("SearchWithin found %d documents, want %d", len(within), len(want))
+	}
+}
+
 func TestSimpleRetriever(t *testing.T) {
 	store := NewMemoryStore()
 	embedder := &fakeEmbedder{}
@@ -151,3 +247,148 @@ func TestCosineSimilarity(t *testing.T) {
 		})
 	}
 }
+
+// flakyEmbedder fails any batch containing "bad" and tracks concurrency.
+type flakyEmbedder struct {
+	fakeEmbedder
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (f *flakyEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	if text == "bad" {
+		return nil, errors.New("cannot embed")
+	}
+	return f.fakeEmbedder.Embed(ctx, text)
+}
+
+func (f *flakyEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.peak = max(f.peak, f.inFlight)
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+	time.Sleep(5 * time.Millisecond)
+	for _, t := range texts {
+		if t == "bad" {
+			return nil, errors.New("batch failed")
+		}
+	}
+	return f.fakeEmbedder.EmbedBatch(ctx, texts)
+}
+
+func TestBuildIndex(t *testing.T) {
+	docs := make([]Document, 200)
+	for i := range docs {
+		docs[i] = Document{ID: fmt.Sprintf("doc-%d", i), Content: fmt.Sprintf("content %d", i)}
+	}
+	docs[7].Content = "bad"
+	docs[150].Content = "bad"
+
+	store := NewMemoryStore()
+	emb := &flakyEmbedder{}
+	report, err := BuildIndex(context.Background(), store, emb, docs, 3)
+	if !errors.Is(err, ErrPartialIndex) {
+		t.Fatalf("err = %v, want ErrPartialIndex", err)
+	}
+	if report.Indexed != 198 || store.Count() != 198 {
+		t.Errorf("indexed = %d, stored = %d, want 198", report.Indexed, store.Count())
+	}
+	if len(report.Failed) != 2 || report.Failed[0].DocID != "doc-7" || report.Failed[1].DocID != "doc-150" {
+		t.Errorf("failed = %+v", report.Failed)
+	}
+	if emb.peak > 3 || emb.peak < 2 {
+		t.Errorf("peak concurrency = %d, want 2..3", emb.peak)
+	}
+	if docs[0].Embedding != nil {
+		t.Error("BuildIndex mutated input documents")
+	}
+
+	ok, err := BuildIndex(context.Background(), NewMemoryStore(), &fakeEmbedder{}, docs[:5], 0)
+	if err != nil || ok.Indexed != 5 {
+		t.Errorf("serial build = %+v, %v", ok, err)
+	}
+}
+
+func TestChunkToDocuments(t *testing.T) {
+	parent := Document{
+		ID:       "guide",
+		Content:  "alpha beta gamma delta epsilon zeta eta theta",
+		Metadata: map[string]any{MetaSource: "docs/guide.md", MetaTitle: "Guide"},
+	}
+
+	chunks := ChunkToDocuments(parent, ChunkOptions{Size: 16, Overlap: 4})
+	if len(chunks) < 3 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+	for i, c := range chunks {
+		info, ok := ChunkInfoOf(c)
+		if !ok {
+			t.Fatalf("chunk %d has no chunk info", i)
+		}
+		if info.Index != i || info.Count != len(chunks) || info.ParentID != "guide" {
+			t.Errorf("chunk %d info = %+v", i, info)
+		}
+		if info.Source != "docs/guide.md" || info.Title != "Guide" {
+			t.Errorf("chunk %d lost source metadata: %+v", i, info)
+		}
+		if got := parent.Content[info.StartOffset:info.EndOffset]; got != c.Content {
+			t.Errorf("chunk %d offsets select %q, content is %q", i, got, c.Content)
+		}
+		if len(c.Content) > 16 {
+			t.Errorf("chunk %d is %d bytes, want <= 16", i, len(c.Content))
+		}
+	}
+	if chunks[0].ID != "guide#0" {
+		t.Errorf("first chunk ID = %q", chunks[0].ID)
+	}
+	if chunks[len(chunks)-1].Metadata[MetaEndOffset] != len(parent.Content) {
+		t.Error("last chunk does not reach the end of the document")
+	}
+	if parent.Metadata[MetaChunkIndex] != nil {
+		t.Error("chunking modified the parent metadata")
+	}
+
+	// Positions survive a JSON round trip through a persistent store.
+	decoded := Document{Metadata: map[string]any{MetaChunkIndex: float64(2), MetaStartOffset: float64(10), MetaEndOffset: float64(20)}}
+	if info, ok := ChunkInfoOf(decoded); !ok || info.Index != 2 || info.StartOffset != 10 || info.EndOffset != 20 {
+		t.Errorf("decoded chunk info = %+v, %v", info, ok)
+	}
+	if _, ok := ChunkInfoOf(parent); ok {
+		t.Error("unchunked document reported chunk info")
+	}
+	if got := ChunkToDocuments(Document{ID: "empty"}, ChunkOptions{}); len(got) != 0 {
+		t.Errorf("empty document produced %d chunks", len(got))
+	}
+}
+
+func TestFallbackEmbedder(t *testing.T) {
+	ctx := context.Background()
+	f := NewFallbackEmbedder(&flakyEmbedder{}, nil, &fakeEmbedder{})
+
+	want, _ := (&fakeEmbedder{}).Embed(ctx, "bad")
+	got, err := f.Embed(ctx, "bad")
+	if err != nil || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("Embed fallback = %v, %v; want %v", got, err, want)
+	}
+
+	texts := []string{"good", "bad"}
+	wantBatch, _ := (&fakeEmbedder{}).EmbedBatch(ctx, texts)
+	gotBatch, err := f.EmbedBatch(ctx, texts)
+	if err != nil || fmt.Sprint(gotBatch) != fmt.Sprint(wantBatch) {
+		t.Fatalf("EmbedBatch fallback = %v, %v; want whole batch from secondary %v", gotBatch, err, wantBatch)
+	}
+
+	allBad := NewFallbackEmbedder(&flakyEmbedder{}, &flakyEmbedder{})
+	if _, err := allBad.EmbedBatch(ctx, texts); err == nil || !strings.Contains(err.Error(), "embedder 1: batch failed") {
+		t.Fatalf("expected joined errors, got %v", err)
+	}
+	if _, err := NewFallbackEmbedder().Embed(ctx, "x"); err == nil {
+		t.Fatal("expected error with no embedders")
+	}
+}
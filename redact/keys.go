@@ -0,0 +1,68 @@
+package redact
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sensitiveKeyTerms match anywhere in a key once it is lowercased and its
+// separators are removed, so "api_key", "apiKey" and "api-key" all match
+// "apikey".
+var sensitiveKeyTerms = []string{
+	"password", "passwd", "secret", "token", "credential", "apikey",
+	"privatekey", "bearer", "connectionstring", "connstr",
+}
+
+// sensitiveKeyWords only match as a whole word, so keys like "keywords",
+// "author" or "passage" stay visible.
+var sensitiveKeyWords = []string{"key", "pass", "pwd", "auth", "jwt", "pem", "salt"}
+
+// IsSensitiveKey reports whether a map or metadata key name suggests its
+// value is a credential (token, key, secret, password, ...).
+func IsSensitiveKey(key string) bool {
+	words := keyWords(key)
+	compact := strings.Join(words, "")
+	for _, term := range sensitiveKeyTerms {
+		if strings.Contains(compact, term) {
+			return true
+		}
+	}
+	for _, word := range words {
+		for _, w := range sensitiveKeyWords {
+			if word == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keyWords splits a key on separators and camelCase boundaries and
+// lowercases each word.
+func keyWords(key string) []string {
+	var (
+		words []string
+		cur   []rune
+		prev  rune
+	)
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+	for _, r := range key {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+		prev = r
+	}
+	flush()
+	return words
+}
@@ -2,6 +2,7 @@ package distributed
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -14,10 +15,24 @@ import (
 )
 
 type DistributedConfig struct {
-	Queue  QueueConfig
+	Queue QueueConfig
+	// Policy is shared with the workers draining the queue; pass the same
+	// value to NewWorker so retries, DLQ routing and OnExhausted agree.
 	Policy RuntimePolicy
+	// MaxInFlight caps how many runs may be queued or claimed-but-unacked
+	// (queue lag + pending) before SubmitRun stops admitting new ones. Zero
+	// means unlimited.
+	MaxInFlight int
+	// AdmissionWait is how long SubmitRun waits for capacity when the queue
+	// is at MaxInFlight, polling every Policy.PollInterval. Zero rejects
+	// immediately with ErrAtCapacity.
+	AdmissionWait time.Duration
 }
 
+// ErrAtCapacity is returned by SubmitRun when MaxInFlight runs are already
+// in flight and no capacity freed up within AdmissionWait.
+var ErrAtCapacity = errors.New("distributed: coordinator at capacity")
+
 type QueueConfig struct {
 	Name   string
 	Prefix string
@@ -48,11 +63,16 @@ type coordinator struct {
 	observer  observe.Sink
 	policy    RuntimePolicy
 	queueName string
-	mu        sync.Mutex
-	cancelled map[string]time.Time // value = when cancelled; entries expire after 1 hour
-	started   bool
-	cancel    context.CancelFunc
-	done      chan struct{}
+	// admission limits; admitMu serializes the capacity check with the
+	// enqueue so concurrent submits cannot overshoot MaxInFlight.
+	maxInFlight   int
+	admissionWait time.Duration
+	admitMu       sync.Mutex
+	mu            sync.Mutex
+	cancelled     map[string]time.Time // value = when cancelled; entries expire after 1 hour
+	started       bool
+	cancel        context.CancelFunc
+	done          chan struct{}
 }
 
 func NewCoordinator(store state.Store, attempts AttemptStore, queueStore queue.Queue, observer observe.Sink, cfg DistributedConfig) (Coordinator, error) {
@@ -78,6 +98,9 @@ func NewCoordinator(store state.Store, attempts AttemptStore, queueStore queue.Q
 		policy:    policy,
 		queueName: queueName,
 		cancelled: map[string]time.Time{},
+
+		maxInFlight:   cfg.MaxInFlight,
+		admissionWait: cfg.AdmissionWait,
 	}, nil
 }
 
@@ -172,6 +195,12 @@ func (c *coordinator) SubmitRun(ctx context.Context, req SubmitRequest) (SubmitR
 	if sessionID == "" {
 		sessionID = uuid.NewString()
 	}
+	if c.maxInFlight > 0 {
+		if err := c.awaitCapacity(ctx); err != nil {
+			return SubmitResult{}, err
+		}
+		defer c.admitMu.Unlock()
+	}
 	now := time.Now().UTC()
 	attempts := req.MaxAttempts
 	if attempts <= 0 {
@@ -190,7 +219,7 @@ func (c *coordinator) SubmitRun(ctx context.Context, req SubmitRequest) (SubmitR
 		RunID:     runID,
 		SessionID: sessionID,
 		Provider:  "distributed",
-		Status:    "queued",
+		Status:    state.RunQueued,
 		Input:     req.Input,
 		Output:    "",
 		Messages:  nil,
@@ -240,6 +269,38 @@ func (c *coordinator) SubmitRun(ctx context.Context, req SubmitRequest) (SubmitR
 	return SubmitResult{RunID: runID, SessionID: sessionID, MessageID: msgID, EnqueuedAt: now}, nil
 }
 
+// awaitCapacity returns nil with admitMu held once fewer than maxInFlight
+// runs are undelivered or unacked; the caller unlocks it after enqueueing.
+// The lock is released between polls, and ErrAtCapacity is returned when
+// admissionWait elapses first.
+func (c *coordinator) awaitCapacity(ctx context.Context) error {
+	deadline := time.Now().Add(c.admissionWait)
+	for {
+		c.admitMu.Lock()
+		stats, err := c.queue.Stats(ctx)
+		if err != nil {
+			c.admitMu.Unlock()
+			return fmt.Errorf("failed to read queue stats: %w", err)
+		}
+		inFlight := stats.Lag + stats.Pending
+		if inFlight < int64(c.maxInFlight) {
+			return nil
+		}
+		c.admitMu.Unlock()
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%w: %d runs in flight (limit %d)", ErrAtCapacity, inFlight, c.maxInFlight)
+		}
+		timer := time.NewTimer(min(c.policy.PollInterval, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
 func (c *coordinator) CancelRun(ctx context.Context, runID string) error {
 	runID = strings.TrimSpace(runID)
 	if runID == "" {
@@ -250,7 +311,7 @@ func (c *coordinator) CancelRun(ctx context.Context, runID string) error {
 		return err
 	}
 	now := time.Now().UTC()
-	run.Status = "canceled"
+	run.Status = state.RunCanceled
 	run.Error = "canceled"
 	run.CompletedAt = &now
 	run.UpdatedAt = &now
@@ -316,7 +377,7 @@ func (c *coordinator) RequeueRun(ctx context.Context, runID string) error {
 		return err
 	}
 	now := time.Now().UTC()
-	run.Status = "queued"
+	run.Status = state.RunQueued
 	run.Error = ""
 	run.CompletedAt = nil
 	run.UpdatedAt = &now
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/PipeOpsHQ/agent-sdk-go/runtime/queue"
+	statememory "github.com/PipeOpsHQ/agent-sdk-go/state/memory"
 	statesqlite "github.com/PipeOpsHQ/agent-sdk-go/state/sqlite"
 )
 
@@ -69,7 +70,8 @@ func (f *fakeQueue) Stats(ctx context.Context) (queue.Stats, error) {
 	_ = ctx
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	return queue.Stats{StreamLength: int64(len(f.tasks)), DLQLength: int64(len(f.dlq))}, nil
+	// Nothing is ever claimed, so every enqueued task counts as lag.
+	return queue.Stats{StreamLength: int64(len(f.tasks)), DLQLength: int64(len(f.dlq)), Lag: int64(len(f.tasks))}, nil
 }
 func (f *fakeQueue) Close() error { return nil }
 
@@ -116,6 +118,131 @@ func TestCoordinatorSubmitAndCancel(t *testing.T) {
 	}
 }
 
+func TestCoordinatorMaxInFlight(t *testing.T) {
+	store := statememory.New()
+	attempts, err := NewSQLiteAttemptStore(t.TempDir() + "/attempts.db")
+	if err != nil {
+		t.Fatalf("attempt store: %v", err)
+	}
+	defer func() { _ = attempts.Close() }()
+
+	fq := &fakeQueue{}
+	c, err := NewCoordinator(store, attempts, fq, nil, DistributedConfig{MaxInFlight: 2})
+	if err != nil {
+		t.Fatalf("new coordinator: %v", err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := c.SubmitRun(ctx, SubmitRequest{Input: "hello"}); err != nil {
+			t.Fatalf("submit %d: %v", i, err)
+		}
+	}
+	_, err = c.SubmitRun(ctx, SubmitRequest{RunID: "rejected", Input: "hello"})
+	if !errors.Is(err, ErrAtCapacity) {
+		t.Fatalf("expected ErrAtCapacity, got %v", err)
+	}
+	if _, err := store.LoadRun(ctx, "rejected"); err == nil {
+		t.Fatalf("rejected run must not be persisted")
+	}
+
+	c, err = NewCoordinator(store, attempts, fq, nil, DistributedConfig{
+		MaxInFlight:   2,
+		AdmissionWait: 2 * time.Second,
+		Policy:        RuntimePolicy{PollInterval: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("new coordinator: %v", err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		fq.mu.Lock()
+		fq.tasks = fq.tasks[:1]
+		fq.mu.Unlock()
+	}()
+	if _, err := c.SubmitRun(ctx, SubmitRequest{Input: "hello"}); err != nil {
+		t.Fatalf("expected submit to wait for capacity, got %v", err)
+	}
+}
+
+func TestCoordinatorAdmissionWaitDoesNotBlockOtherSubmits(t *testing.T) {
+	store := statememory.New()
+	attempts, err := NewSQLiteAttemptStore(t.TempDir() + "/attempts.db")
+	if err != nil {
+		t.Fatalf("attempt store: %v", err)
+	}
+	defer func() { _ = attempts.Close() }()
+
+	fq := &fakeQueue{}
+	c, err := NewCoordinator(store, attempts, fq, nil, DistributedConfig{
+		MaxInFlight:   1,
+		AdmissionWait: time.Second,
+		Policy:        RuntimePolicy{PollInterval: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("new coordinator: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := c.SubmitRun(ctx, SubmitRequest{Input: "hello"}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	waiting := make(chan error, 1)
+	go func() {
+		_, err := c.SubmitRun(ctx, SubmitRequest{Input: "hello"})
+		waiting <- err
+	}()
+	time.Sleep(30 * time.Millisecond)
+
+	// A second submit must honour its own deadline instead of queueing
+	// behind the one already waiting for capacity.
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.SubmitRun(shortCtx, SubmitRequest{Input: "hello"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("submit blocked for %s behind the waiting one", elapsed)
+	}
+	if err := <-waiting; !errors.Is(err, ErrAtCapacity) {
+		t.Fatalf("expected ErrAtCapacity for the waiting submit, got %v", err)
+	}
+}
+
+func TestCoordinatorWithMemoryStore(t *testing.T) {
+	store := statememory.New()
+	attempts, err := NewSQLiteAttemptStore(t.TempDir() + "/attempts.db")
+	if err != nil {
+		t.Fatalf("attempt store: %v", err)
+	}
+	defer func() { _ = attempts.Close() }()
+
+	c, err := NewCoordinator(store, attempts, &fakeQueue{}, nil, DistributedConfig{})
+	if err != nil {
+		t.Fatalf("new coordinator: %v", err)
+	}
+	res, err := c.SubmitRun(context.Background(), SubmitRequest{Input: "hello", MaxAttempts: 2})
+	if err != nil {
+		t.Fatalf("submit run: %v", err)
+	}
+	run, err := store.LoadRun(context.Background(), res.RunID)
+	if err != nil {
+		t.Fatalf("load run: %v", err)
+	}
+	if run.Status != "queued" {
+		t.Fatalf("expected queued status, got %s", run.Status)
+	}
+	if _, ok := run.Metadata["attempt"].(float64); !ok {
+		t.Fatalf("expected attempt to decode as float64 like sqlite, got %#v", run.Metadata["attempt"])
+	}
+	if err := c.CancelRun(context.Background(), res.RunID); err != nil {
+		t.Fatalf("cancel run: %v", err)
+	}
+	run, _ = store.LoadRun(context.Background(), res.RunID)
+	if run.Status != "canceled" {
+		t.Fatalf("expected canceled status, got %s", run.Status)
+	}
+}
+
 func TestCoordinatorStopCancelsStartLoop(t *testing.T) {
 	store, err := statesqlite.New(t.TempDir() + "/state.db")
 	if err != nil {
@@ -9,6 +9,18 @@ type RuntimePolicy struct {
 	PollInterval      time.Duration
 	ClaimBlock        time.Duration
 	HeartbeatInterval time.Duration
+	// VisibilityTimeout is how long a claimed message may go unacked before
+	// another worker reclaims it. Workers extend their claims while a run is
+	// in progress, so this only fires for crashed workers. Negative disables
+	// reclamation. Requires a queue implementing queue.Reclaimer.
+	VisibilityTimeout time.Duration
+	// OnExhausted is called after a run fails its final attempt and has been
+	// dead-lettered.
+	OnExhausted ExhaustedFunc
+	// DLQRoutes maps a task's workflow name to a named DLQ. Workflows without
+	// a route, or queues that do not implement queue.DLQRouter, use the
+	// default DLQ.
+	DLQRoutes map[string]string
 }
 
 func DefaultRuntimePolicy() RuntimePolicy {
@@ -19,6 +31,7 @@ func DefaultRuntimePolicy() RuntimePolicy {
 		PollInterval:      200 * time.Millisecond,
 		ClaimBlock:        2 * time.Second,
 		HeartbeatInterval: 5 * time.Second,
+		VisibilityTimeout: 5 * time.Minute,
 	}
 }
 
@@ -44,6 +57,9 @@ func NormalizeRuntimePolicy(policy RuntimePolicy) RuntimePolicy {
 	if policy.HeartbeatInterval <= 0 {
 		policy.HeartbeatInterval = 5 * time.Second
 	}
+	if policy.VisibilityTimeout == 0 {
+		policy.VisibilityTimeout = 5 * time.Minute
+	}
 	return policy
 }
 
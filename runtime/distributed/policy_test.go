@@ -17,3 +17,12 @@ func TestRuntimePolicyBackoff(t *testing.T) {
 		t.Fatalf("expected capped backoff, got %v", p.Backoff(8))
 	}
 }
+
+func TestRuntimePolicyVisibilityTimeout(t *testing.T) {
+	if got := NormalizeRuntimePolicy(RuntimePolicy{}).VisibilityTimeout; got != 5*time.Minute {
+		t.Fatalf("expected default visibility timeout, got %v", got)
+	}
+	if got := NormalizeRuntimePolicy(RuntimePolicy{VisibilityTimeout: -1}).VisibilityTimeout; got >= 0 {
+		t.Fatalf("expected negative visibility timeout to stay disabled, got %v", got)
+	}
+}
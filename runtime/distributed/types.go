@@ -41,6 +41,9 @@ type ProcessResult struct {
 
 type ProcessFunc func(ctx context.Context, task queue.Task) (ProcessResult, error)
 
+// ExhaustedFunc handles a run that has used up its MaxAttempts.
+type ExhaustedFunc func(ctx context.Context, runID string, lastErr error)
+
 type AttemptRecord struct {
 	RunID     string         `json:"runId"`
 	Attempt   int            `json:"attempt"`
@@ -134,7 +134,7 @@ func (w *worker) Start(ctx context.Context) error {
 				},
 			})
 		default:
-			deliveries, err := w.queue.Claim(runCtx, w.cfg.WorkerID, w.policy.ClaimBlock, w.cfg.Capacity)
+			deliveries, err := w.claim(runCtx)
 			if err != nil {
 				pollTimer.Reset(w.policy.PollInterval)
 				select {
@@ -153,6 +153,7 @@ func (w *worker) Start(ctx context.Context) error {
 				}
 				continue
 			}
+			stopExtend := w.extendClaims(runCtx, deliveries)
 			for _, delivery := range deliveries {
 				if err := w.handleDelivery(runCtx, delivery); err != nil {
 					_ = w.attempts.SaveQueueEvent(runCtx, QueueEvent{
@@ -166,10 +167,69 @@ func (w *worker) Start(ctx context.Context) error {
 					})
 				}
 			}
+			stopExtend()
 		}
 	}
 }
 
+// claim first reclaims deliveries abandoned past the visibility timeout,
+// then falls back to claiming new ones.
+func (w *worker) claim(ctx context.Context) ([]queue.Delivery, error) {
+	if r, ok := w.queue.(queue.Reclaimer); ok && w.policy.VisibilityTimeout > 0 {
+		reclaimed, err := r.Reclaim(ctx, w.cfg.WorkerID, w.policy.VisibilityTimeout, w.cfg.Capacity)
+		if err == nil && len(reclaimed) > 0 {
+			for _, d := range reclaimed {
+				_ = w.attempts.SaveQueueEvent(ctx, QueueEvent{
+					RunID:   d.Task.RunID,
+					Event:   "queue.reclaimed",
+					At:      time.Now().UTC(),
+					Payload: map[string]any{"workerId": w.cfg.WorkerID, "messageId": d.ID, "attempt": d.Task.Attempt},
+				})
+			}
+			return reclaimed, nil
+		}
+	}
+	return w.queue.Claim(ctx, w.cfg.WorkerID, w.policy.ClaimBlock, w.cfg.Capacity)
+}
+
+// extendClaims keeps the batch's claims fresh while it is being processed so
+// other workers do not reclaim in-flight runs. The returned func stops it.
+func (w *worker) extendClaims(ctx context.Context, deliveries []queue.Delivery) func() {
+	r, ok := w.queue.(queue.Reclaimer)
+	if !ok || w.policy.VisibilityTimeout <= 0 || len(deliveries) == 0 {
+		return func() {}
+	}
+	ids := make([]string, 0, len(deliveries))
+	for _, d := range deliveries {
+		ids = append(ids, d.ID)
+	}
+	interval := w.policy.VisibilityTimeout / 3
+	if interval <= 0 {
+		interval = w.policy.VisibilityTimeout
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+				_ = r.Extend(ctx, w.cfg.WorkerID, ids...)
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
 func (w *worker) Stop(ctx context.Context) error {
 	if w == nil {
 		return nil
@@ -215,7 +275,7 @@ func (w *worker) handleDelivery(ctx context.Context, delivery queue.Delivery) er
 
 	run, err := w.store.LoadRun(ctx, task.RunID)
 	if err == nil {
-		if run.Status == "canceled" || run.Status == "completed" {
+		if state.IsTerminalStatus(run.Status) {
 			return w.queue.Ack(ctx, w.cfg.WorkerID, delivery.ID)
 		}
 	}
@@ -232,7 +292,7 @@ func (w *worker) handleDelivery(ctx context.Context, delivery queue.Delivery) er
 	})
 	_ = w.attempts.SaveQueueEvent(ctx, QueueEvent{RunID: task.RunID, Event: "queue.claimed", At: now, Payload: map[string]any{"workerId": w.cfg.WorkerID, "attempt": task.Attempt}})
 
-	if err := w.updateRunStatus(ctx, task, "running", "", nil); err != nil {
+	if err := w.updateRunStatus(ctx, task, state.RunRunning, "", nil); err != nil {
 		_ = w.queue.Ack(ctx, w.cfg.WorkerID, delivery.ID)
 		return err
 	}
@@ -249,7 +309,7 @@ func (w *worker) handleDelivery(ctx context.Context, delivery queue.Delivery) er
 	if runErr == nil {
 		now := time.Now().UTC()
 		_ = w.attempts.FinishAttempt(ctx, task.RunID, task.Attempt, "completed", "")
-		_ = w.updateRunStatus(ctx, task, "completed", result.Output, &now)
+		_ = w.updateRunStatus(ctx, task, state.RunCompleted, result.Output, &now)
 		_ = w.attempts.SaveQueueEvent(ctx, QueueEvent{RunID: task.RunID, Event: "run.completed", At: now, Payload: map[string]any{"workerId": w.cfg.WorkerID, "attempt": task.Attempt}})
 		w.emit(ctx, observe.Event{RunID: task.RunID, SessionID: task.SessionID, Kind: observe.KindRun, Status: observe.StatusCompleted, Name: "run.completed"})
 		return w.queue.Ack(ctx, w.cfg.WorkerID, delivery.ID)
@@ -263,19 +323,35 @@ func (w *worker) handleDelivery(ctx context.Context, delivery queue.Delivery) er
 		backoff := w.policy.Backoff(task.Attempt)
 		_, _ = w.queue.Requeue(ctx, next, errText, backoff)
 		_ = w.attempts.SaveQueueEvent(ctx, QueueEvent{RunID: task.RunID, Event: "queue.retried", At: time.Now().UTC(), Payload: map[string]any{"attempt": next.Attempt, "error": errText}})
-		_ = w.updateRunStatus(ctx, task, "queued", "", nil)
+		_ = w.updateRunStatus(ctx, task, state.RunQueued, "", nil)
 		w.emit(ctx, observe.Event{RunID: task.RunID, SessionID: task.SessionID, Kind: observe.KindCustom, Status: observe.StatusFailed, Name: "queue.retried", Error: errText, Attributes: map[string]any{"attempt": next.Attempt}})
 		return w.queue.Ack(ctx, w.cfg.WorkerID, delivery.ID)
 	}
 
-	_, _ = w.queue.DeadLetter(ctx, delivery, errText)
+	dlq := w.deadLetter(ctx, delivery, errText)
 	finished := time.Now().UTC()
 	_ = w.updateRunStatusFailed(ctx, task, errText, &finished)
-	_ = w.attempts.SaveQueueEvent(ctx, QueueEvent{RunID: task.RunID, Event: "queue.dead_lettered", At: finished, Payload: map[string]any{"attempt": task.Attempt, "error": errText}})
-	w.emit(ctx, observe.Event{RunID: task.RunID, SessionID: task.SessionID, Kind: observe.KindCustom, Status: observe.StatusFailed, Name: "queue.dead_lettered", Error: errText, Attributes: map[string]any{"attempt": task.Attempt}})
+	_ = w.attempts.SaveQueueEvent(ctx, QueueEvent{RunID: task.RunID, Event: "queue.dead_lettered", At: finished, Payload: map[string]any{"attempt": task.Attempt, "error": errText, "dlq": dlq}})
+	w.emit(ctx, observe.Event{RunID: task.RunID, SessionID: task.SessionID, Kind: observe.KindCustom, Status: observe.StatusFailed, Name: "queue.dead_lettered", Error: errText, Attributes: map[string]any{"attempt": task.Attempt, "dlq": dlq}})
+	if w.policy.OnExhausted != nil {
+		w.policy.OnExhausted(ctx, task.RunID, runErr)
+	}
 	return nil
 }
 
+// deadLetter moves delivery to the DLQ routed for its workflow and returns
+// the DLQ name used ("" for the default).
+func (w *worker) deadLetter(ctx context.Context, delivery queue.Delivery, reason string) string {
+	name := strings.TrimSpace(w.policy.DLQRoutes[delivery.Task.Workflow])
+	if router, ok := w.queue.(queue.DLQRouter); ok && name != "" {
+		if _, err := router.DeadLetterTo(ctx, name, delivery, reason); err == nil {
+			return name
+		}
+	}
+	_, _ = w.queue.DeadLetter(ctx, delivery, reason)
+	return ""
+}
+
 func (w *worker) updateRunStatus(ctx context.Context, task queue.Task, status string, output string, completedAt *time.Time) error {
 	run, err := w.store.LoadRun(ctx, task.RunID)
 	if err != nil {
@@ -327,7 +403,7 @@ func (w *worker) updateRunStatusFailed(ctx context.Context, task queue.Task, err
 		return err
 	}
 	now := time.Now().UTC()
-	run.Status = "failed"
+	run.Status = state.RunFailed
 	run.Error = errText
 	run.UpdatedAt = &now
 	run.CompletedAt = completedAt
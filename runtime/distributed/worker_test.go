@@ -180,3 +180,168 @@ func TestWorkerStopCancelsStartLoop(t *testing.T) {
 		t.Fatalf("worker start loop did not exit after Stop")
 	}
 }
+
+type reclaimingQueue struct {
+	singleDeliveryQueue
+	stale    *queue.Delivery
+	minIdle  time.Duration
+	extended int
+}
+
+func (r *reclaimingQueue) Reclaim(ctx context.Context, consumer string, minIdle time.Duration, count int) ([]queue.Delivery, error) {
+	_ = ctx
+	_ = consumer
+	_ = count
+	r.minIdle = minIdle
+	if r.stale == nil {
+		return []queue.Delivery{}, nil
+	}
+	d := *r.stale
+	r.stale = nil
+	r.delivery = &d
+	return []queue.Delivery{d}, nil
+}
+
+func (r *reclaimingQueue) Extend(ctx context.Context, consumer string, messageIDs ...string) error {
+	_ = ctx
+	_ = consumer
+	_ = messageIDs
+	r.extended++
+	return nil
+}
+
+func TestWorkerReclaimsStaleDeliveries(t *testing.T) {
+	store, err := statesqlite.New(t.TempDir() + "/state.db")
+	if err != nil {
+		t.Fatalf("state store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	attempts, err := NewSQLiteAttemptStore(t.TempDir() + "/attempts.db")
+	if err != nil {
+		t.Fatalf("attempt store: %v", err)
+	}
+	defer func() { _ = attempts.Close() }()
+
+	q := &reclaimingQueue{stale: &queue.Delivery{ID: "7-0", Stream: "runs", Task: queue.Task{RunID: "r-stale", SessionID: "s1", Input: "hello", Attempt: 1, MaxAttempts: 1}}}
+	policy := DefaultRuntimePolicy()
+	policy.VisibilityTimeout = 30 * time.Millisecond
+	processed := make(chan string, 1)
+	w, err := NewWorker(WorkerConfig{WorkerID: "w-reclaim"}, store, attempts, q, nil, policy, func(ctx context.Context, task queue.Task) (ProcessResult, error) {
+		_ = ctx
+		time.Sleep(60 * time.Millisecond)
+		processed <- task.RunID
+		return ProcessResult{Output: "ok"}, nil
+	})
+	if err != nil {
+		t.Fatalf("new worker: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_ = w.Start(ctx)
+
+	select {
+	case runID := <-processed:
+		if runID != "r-stale" {
+			t.Fatalf("unexpected run processed: %s", runID)
+		}
+	default:
+		t.Fatalf("reclaimed delivery was not processed")
+	}
+	if q.minIdle != policy.VisibilityTimeout {
+		t.Fatalf("expected reclaim with min idle %v, got %v", policy.VisibilityTimeout, q.minIdle)
+	}
+	if q.extended == 0 {
+		t.Fatalf("expected claim to be extended during processing")
+	}
+	events, err := attempts.ListQueueEvents(context.Background(), "r-stale", 10)
+	if err != nil {
+		t.Fatalf("list queue events: %v", err)
+	}
+	found := false
+	for _, ev := range events {
+		if ev.Event == "queue.reclaimed" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected queue.reclaimed event, got %+v", events)
+	}
+}
+
+type routingQueue struct {
+	singleDeliveryQueue
+	routedTo string
+}
+
+func (r *routingQueue) DeadLetterTo(ctx context.Context, dlq string, delivery queue.Delivery, reason string) (string, error) {
+	_ = ctx
+	_ = delivery
+	_ = reason
+	r.routedTo = dlq
+	r.acked = true
+	return "dlq-critical-1", nil
+}
+
+func (r *routingQueue) ListNamedDLQ(ctx context.Context, dlq string, limit int) ([]queue.Delivery, error) {
+	_ = ctx
+	_ = dlq
+	_ = limit
+	return nil, nil
+}
+
+func TestWorkerExhaustedRoutesDLQAndCallsHook(t *testing.T) {
+	store, err := statesqlite.New(t.TempDir() + "/state.db")
+	if err != nil {
+		t.Fatalf("state store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	attempts, err := NewSQLiteAttemptStore(t.TempDir() + "/attempts.db")
+	if err != nil {
+		t.Fatalf("attempt store: %v", err)
+	}
+	defer func() { _ = attempts.Close() }()
+
+	now := time.Now().UTC()
+	if err := store.SaveRun(context.Background(), state.RunRecord{RunID: "r-fail", SessionID: "s1", Status: "queued", Input: "x", CreatedAt: &now, UpdatedAt: &now}); err != nil {
+		t.Fatalf("seed run: %v", err)
+	}
+
+	q := &routingQueue{singleDeliveryQueue: singleDeliveryQueue{delivery: &queue.Delivery{ID: "1-0", Stream: "runs", Task: queue.Task{RunID: "r-fail", SessionID: "s1", Input: "x", Workflow: "payments", Attempt: 1, MaxAttempts: 1}}}}
+	var exhaustedRun string
+	var exhaustedErr error
+	policy := DefaultRuntimePolicy()
+	policy.DLQRoutes = map[string]string{"payments": "critical"}
+	policy.OnExhausted = func(ctx context.Context, runID string, lastErr error) {
+		_ = ctx
+		exhaustedRun = runID
+		exhaustedErr = lastErr
+	}
+	boom := errors.New("boom")
+	w, err := NewWorker(WorkerConfig{WorkerID: "w-dlq"}, store, attempts, q, nil, policy, func(ctx context.Context, task queue.Task) (ProcessResult, error) {
+		_ = ctx
+		_ = task
+		return ProcessResult{}, boom
+	})
+	if err != nil {
+		t.Fatalf("new worker: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_ = w.Start(ctx)
+
+	if q.routedTo != "critical" {
+		t.Fatalf("expected routing to critical dlq, got %q", q.routedTo)
+	}
+	if exhaustedRun != "r-fail" || !errors.Is(exhaustedErr, boom) {
+		t.Fatalf("unexpected exhausted hook call: run=%q err=%v", exhaustedRun, exhaustedErr)
+	}
+	run, err := store.LoadRun(context.Background(), "r-fail")
+	if err != nil {
+		t.Fatalf("load run: %v", err)
+	}
+	if run.Status != "failed" {
+		t.Fatalf("expected failed, got %s", run.Status)
+	}
+}
@@ -2,9 +2,15 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrLeaseLost is returned by Reclaimer.Extend when a delivery is no longer
+// owned by the calling consumer, e.g. because another consumer reclaimed it
+// or it was already acked.
+var ErrLeaseLost = errors.New("queue: lease lost")
+
 type Task struct {
 	RunID        string         `json:"runId"`
 	SessionID    string         `json:"sessionId"`
@@ -30,8 +36,22 @@ type Delivery struct {
 
 type Stats struct {
 	StreamLength int64 `json:"streamLength"`
-	DLQLength    int64 `json:"dlqLength"`
-	Pending      int64 `json:"pending"`
+	// DLQLength counts entries in every DLQ, named ones included.
+	DLQLength int64 `json:"dlqLength"`
+	// NamedDLQLength maps each named DLQ (see DLQRouter) to its length.
+	NamedDLQLength map[string]int64 `json:"namedDlqLength,omitempty"`
+	Pending        int64            `json:"pending"`
+	// Lag is the number of messages not yet delivered to any consumer. It is
+	// estimated when the backend cannot report it exactly.
+	Lag int64 `json:"lag"`
+	// OldestPendingAge is how long the oldest claimed-but-unacked message
+	// has been in the queue.
+	OldestPendingAge time.Duration `json:"oldestPendingAge"`
+	// OldestMessageAge is the age of the oldest message still in the queue,
+	// delivered or not.
+	OldestMessageAge time.Duration `json:"oldestMessageAge"`
+	// ConsumerPending maps consumer name to its pending (unacked) count.
+	ConsumerPending map[string]int64 `json:"consumerPending,omitempty"`
 }
 
 type Queue interface {
@@ -45,3 +65,22 @@ type Queue interface {
 	Stats(ctx context.Context) (Stats, error)
 	Close() error
 }
+
+// Reclaimer is implemented by queues that can redeliver messages whose
+// consumer claimed them but never acked, e.g. because the worker crashed.
+type Reclaimer interface {
+	// Reclaim transfers up to count deliveries that have been idle for at
+	// least minIdle to consumer.
+	Reclaim(ctx context.Context, consumer string, minIdle time.Duration, count int) ([]Delivery, error)
+	// Extend resets the idle time of deliveries still being processed so
+	// they are not reclaimed by another consumer. Deliveries the consumer
+	// no longer owns are left alone and reported with ErrLeaseLost.
+	Extend(ctx context.Context, consumer string, messageIDs ...string) error
+}
+
+// DLQRouter is implemented by queues that support multiple named dead-letter
+// queues in addition to the default one.
+type DLQRouter interface {
+	DeadLetterTo(ctx context.Context, dlq string, delivery Delivery, reason string) (string, error)
+	ListNamedDLQ(ctx context.Context, dlq string, limit int) ([]Delivery, error)
+}
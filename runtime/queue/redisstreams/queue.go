@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -154,27 +156,137 @@ func (q *Queue) Claim(ctx context.Context, consumer string, block time.Duration,
 	}
 	out := make([]queue.Delivery, 0, count)
 	for _, stream := range res {
-		for _, msg := range stream.Messages {
-			payload, _ := msg.Values["payload"].(string)
-			if payload == "" {
-				continue
-			}
-			var task queue.Task
-			if err := json.Unmarshal([]byte(payload), &task); err != nil {
-				_ = q.client.XAck(ctx, q.runStream, q.group, msg.ID).Err()
-				continue
+		out = append(out, q.decodeDeliveries(ctx, stream.Stream, stream.Messages)...)
+	}
+	return out, nil
+}
+
+// Reclaim uses XAUTOCLAIM to take over messages that another consumer
+// claimed but has not acked within minIdle.
+func (q *Queue) Reclaim(ctx context.Context, consumer string, minIdle time.Duration, count int) ([]queue.Delivery, error) {
+	if strings.TrimSpace(consumer) == "" {
+		return nil, fmt.Errorf("consumer is required")
+	}
+	if minIdle <= 0 {
+		return []queue.Delivery{}, nil
+	}
+	if count <= 0 {
+		count = 1
+	}
+	msgs, _, err := q.client.XAutoClaim(ctx, &goredis.XAutoClaimArgs{
+		Stream:   q.runStream,
+		Group:    q.group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Count:    int64(count),
+	}).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return []queue.Delivery{}, nil
+		}
+		return nil, fmt.Errorf("failed to reclaim tasks: %w", err)
+	}
+	deliveries := q.decodeDeliveries(ctx, q.runStream, msgs)
+	out := make([]queue.Delivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		// Every delivery after the first one is a reclaim of an attempt that
+		// never finished, so it counts against MaxAttempts.
+		if p, ok, _ := q.pendingEntry(ctx, d.ID); ok && p.RetryCount > 1 {
+			d.Task.Attempt += int(p.RetryCount) - 1
+		}
+		if d.Task.MaxAttempts > 0 && d.Task.Attempt > d.Task.MaxAttempts {
+			reason := fmt.Sprintf("exceeded %d attempts after reclaim", d.Task.MaxAttempts)
+			if _, err := q.deadLetter(ctx, q.dlqStream, d, reason); err != nil {
+				return nil, err
 			}
-			out = append(out, queue.Delivery{
-				ID:       msg.ID,
-				Stream:   stream.Stream,
-				Task:     task,
-				Received: time.Now().UTC(),
-			})
+			continue
 		}
+		out = append(out, d)
 	}
 	return out, nil
 }
 
+// Extend re-claims the given messages for consumer, resetting their idle time.
+// Messages now pending for another consumer, or no longer pending at all, are
+// not touched and are reported with queue.ErrLeaseLost.
+func (q *Queue) Extend(ctx context.Context, consumer string, messageIDs ...string) error {
+	if strings.TrimSpace(consumer) == "" || len(messageIDs) == 0 {
+		return nil
+	}
+	var lost []string
+	for _, id := range messageIDs {
+		p, ok, err := q.pendingEntry(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !ok || p.Consumer != consumer {
+			lost = append(lost, id)
+			continue
+		}
+		// Using the observed idle time as MinIdle makes the claim a no-op if
+		// another consumer reclaimed the message since XPENDING ran.
+		claimed, err := q.client.XClaimJustID(ctx, &goredis.XClaimArgs{
+			Stream:   q.runStream,
+			Group:    q.group,
+			Consumer: consumer,
+			MinIdle:  p.Idle,
+			Messages: []string{id},
+		}).Result()
+		if err != nil && err != goredis.Nil {
+			return fmt.Errorf("failed to extend claim: %w", err)
+		}
+		if len(claimed) == 0 {
+			lost = append(lost, id)
+		}
+	}
+	if len(lost) > 0 {
+		return fmt.Errorf("%w: %s", queue.ErrLeaseLost, strings.Join(lost, ", "))
+	}
+	return nil
+}
+
+// pendingEntry returns the pending entry for a run stream message, reporting
+// false if it is not pending.
+func (q *Queue) pendingEntry(ctx context.Context, id string) (goredis.XPendingExt, bool, error) {
+	entries, err := q.client.XPendingExt(ctx, &goredis.XPendingExtArgs{
+		Stream: q.runStream,
+		Group:  q.group,
+		Start:  id,
+		End:    id,
+		Count:  1,
+	}).Result()
+	if err != nil && err != goredis.Nil {
+		return goredis.XPendingExt{}, false, fmt.Errorf("failed to read pending entry: %w", err)
+	}
+	if len(entries) == 0 {
+		return goredis.XPendingExt{}, false, nil
+	}
+	return entries[0], true, nil
+}
+
+func (q *Queue) decodeDeliveries(ctx context.Context, stream string, msgs []goredis.XMessage) []queue.Delivery {
+	out := make([]queue.Delivery, 0, len(msgs))
+	for _, msg := range msgs {
+		payload, _ := msg.Values["payload"].(string)
+		if payload == "" {
+			continue
+		}
+		var task queue.Task
+		if err := json.Unmarshal([]byte(payload), &task); err != nil {
+			_ = q.client.XAck(ctx, q.runStream, q.group, msg.ID).Err()
+			continue
+		}
+		out = append(out, queue.Delivery{
+			ID:       msg.ID,
+			Stream:   stream,
+			Task:     task,
+			Received: time.Now().UTC(),
+		})
+	}
+	return out
+}
+
 func (q *Queue) Ack(ctx context.Context, consumer string, messageIDs ...string) error {
 	_ = consumer
 	if len(messageIDs) == 0 {
@@ -230,6 +342,22 @@ func (q *Queue) Requeue(ctx context.Context, task queue.Task, reason string, del
 }
 
 func (q *Queue) DeadLetter(ctx context.Context, delivery queue.Delivery, reason string) (string, error) {
+	return q.deadLetter(ctx, q.dlqStream, delivery, reason)
+}
+
+// DeadLetterTo moves delivery to the named DLQ stream "<prefix>:runs:dlq:<dlq>".
+// An empty name uses the default DLQ. Named DLQs are recorded so Stats and
+// RequeueDLQByID can find them.
+func (q *Queue) DeadLetterTo(ctx context.Context, dlq string, delivery queue.Delivery, reason string) (string, error) {
+	if dlq = strings.TrimSpace(dlq); dlq != "" {
+		if err := q.client.SAdd(ctx, q.dlqNamesKey(), dlq).Err(); err != nil {
+			return "", fmt.Errorf("failed to record dlq %q: %w", dlq, err)
+		}
+	}
+	return q.deadLetter(ctx, q.namedDLQStream(dlq), delivery, reason)
+}
+
+func (q *Queue) deadLetter(ctx context.Context, stream string, delivery queue.Delivery, reason string) (string, error) {
 	if delivery.Task.Metadata == nil {
 		delivery.Task.Metadata = map[string]any{}
 	}
@@ -239,7 +367,7 @@ func (q *Queue) DeadLetter(ctx context.Context, delivery queue.Delivery, reason
 		return "", fmt.Errorf("failed to marshal dead letter task: %w", err)
 	}
 	id, err := q.client.XAdd(ctx, &goredis.XAddArgs{
-		Stream: q.dlqStream,
+		Stream: stream,
 		Values: map[string]any{
 			"payload":   string(payload),
 			"source_id": delivery.ID,
@@ -254,10 +382,18 @@ func (q *Queue) DeadLetter(ctx context.Context, delivery queue.Delivery, reason
 }
 
 func (q *Queue) ListDLQ(ctx context.Context, limit int) ([]queue.Delivery, error) {
+	return q.listDLQ(ctx, q.dlqStream, limit)
+}
+
+func (q *Queue) ListNamedDLQ(ctx context.Context, dlq string, limit int) ([]queue.Delivery, error) {
+	return q.listDLQ(ctx, q.namedDLQStream(dlq), limit)
+}
+
+func (q *Queue) listDLQ(ctx context.Context, stream string, limit int) ([]queue.Delivery, error) {
 	if limit <= 0 {
 		limit = 50
 	}
-	entries, err := q.client.XRevRangeN(ctx, q.dlqStream, "+", "-", int64(limit)).Result()
+	entries, err := q.client.XRevRangeN(ctx, stream, "+", "-", int64(limit)).Result()
 	if err != nil {
 		if err == goredis.Nil {
 			return []queue.Delivery{}, nil
@@ -274,11 +410,33 @@ func (q *Queue) ListDLQ(ctx context.Context, limit int) ([]queue.Delivery, error
 		if err := json.Unmarshal([]byte(payload), &task); err != nil {
 			continue
 		}
-		out = append(out, queue.Delivery{ID: entry.ID, Stream: q.dlqStream, Task: task, Received: time.Now().UTC()})
+		out = append(out, queue.Delivery{ID: entry.ID, Stream: stream, Task: task, Received: time.Now().UTC()})
 	}
 	return out, nil
 }
 
+// namedDLQs returns the names of the DLQs written by DeadLetterTo.
+func (q *Queue) namedDLQs(ctx context.Context) ([]string, error) {
+	names, err := q.client.SMembers(ctx, q.dlqNamesKey()).Result()
+	if err != nil && err != goredis.Nil {
+		return nil, fmt.Errorf("failed to list named dlqs: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (q *Queue) dlqNamesKey() string {
+	return q.dlqStream + ":names"
+}
+
+func (q *Queue) namedDLQStream(dlq string) string {
+	dlq = strings.TrimSpace(dlq)
+	if dlq == "" {
+		return q.dlqStream
+	}
+	return q.dlqStream + ":" + dlq
+}
+
 func (q *Queue) Stats(ctx context.Context) (queue.Stats, error) {
 	runLen, err := q.client.XLen(ctx, q.runStream).Result()
 	if err != nil && err != goredis.Nil {
@@ -288,22 +446,106 @@ func (q *Queue) Stats(ctx context.Context) (queue.Stats, error) {
 	if err != nil && err != goredis.Nil {
 		return queue.Stats{}, fmt.Errorf("failed to read dlq length: %w", err)
 	}
-	pending := int64(0)
+	stats := queue.Stats{StreamLength: runLen, DLQLength: dlqLen}
+	names, err := q.namedDLQs(ctx)
+	if err != nil {
+		return queue.Stats{}, err
+	}
+	for _, name := range names {
+		n, err := q.client.XLen(ctx, q.namedDLQStream(name)).Result()
+		if err != nil && err != goredis.Nil {
+			return queue.Stats{}, fmt.Errorf("failed to read dlq %q length: %w", name, err)
+		}
+		if stats.NamedDLQLength == nil {
+			stats.NamedDLQLength = map[string]int64{}
+		}
+		stats.NamedDLQLength[name] = n
+		stats.DLQLength += n
+	}
+	now := time.Now().UTC()
 	pendingRes, err := q.client.XPending(ctx, q.runStream, q.group).Result()
 	if err == nil {
-		pending = pendingRes.Count
+		stats.Pending = pendingRes.Count
+		if pendingRes.Count > 0 {
+			stats.ConsumerPending = pendingRes.Consumers
+			if ts, ok := streamIDTime(pendingRes.Lower); ok {
+				stats.OldestPendingAge = ageSince(now, ts)
+			}
+		}
+	}
+	if oldest, err := q.client.XRangeN(ctx, q.runStream, "-", "+", 1).Result(); err == nil && len(oldest) > 0 {
+		if ts, ok := streamIDTime(oldest[0].ID); ok {
+			stats.OldestMessageAge = ageSince(now, ts)
+		}
+	}
+	stats.Lag = -1
+	if groups, err := q.client.XInfoGroups(ctx, q.runStream).Result(); err == nil {
+		for _, g := range groups {
+			// Before Redis 7.0 XINFO GROUPS has neither lag nor entries-read,
+			// so a zero lag with nothing read means the lag is unknown. Redis
+			// 7 reports it as nil (-1) once entries have been deleted.
+			if g.Name == q.group && g.Lag >= 0 && (g.Lag > 0 || g.EntriesRead > 0) {
+				stats.Lag = g.Lag
+				break
+			}
+		}
 	}
-	return queue.Stats{StreamLength: runLen, DLQLength: dlqLen, Pending: pending}, nil
+	if stats.Lag < 0 {
+		// Acked messages are deleted, so whatever is in the stream and not
+		// pending has not been delivered yet.
+		stats.Lag = runLen - stats.Pending
+		if stats.Lag < 0 {
+			stats.Lag = 0
+		}
+	}
+	return stats, nil
+}
+
+// streamIDTime extracts the millisecond timestamp from a stream entry ID
+// ("<ms>-<seq>").
+func streamIDTime(id string) (time.Time, bool) {
+	msPart, _, _ := strings.Cut(strings.TrimSpace(id), "-")
+	ms, err := strconv.ParseInt(msPart, 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms).UTC(), true
+}
+
+func ageSince(now, ts time.Time) time.Duration {
+	if d := now.Sub(ts); d > 0 {
+		return d
+	}
+	return 0
 }
 
+// RequeueDLQByID moves the entry with the given ID from the default DLQ or
+// any named DLQ back onto the run stream.
 func (q *Queue) RequeueDLQByID(ctx context.Context, id string, resetAttempt bool) (string, error) {
 	id = strings.TrimSpace(id)
 	if id == "" {
 		return "", fmt.Errorf("id is required")
 	}
-	entries, err := q.client.XRangeN(ctx, q.dlqStream, id, id, 1).Result()
+	names, err := q.namedDLQs(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to load dlq entry: %w", err)
+		return "", err
+	}
+	streams := []string{q.dlqStream}
+	for _, name := range names {
+		streams = append(streams, q.namedDLQStream(name))
+	}
+	var (
+		entries []goredis.XMessage
+		stream  string
+	)
+	for _, stream = range streams {
+		entries, err = q.client.XRangeN(ctx, stream, id, id, 1).Result()
+		if err != nil {
+			return "", fmt.Errorf("failed to load dlq entry: %w", err)
+		}
+		if len(entries) > 0 {
+			break
+		}
 	}
 	if len(entries) == 0 {
 		return "", fmt.Errorf("dlq entry %q not found", id)
@@ -326,7 +568,7 @@ func (q *Queue) RequeueDLQByID(ctx context.Context, id string, resetAttempt bool
 	if err != nil {
 		return "", err
 	}
-	_ = q.client.XDel(ctx, q.dlqStream, id).Err()
+	_ = q.client.XDel(ctx, stream, id).Err()
 	return newID, nil
 }
 
@@ -344,4 +586,8 @@ func max(a, b int) int {
 	return b
 }
 
-var _ queue.Queue = (*Queue)(nil)
+var (
+	_ queue.Queue     = (*Queue)(nil)
+	_ queue.Reclaimer = (*Queue)(nil)
+	_ queue.DLQRouter = (*Queue)(nil)
+)
@@ -2,6 +2,7 @@ package redisstreams
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -23,7 +24,13 @@ func newTestQueue(t *testing.T) *Queue {
 	}
 	t.Cleanup(func() {
 		ctx := context.Background()
-		_ = q.client.Del(ctx, q.runStream, q.dlqStream).Err()
+		keys := []string{q.runStream, q.dlqStream, q.dlqNamesKey()}
+		if names, err := q.namedDLQs(ctx); err == nil {
+			for _, name := range names {
+				keys = append(keys, q.namedDLQStream(name))
+			}
+		}
+		_ = q.client.Del(ctx, keys...).Err()
 		_ = q.Close()
 	})
 	return q
@@ -81,3 +88,135 @@ func TestQueue_DeadLetterAndList(t *testing.T) {
 		t.Fatalf("expected dlq entries")
 	}
 }
+
+func TestQueue_NamedDLQStatsAndRequeue(t *testing.T) {
+	q := newTestQueue(t)
+	ctx := context.Background()
+	if _, err := q.Enqueue(ctx, queue.Task{RunID: "r5", SessionID: "s5", Input: "x", Attempt: 3, MaxAttempts: 3}); err != nil {
+		t.Fatalf("enqueue failed: %v", err)
+	}
+	deliveries, err := q.Claim(ctx, "worker-5", 500*time.Millisecond, 1)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("claim = %v, %v; want one delivery", deliveries, err)
+	}
+	if _, err := q.DeadLetterTo(ctx, "critical", deliveries[0], "failed"); err != nil {
+		t.Fatalf("deadletter failed: %v", err)
+	}
+
+	stats, err := q.Stats(ctx)
+	if err != nil {
+		t.Fatalf("stats failed: %v", err)
+	}
+	if stats.NamedDLQLength["critical"] != 1 || stats.DLQLength != 1 {
+		t.Fatalf("unexpected dlq stats: total=%d named=%v", stats.DLQLength, stats.NamedDLQLength)
+	}
+
+	dlq, err := q.ListNamedDLQ(ctx, "critical", 10)
+	if err != nil || len(dlq) != 1 {
+		t.Fatalf("list named dlq = %v, %v; want one entry", dlq, err)
+	}
+	if _, err := q.RequeueDLQByID(ctx, dlq[0].ID, true); err != nil {
+		t.Fatalf("requeue from named dlq failed: %v", err)
+	}
+	if stats, _ := q.Stats(ctx); stats.NamedDLQLength["critical"] != 0 {
+		t.Fatalf("named dlq still has entries: %v", stats.NamedDLQLength)
+	}
+}
+
+func TestQueue_StatsReportsPendingAge(t *testing.T) {
+	q := newTestQueue(t)
+	ctx := context.Background()
+	for _, runID := range []string{"r3", "r4"} {
+		if _, err := q.Enqueue(ctx, queue.Task{RunID: runID, SessionID: "s3", Input: "x"}); err != nil {
+			t.Fatalf("enqueue failed: %v", err)
+		}
+	}
+	if _, err := q.Claim(ctx, "worker-3", 500*time.Millisecond, 1); err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	stats, err := q.Stats(ctx)
+	if err != nil {
+		t.Fatalf("stats failed: %v", err)
+	}
+	if stats.Pending != 1 || stats.ConsumerPending["worker-3"] != 1 {
+		t.Fatalf("unexpected pending stats: %+v", stats)
+	}
+	if stats.Lag != 1 {
+		t.Fatalf("expected lag 1, got %d", stats.Lag)
+	}
+	if stats.OldestPendingAge <= 0 || stats.OldestMessageAge < stats.OldestPendingAge {
+		t.Fatalf("unexpected ages: pending=%s message=%s", stats.OldestPendingAge, stats.OldestMessageAge)
+	}
+}
+
+func TestQueue_ExtendReportsLostLease(t *testing.T) {
+	q := newTestQueue(t)
+	ctx := context.Background()
+	if _, err := q.Enqueue(ctx, queue.Task{RunID: "r5", SessionID: "s5", Input: "x"}); err != nil {
+		t.Fatalf("enqueue failed: %v", err)
+	}
+	claimed, err := q.Claim(ctx, "worker-a", 500*time.Millisecond, 1)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("claim failed: %v (%d deliveries)", err, len(claimed))
+	}
+	if err := q.Extend(ctx, "worker-a", claimed[0].ID); err != nil {
+		t.Fatalf("extend by owner failed: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	reclaimed, err := q.Reclaim(ctx, "worker-b", 10*time.Millisecond, 1)
+	if err != nil || len(reclaimed) != 1 {
+		t.Fatalf("reclaim failed: %v (%d deliveries)", err, len(reclaimed))
+	}
+	if err := q.Extend(ctx, "worker-a", claimed[0].ID); !errors.Is(err, queue.ErrLeaseLost) {
+		t.Fatalf("expected ErrLeaseLost for the previous owner, got %v", err)
+	}
+	p, ok, err := q.pendingEntry(ctx, claimed[0].ID)
+	if err != nil || !ok || p.Consumer != "worker-b" {
+		t.Fatalf("expected worker-b to keep the message, got %+v ok=%v err=%v", p, ok, err)
+	}
+}
+
+func TestQueue_ReclaimCountsAttempts(t *testing.T) {
+	q := newTestQueue(t)
+	ctx := context.Background()
+	if _, err := q.Enqueue(ctx, queue.Task{RunID: "r6", SessionID: "s6", Input: "x", MaxAttempts: 2}); err != nil {
+		t.Fatalf("enqueue failed: %v", err)
+	}
+	if _, err := q.Claim(ctx, "worker-a", 500*time.Millisecond, 1); err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	reclaimed, err := q.Reclaim(ctx, "worker-b", 10*time.Millisecond, 1)
+	if err != nil || len(reclaimed) != 1 {
+		t.Fatalf("reclaim failed: %v (%d deliveries)", err, len(reclaimed))
+	}
+	if reclaimed[0].Task.Attempt != 2 {
+		t.Fatalf("expected reclaimed attempt 2, got %d", reclaimed[0].Task.Attempt)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	reclaimed, err = q.Reclaim(ctx, "worker-c", 10*time.Millisecond, 1)
+	if err != nil || len(reclaimed) != 0 {
+		t.Fatalf("expected the exhausted message to be dead-lettered, got %v (%d deliveries)", err, len(reclaimed))
+	}
+	dlq, err := q.ListDLQ(ctx, 10)
+	if err != nil || len(dlq) != 1 || dlq[0].Task.RunID != "r6" {
+		t.Fatalf("expected r6 in the dlq, got %v (%+v)", err, dlq)
+	}
+	stats, err := q.Stats(ctx)
+	if err != nil || stats.Pending != 0 {
+		t.Fatalf("expected no pending messages, got %v (%+v)", err, stats)
+	}
+}
+
+func TestStreamIDTime(t *testing.T) {
+	ts, ok := streamIDTime("1700000000123-4")
+	if !ok || ts.UnixMilli() != 1700000000123 {
+		t.Fatalf("unexpected parse: %v %v", ts, ok)
+	}
+	if _, ok := streamIDTime("bogus"); ok {
+		t.Fatalf("expected parse failure")
+	}
+}
@@ -1,11 +1,46 @@
 package skill
 
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
 // RegisterBuiltins registers the built-in skills.
 // Silently skips any skill name already registered.
 func RegisterBuiltins() {
 	for _, s := range builtinSkills {
-		_ = Register(s)
+		_ = Register(s.clone())
+	}
+}
+
+// BuiltinSkills returns copies of the built-in skills, so callers can
+// inspect them or base a customized skill on one without affecting the
+// originals.
+func BuiltinSkills() []*Skill {
+	out := make([]*Skill, 0, len(builtinSkills))
+	for _, s := range builtinSkills {
+		out = append(out, s.clone())
 	}
+	return out
+}
+
+// RegisterBuiltin registers a copy of the named built-in skill.
+func RegisterBuiltin(name string) error {
+	for _, s := range builtinSkills {
+		if s.Name == name {
+			return Register(s.clone())
+		}
+	}
+	return fmt.Errorf("unknown built-in skill %q", name)
+}
+
+func (s *Skill) clone() *Skill {
+	c := *s
+	c.AllowedTools = slices.Clone(s.AllowedTools)
+	c.Requires = slices.Clone(s.Requires)
+	c.Metadata = maps.Clone(s.Metadata)
+	return &c
 }
 
 var builtinSkills = []*Skill{
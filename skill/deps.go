@@ -0,0 +1,78 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrMissingDependency is returned when a required skill is not registered.
+	ErrMissingDependency = errors.New("skill: missing dependency")
+	// ErrDependencyCycle is returned when skills require each other.
+	ErrDependencyCycle = errors.New("skill: dependency cycle")
+)
+
+// ResolveDependencies returns the named skills plus everything they
+// transitively require, ordered so each skill comes after its
+// dependencies. Requested order is kept otherwise, and each skill appears
+// once.
+func ResolveDependencies(names []string) ([]*Skill, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var (
+		out   []*Skill
+		stack []string
+	)
+	var visit func(name, requiredBy string) error
+	visit = func(name, requiredBy string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for i, n := range stack {
+				if n == name {
+					start = i
+				}
+			}
+			cycle := append(append([]string{}, stack[start:]...), name)
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+		s, ok := skills[name]
+		if !ok {
+			if requiredBy == "" {
+				return fmt.Errorf("%w: skill %q not found", ErrMissingDependency, name)
+			}
+			return fmt.Errorf("%w: %q required by %q", ErrMissingDependency, name, requiredBy)
+		}
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range s.Requires {
+			if err := visit(dep, name); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		out = append(out, s)
+		return nil
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if err := visit(name, ""); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,92 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+)
+
+// LoadFromFS is LoadFromDir over an fs.FS, so skills embedded with
+// //go:embed register at startup without shipping a skills directory:
+//
+//	//go:embed skills
+//	var bundled embed.FS
+//
+//	n, err := skill.LoadFromFS(bundled, "skills")
+//
+// Loaded skills have no Path; their Source is "embed:<dir>".
+func LoadFromFS(fsys fs.FS, root string, opts ...LoadOption) (int, error) {
+	o := newLoadOptions(opts)
+	if root == "" {
+		root = "."
+	}
+	info, err := fs.Stat(fsys, root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil // directory doesn't exist, skip silently
+		}
+		return 0, fmt.Errorf("failed to stat skills directory %q: %w", root, err)
+	}
+	if !info.IsDir() {
+		return 0, fmt.Errorf("%q is not a directory", root)
+	}
+
+	entries, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read skills directory %q: %w", root, err)
+	}
+
+	loaded := 0
+	var conflicts []error
+	load := func(file string) {
+		ok, err := loadSkillFS(fsys, file, o.conflict)
+		switch {
+		case errors.Is(err, ErrSkillConflict):
+			conflicts = append(conflicts, err)
+		case err != nil:
+			log.Printf("⚠️  Failed to load skill from %s: %v", file, err)
+		case ok:
+			loaded++
+		}
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			if entry.Name() == skillFileName {
+				load(path.Join(root, skillFileName))
+			}
+			continue
+		}
+
+		skillPath := path.Join(root, entry.Name(), skillFileName)
+		if _, err := fs.Stat(fsys, skillPath); err != nil {
+			if entry.Name() == ".curated" || entry.Name() == ".experimental" || entry.Name() == ".system" {
+				subLoaded, subErr := LoadFromFS(fsys, path.Join(root, entry.Name()), opts...)
+				if errors.Is(subErr, ErrSkillConflict) {
+					conflicts = append(conflicts, subErr)
+				} else if subErr != nil {
+					log.Printf("⚠️  Failed to scan %s: %v", path.Join(root, entry.Name()), subErr)
+				}
+				loaded += subLoaded
+			}
+			continue
+		}
+		load(skillPath)
+	}
+
+	return loaded, errors.Join(conflicts...)
+}
+
+func loadSkillFS(fsys fs.FS, file string, policy ConflictPolicy) (bool, error) {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read skill file %q: %w", file, err)
+	}
+	s, err := Parse(string(data))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse skill file %q: %w", file, err)
+	}
+	s.Source = "embed:" + path.Dir(file)
+	return registerLoaded(s, policy)
+}
@@ -1,6 +1,8 @@
 package skill
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,17 +10,140 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
-var httpClient = &http.Client{Timeout: 30 * time.Second}
+const (
+	defaultInstallTimeout = 30 * time.Second
+	// defaultDiscoveryConcurrency bounds parallel directory listings while
+	// scanning a repository for skill collections.
+	defaultDiscoveryConcurrency = 8
+)
+
+// InstallOption configures InstallFromGitHub.
+type InstallOption func(*installer)
+
+// WithHTTPClient sets the HTTP client used to reach GitHub. Use it to
+// configure proxies, custom CA pools, or timeouts.
+func WithHTTPClient(h *http.Client) InstallOption {
+	return func(in *installer) {
+		if h != nil {
+			in.client = h
+			in.ownsTransport = false
+		}
+	}
+}
+
+// WithProxy routes GitHub requests through the given proxy URL.
+func WithProxy(proxyURL *url.URL) InstallOption {
+	return func(in *installer) {
+		if proxyURL != nil {
+			in.transport().Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+}
+
+// WithTLSConfig sets the TLS configuration (e.g. a custom RootCAs pool)
+// used for GitHub requests.
+func WithTLSConfig(cfg *tls.Config) InstallOption {
+	return func(in *installer) {
+		if cfg != nil {
+			in.transport().TLSClientConfig = cfg
+		}
+	}
+}
+
+// WithDiscoveryConcurrency sets how many GitHub directory listings may run
+// at once while scanning a repository for skill collections. Values below 1
+// are treated as 1.
+func WithDiscoveryConcurrency(n int) InstallOption {
+	return func(in *installer) {
+		if n < 1 {
+			n = 1
+		}
+		in.discoveryConcurrency = n
+	}
+}
+
+// InstallStage identifies the kind of InstallProgress event.
+type InstallStage string
+
+const (
+	InstallStageDiscovered InstallStage = "discovered"
+	InstallStageInstalled  InstallStage = "installed"
+	InstallStageFailed     InstallStage = "failed"
+)
+
+// InstallProgress is reported for each skill as InstallFromGitHub discovers,
+// installs, or fails to install it.
+type InstallProgress struct {
+	Stage     InstallStage `json:"stage"`
+	Skill     string       `json:"skill,omitempty"`
+	RepoPath  string       `json:"repoPath"`
+	Installed int          `json:"installed"`
+	Err       error        `json:"-"`
+}
+
+// WithProgress registers a callback invoked synchronously for every
+// InstallProgress event.
+func WithProgress(fn func(event InstallProgress)) InstallOption {
+	return func(in *installer) { in.progress = fn }
+}
+
+type installer struct {
+	client               *http.Client
+	ownsTransport        bool
+	progress             func(InstallProgress)
+	installed            int
+	discoveryConcurrency int
+}
+
+func newInstaller(opts ...InstallOption) *installer {
+	in := &installer{
+		client:               &http.Client{Timeout: defaultInstallTimeout},
+		discoveryConcurrency: defaultDiscoveryConcurrency,
+	}
+	for _, opt := range opts {
+		opt(in)
+	}
+	return in
+}
+
+// transport returns a mutable *http.Transport for the installer's client,
+// cloning on first use so caller-supplied and global transports are never modified.
+func (in *installer) transport() *http.Transport {
+	if in.ownsTransport {
+		return in.client.Transport.(*http.Transport)
+	}
+	base, ok := in.client.Transport.(*http.Transport)
+	if !ok {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+	t := base.Clone()
+	in.ownsTransport = true
+	c := *in.client
+	c.Transport = t
+	in.client = &c
+	return t
+}
 
 // InstallFromGitHub downloads a skill from a GitHub repository and saves it locally.
 // repoRef can be: "owner/repo/path/to/skill" or "owner/repo" (installs all skills).
 // destDir is the local directory to save into (e.g., "./skills").
+// Every request is bound to ctx; cancelling it or reaching its deadline aborts
+// the install and returns ctx.Err() along with the number installed so far.
 // Returns the number of skills installed.
-func InstallFromGitHub(repoRef string, destDir string) (int, error) {
+func InstallFromGitHub(ctx context.Context, repoRef string, destDir string, opts ...InstallOption) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return newInstaller(opts...).install(ctx, repoRef, destDir)
+}
+
+func (in *installer) install(ctx context.Context, repoRef string, destDir string) (int, error) {
 	owner, repo, skillPath, err := parseGitHubRef(repoRef)
 	if err != nil {
 		return 0, err
@@ -31,13 +156,13 @@ func InstallFromGitHub(repoRef string, destDir string) (int, error) {
 	// If a specific skill path is given, install just that one
 	if skillPath != "" {
 		if isSkillCollectionPath(skillPath) {
-			return installAllSkillsFrom(owner, repo, skillPath, destDir)
+			return in.installAllSkillsFrom(ctx, owner, repo, skillPath, destDir)
 		}
-		return installSingleSkill(owner, repo, skillPath, destDir)
+		return in.installSingleSkill(ctx, owner, repo, skillPath, destDir)
 	}
 
 	// Otherwise, list the skills directory and install all
-	return installAllSkills(owner, repo, destDir)
+	return in.installAllSkills(ctx, owner, repo, destDir)
 }
 
 func parseGitHubRef(repoRef string) (owner, repo, skillPath string, err error) {
@@ -85,20 +210,43 @@ func parseGitHubRef(repoRef string) (owner, repo, skillPath string, err error) {
 	return owner, repo, skillPath, nil
 }
 
-func installSingleSkill(owner, repo, skillPath, destDir string) (int, error) {
+func (in *installer) emit(stage InstallStage, name, repoPath string, err error) {
+	if in.progress == nil {
+		return
+	}
+	in.progress(InstallProgress{Stage: stage, Skill: name, RepoPath: repoPath, Installed: in.installed, Err: err})
+}
+
+func (in *installer) installSingleSkill(ctx context.Context, owner, repo, skillPath, destDir string) (int, error) {
 	// Try to fetch SKILL.md from the path
 	skillMDPath := skillPath + "/SKILL.md"
-	content, err := fetchGitHubFile(owner, repo, skillMDPath)
+	content, err := in.fetchGitHubFile(ctx, owner, repo, skillMDPath)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch %s from %s/%s: %w", skillMDPath, owner, repo, err)
+		err = fmt.Errorf("failed to fetch %s from %s/%s: %w", skillMDPath, owner, repo, err)
+		in.emit(InstallStageFailed, "", skillPath, err)
+		return 0, err
 	}
 
 	// Parse to get the skill name
 	s, err := Parse(content)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse skill from %s/%s/%s: %w", owner, repo, skillPath, err)
+		err = fmt.Errorf("failed to parse skill from %s/%s/%s: %w", owner, repo, skillPath, err)
+		in.emit(InstallStageFailed, "", skillPath, err)
+		return 0, err
 	}
+	in.emit(InstallStageDiscovered, s.Name, skillPath, nil)
+
+	n, err := saveInstalledSkill(s, content, owner, repo, destDir)
+	if err != nil {
+		in.emit(InstallStageFailed, s.Name, skillPath, err)
+		return 0, err
+	}
+	in.installed += n
+	in.emit(InstallStageInstalled, s.Name, skillPath, nil)
+	return n, nil
+}
 
+func saveInstalledSkill(s *Skill, content, owner, repo, destDir string) (int, error) {
 	// Save locally
 	localDir := filepath.Join(destDir, s.Name)
 	if err := os.MkdirAll(localDir, 0755); err != nil {
@@ -122,11 +270,11 @@ func installSingleSkill(owner, repo, skillPath, destDir string) (int, error) {
 	return 1, nil
 }
 
-func installAllSkills(owner, repo, destDir string) (int, error) {
-	return installAllSkillsFrom(owner, repo, "", destDir)
+func (in *installer) installAllSkills(ctx context.Context, owner, repo, destDir string) (int, error) {
+	return in.installAllSkillsFrom(ctx, owner, repo, "", destDir)
 }
 
-func installAllSkillsFrom(owner, repo, basePath, destDir string) (int, error) {
+func (in *installer) installAllSkillsFrom(ctx context.Context, owner, repo, basePath, destDir string) (int, error) {
 	// List contents of the "skills" directory in the repo
 	searchPaths := []string{"skills", "skills/.curated", "skills/.experimental"}
 	if bp := strings.Trim(strings.TrimSpace(basePath), "/"); bp != "" {
@@ -135,8 +283,11 @@ func installAllSkillsFrom(owner, repo, basePath, destDir string) (int, error) {
 	installed := 0
 
 	for _, searchPath := range searchPaths {
-		entries, err := listGitHubDir(owner, repo, searchPath)
+		entries, err := in.listGitHubDir(ctx, owner, repo, searchPath)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return installed, ctxErr
+			}
 			continue // directory might not exist
 		}
 
@@ -144,8 +295,11 @@ func installAllSkillsFrom(owner, repo, basePath, destDir string) (int, error) {
 			if entry.Type != "dir" {
 				continue
 			}
-			n, err := installSingleSkill(owner, repo, searchPath+"/"+entry.Name, destDir)
+			n, err := in.installSingleSkill(ctx, owner, repo, searchPath+"/"+entry.Name, destDir)
 			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return installed, ctxErr
+				}
 				continue // skip skills that fail to install
 			}
 			installed += n
@@ -153,9 +307,11 @@ func installAllSkillsFrom(owner, repo, basePath, destDir string) (int, error) {
 	}
 
 	if installed == 0 {
-		fallback, err := installFromRootDirs(owner, repo, basePath, destDir)
+		fallback, err := in.installFromRootDirs(ctx, owner, repo, basePath, destDir)
 		if err == nil {
 			installed += fallback
+		} else if ctxErr := ctx.Err(); ctxErr != nil {
+			return installed, ctxErr
 		}
 	}
 
@@ -168,9 +324,9 @@ func installAllSkillsFrom(owner, repo, basePath, destDir string) (int, error) {
 	return installed, nil
 }
 
-func installFromRootDirs(owner, repo, basePath, destDir string) (int, error) {
+func (in *installer) installFromRootDirs(ctx context.Context, owner, repo, basePath, destDir string) (int, error) {
 	root := strings.Trim(strings.TrimSpace(basePath), "/")
-	skillDirs, err := discoverSkillCollectionPaths(owner, repo, root, 5)
+	skillDirs, err := in.discoverSkillCollectionPaths(ctx, owner, repo, root, 5)
 	if err != nil {
 		return 0, err
 	}
@@ -182,8 +338,11 @@ func installFromRootDirs(owner, repo, basePath, destDir string) (int, error) {
 			continue
 		}
 		seen[dir] = true
-		n, installErr := installAllSkillsFrom(owner, repo, dir, destDir)
+		n, installErr := in.installAllSkillsFrom(ctx, owner, repo, dir, destDir)
 		if installErr != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return installed, ctxErr
+			}
 			continue
 		}
 		installed += n
@@ -194,35 +353,76 @@ func installFromRootDirs(owner, repo, basePath, destDir string) (int, error) {
 	return installed, nil
 }
 
-func discoverSkillCollectionPaths(owner, repo, base string, maxDepth int) ([]string, error) {
+// discoverSkillCollectionPaths finds "skills" directories up to maxDepth
+// levels below base. Subdirectories are listed in parallel, at most
+// discoveryConcurrency at a time; a shared visited set keeps each directory
+// from being listed twice. Listing errors below base are skipped. Paths are
+// returned sorted.
+func (in *installer) discoverSkillCollectionPaths(ctx context.Context, owner, repo, base string, maxDepth int) ([]string, error) {
 	if maxDepth < 0 {
 		return nil, nil
 	}
-	entries, err := listGitHubDir(owner, repo, strings.Trim(strings.TrimSpace(base), "/"))
+	base = strings.Trim(strings.TrimSpace(base), "/")
+	entries, err := in.listGitHubDir(ctx, owner, repo, base)
 	if err != nil {
 		return nil, err
 	}
-	paths := []string{}
-	for _, entry := range entries {
-		if entry.Type != "dir" {
-			continue
-		}
-		path := entry.Name
-		if strings.TrimSpace(base) != "" {
-			path = strings.Trim(strings.TrimSpace(base), "/") + "/" + entry.Name
-		}
-		if entry.Name == "skills" {
-			paths = append(paths, path)
-		}
-		if maxDepth == 0 {
-			continue
-		}
-		nested, nestedErr := discoverSkillCollectionPaths(owner, repo, path, maxDepth-1)
-		if nestedErr != nil {
-			continue
+
+	limit := in.discoveryConcurrency
+	if limit < 1 {
+		limit = 1
+	}
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		paths   []string
+		visited = map[string]bool{base: true}
+		sem     = make(chan struct{}, limit)
+	)
+	var walk func(dir string, entries []githubEntry, depth int)
+	walk = func(dir string, entries []githubEntry, depth int) {
+		for _, entry := range entries {
+			if entry.Type != "dir" {
+				continue
+			}
+			path := entry.Name
+			if dir != "" {
+				path = dir + "/" + entry.Name
+			}
+			mu.Lock()
+			seen := visited[path]
+			visited[path] = true
+			if !seen && entry.Name == "skills" {
+				paths = append(paths, path)
+			}
+			mu.Unlock()
+			if seen || depth == 0 {
+				continue
+			}
+			wg.Add(1)
+			go func(path string) {
+				defer wg.Done()
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				nested, err := in.listGitHubDir(ctx, owner, repo, path)
+				<-sem
+				if err != nil {
+					return
+				}
+				walk(path, nested, depth-1)
+			}(path)
 		}
-		paths = append(paths, nested...)
 	}
+	walk(base, entries, maxDepth)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
 	return paths, nil
 }
 
@@ -253,15 +453,23 @@ func splitPath(path string) []string {
 	return parts
 }
 
+func (in *installer) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return in.client.Do(req)
+}
+
 type githubEntry struct {
 	Name string `json:"name"`
 	Type string `json:"type"` // "file" or "dir"
 	Path string `json:"path"`
 }
 
-func fetchGitHubFile(owner, repo, path string) (string, error) {
+func (in *installer) fetchGitHubFile(ctx context.Context, owner, repo, path string) (string, error) {
 	url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/%s", owner, repo, path)
-	resp, err := httpClient.Get(url)
+	resp, err := in.get(ctx, url)
 	if err != nil {
 		return "", err
 	}
@@ -270,7 +478,7 @@ func fetchGitHubFile(owner, repo, path string) (string, error) {
 	if resp.StatusCode == 404 {
 		// Try HEAD branch
 		url = fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/HEAD/%s", owner, repo, path)
-		resp2, err := httpClient.Get(url)
+		resp2, err := in.get(ctx, url)
 		if err != nil {
 			return "", err
 		}
@@ -290,9 +498,9 @@ func fetchGitHubFile(owner, repo, path string) (string, error) {
 	return string(body), err
 }
 
-func listGitHubDir(owner, repo, path string) ([]githubEntry, error) {
+func (in *installer) listGitHubDir(ctx context.Context, owner, repo, path string) ([]githubEntry, error) {
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", owner, repo, path)
-	resp, err := httpClient.Get(url)
+	resp, err := in.get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,75 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"unicode/utf8"
+)
+
+// DefaultMaxInstructionBytes is the default cap on a skill's instructions.
+// It comfortably fits hand-written skills while stopping a runaway one from
+// eating every prompt it is injected into.
+const DefaultMaxInstructionBytes = 64 * 1024
+
+// ErrSkillTooLarge is returned under OversizeReject when a skill's
+// instructions exceed the configured limit.
+var ErrSkillTooLarge = errors.New("skill: instructions exceed size limit")
+
+// OversizePolicy decides what happens to a skill whose instructions exceed
+// the configured limit.
+type OversizePolicy string
+
+const (
+	// OversizeReject refuses to register the skill and reports ErrSkillTooLarge.
+	OversizeReject OversizePolicy = "reject"
+	// OversizeTruncate registers the skill with its instructions cut to the
+	// limit and a note appended.
+	OversizeTruncate OversizePolicy = "truncate"
+)
+
+var (
+	maxInstructionBytes = DefaultMaxInstructionBytes
+	oversizePolicy      = OversizeReject
+)
+
+// SetInstructionLimit configures the size check applied by Register and the
+// loaders. maxBytes <= 0 disables the check; an empty policy keeps the
+// current one.
+func SetInstructionLimit(maxBytes int, policy OversizePolicy) {
+	mu.Lock()
+	defer mu.Unlock()
+	maxInstructionBytes = maxBytes
+	if policy != "" {
+		oversizePolicy = policy
+	}
+}
+
+// InstructionLimit returns the configured limit and policy.
+func InstructionLimit() (int, OversizePolicy) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return maxInstructionBytes, oversizePolicy
+}
+
+// enforceInstructionLimit rejects or truncates s according to the
+// configured limit. A truncated skill is returned as a copy so the caller's
+// value is left as it was. Callers must hold mu.
+func enforceInstructionLimit(s *Skill) (*Skill, error) {
+	limit := maxInstructionBytes
+	size := len(s.Instructions)
+	if limit <= 0 || size <= limit {
+		return s, nil
+	}
+	if oversizePolicy != OversizeTruncate {
+		return nil, fmt.Errorf("%w: %q from %s has %d bytes of instructions, limit is %d", ErrSkillTooLarge, s.Name, skillOrigin(s), size, limit)
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s.Instructions[cut]) {
+		cut--
+	}
+	truncated := s.clone()
+	truncated.Instructions = s.Instructions[:cut] + fmt.Sprintf("\n\n[truncated: instructions exceeded %d bytes]", limit)
+	log.Printf("⚠️  Skill %q from %s truncated from %d to %d bytes", s.Name, skillOrigin(s), size, limit)
+	return truncated, nil
+}
@@ -1,14 +1,71 @@
 package skill
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const skillFileName = "SKILL.md"
 
+// SearchPathMode controls how AGENT_SKILLS_PATH combines with the defaults.
+type SearchPathMode string
+
+const (
+	// SearchPathAppend scans the default paths first, then AGENT_SKILLS_PATH.
+	SearchPathAppend SearchPathMode = "append"
+	// SearchPathPrepend scans AGENT_SKILLS_PATH before the default paths.
+	SearchPathPrepend SearchPathMode = "prepend"
+	// SearchPathReplace scans only AGENT_SKILLS_PATH.
+	SearchPathReplace SearchPathMode = "replace"
+)
+
+// ErrSkillConflict is returned under ConflictError when a loaded skill's
+// name is already registered.
+var ErrSkillConflict = errors.New("skill: name already registered")
+
+// ConflictPolicy decides what happens when a loaded skill's name is already
+// registered (by a built-in or an earlier path).
+type ConflictPolicy string
+
+const (
+	// ConflictFirstWins keeps the registered skill and ignores the new one.
+	ConflictFirstWins ConflictPolicy = "first-wins"
+	// ConflictLastWins replaces the registered skill, so later paths
+	// override earlier ones and local skills override built-ins.
+	ConflictLastWins ConflictPolicy = "last-wins"
+	// ConflictError rejects the new skill and reports ErrSkillConflict.
+	ConflictError ConflictPolicy = "error"
+)
+
+// LoadOption configures LoadFromDir, LoadFromFS, and LoadFromPaths.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	conflict ConflictPolicy
+}
+
+// WithConflictPolicy sets how name conflicts are resolved. The default is
+// ConflictFirstWins.
+func WithConflictPolicy(p ConflictPolicy) LoadOption {
+	return func(o *loadOptions) {
+		if p != "" {
+			o.conflict = p
+		}
+	}
+}
+
+func newLoadOptions(opts []LoadOption) loadOptions {
+	o := loadOptions{conflict: ConflictFirstWins}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // DefaultSearchPaths returns the default directories to scan for skills.
 func DefaultSearchPaths() []string {
 	paths := []string{
@@ -23,8 +80,10 @@ func DefaultSearchPaths() []string {
 }
 
 // LoadFromDir scans a directory for skill folders (each containing SKILL.md)
-// and registers them. Returns the number of skills loaded.
-func LoadFromDir(dir string) (int, error) {
+// and registers them. Returns the number of skills loaded. Under
+// ConflictError the returned error joins every ErrSkillConflict found.
+func LoadFromDir(dir string, opts ...LoadOption) (int, error) {
+	o := newLoadOptions(opts)
 	info, err := os.Stat(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -42,13 +101,18 @@ func LoadFromDir(dir string) (int, error) {
 	}
 
 	loaded := 0
+	var conflicts []error
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			// Check if it's a SKILL.md file directly in the dir
 			if entry.Name() == skillFileName {
-				if err := loadSkillFile(filepath.Join(dir, skillFileName)); err != nil {
+				ok, err := loadSkillFile(filepath.Join(dir, skillFileName), o.conflict)
+				switch {
+				case errors.Is(err, ErrSkillConflict):
+					conflicts = append(conflicts, err)
+				case err != nil:
 					log.Printf("⚠️  Failed to load skill from %s: %v", dir, err)
-				} else {
+				case ok:
 					loaded++
 				}
 			}
@@ -59,8 +123,10 @@ func LoadFromDir(dir string) (int, error) {
 		if _, err := os.Stat(skillPath); err != nil {
 			// Also check subdirectories (e.g., .curated/skill-name/, .experimental/)
 			if entry.Name() == ".curated" || entry.Name() == ".experimental" || entry.Name() == ".system" {
-				subLoaded, subErr := LoadFromDir(filepath.Join(dir, entry.Name()))
-				if subErr != nil {
+				subLoaded, subErr := LoadFromDir(filepath.Join(dir, entry.Name()), opts...)
+				if errors.Is(subErr, ErrSkillConflict) {
+					conflicts = append(conflicts, subErr)
+				} else if subErr != nil {
 					log.Printf("⚠️  Failed to scan %s: %v", filepath.Join(dir, entry.Name()), subErr)
 				}
 				loaded += subLoaded
@@ -68,43 +134,113 @@ func LoadFromDir(dir string) (int, error) {
 			continue
 		}
 
-		if err := loadSkillFile(skillPath); err != nil {
+		ok, err := loadSkillFile(skillPath, o.conflict)
+		switch {
+		case errors.Is(err, ErrSkillConflict):
+			conflicts = append(conflicts, err)
+		case err != nil:
 			log.Printf("⚠️  Failed to load skill %q: %v", entry.Name(), err)
-			continue
+		case ok:
+			loaded++
 		}
-		loaded++
 	}
 
-	return loaded, nil
+	return loaded, errors.Join(conflicts...)
 }
 
-// LoadFromPaths scans multiple directories for skills.
-func LoadFromPaths(paths []string) int {
+// LoadFromPaths scans multiple directories for skills, in order. Conflicts
+// between paths are resolved by the configured ConflictPolicy and logged.
+func LoadFromPaths(paths []string, opts ...LoadOption) int {
 	total := 0
 	for _, p := range paths {
-		n, err := LoadFromDir(p)
+		n, err := LoadFromDir(p, opts...)
+		total += n
 		if err != nil {
 			log.Printf("⚠️  Error scanning skills directory %q: %v", p, err)
-			continue
 		}
-		total += n
 	}
 	return total
 }
 
-// ScanDefaults scans all default search paths for skills.
+// SearchPaths returns the directories ScanDefaults scans: DefaultSearchPaths
+// combined with the list in AGENT_SKILLS_PATH (separated like PATH, i.e.
+// colon-separated on Unix) according to AGENT_SKILLS_PATH_MODE
+// ("append" by default, "prepend" or "replace").
+func SearchPaths() []string {
+	var extra []string
+	for _, p := range filepath.SplitList(os.Getenv("AGENT_SKILLS_PATH")) {
+		if p = strings.TrimSpace(p); p != "" {
+			extra = append(extra, p)
+		}
+	}
+	if len(extra) == 0 {
+		return DefaultSearchPaths()
+	}
+
+	mode := SearchPathMode(strings.ToLower(strings.TrimSpace(os.Getenv("AGENT_SKILLS_PATH_MODE"))))
+	switch mode {
+	case SearchPathReplace:
+		return extra
+	case SearchPathPrepend:
+		return append(extra, DefaultSearchPaths()...)
+	case SearchPathAppend, "":
+	default:
+		log.Printf("⚠️  Unknown AGENT_SKILLS_PATH_MODE %q, using %q", mode, SearchPathAppend)
+	}
+	return append(DefaultSearchPaths(), extra...)
+}
+
+// ScanDefaults scans all search paths (see SearchPaths) for skills, using
+// the conflict policy named by AGENT_SKILLS_ON_CONFLICT if set.
 func ScanDefaults() int {
-	return LoadFromPaths(DefaultSearchPaths())
+	policy := ConflictPolicy(strings.ToLower(strings.TrimSpace(os.Getenv("AGENT_SKILLS_ON_CONFLICT"))))
+	switch policy {
+	case ConflictFirstWins, ConflictLastWins, ConflictError, "":
+	default:
+		log.Printf("⚠️  Unknown AGENT_SKILLS_ON_CONFLICT %q, using %q", policy, ConflictFirstWins)
+		policy = ""
+	}
+	return LoadFromPaths(SearchPaths(), WithConflictPolicy(policy))
 }
 
-func loadSkillFile(path string) error {
+// loadSkillFile parses and registers the skill at path, reporting whether
+// it ended up in the registry.
+func loadSkillFile(path string, policy ConflictPolicy) (bool, error) {
 	s, err := ParseFile(path)
 	if err != nil {
-		return err
+		return false, err
+	}
+	return registerLoaded(s, policy)
+}
+
+// registerLoaded registers a parsed skill under policy, reporting whether
+// it ended up in the registry.
+func registerLoaded(s *Skill, policy ConflictPolicy) (bool, error) {
+	existing, exists := Get(s.Name)
+	if !exists {
+		return true, Register(s)
+	}
+	switch policy {
+	case ConflictLastWins:
+		if err := replace(s); err != nil {
+			return false, err
+		}
+		log.Printf("📚 Skill %q from %s overrides %s (last-wins)", s.Name, skillOrigin(s), skillOrigin(existing))
+		return true, nil
+	case ConflictError:
+		return false, fmt.Errorf("%w: %q from %s conflicts with %s", ErrSkillConflict, s.Name, skillOrigin(s), skillOrigin(existing))
+	default:
+		log.Printf("📚 Skill %q from %s ignored; keeping %s (first-wins)", s.Name, skillOrigin(s), skillOrigin(existing))
+		return false, nil
+	}
+}
+
+func skillOrigin(s *Skill) string {
+	if s.Path != "" {
+		return s.Path
 	}
-	// Skip if already registered (first loaded wins)
-	if _, exists := Get(s.Name); exists {
-		return nil
+	if s.Source != "" {
+		return s.Source
 	}
-	return Register(s)
+	return "registry"
 }
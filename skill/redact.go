@@ -0,0 +1,23 @@
+package skill
+
+import "github.com/PipeOpsHQ/agent-sdk-go/redact"
+
+// RedactedValue replaces sensitive metadata values in API listings.
+const RedactedValue = redact.Replacement
+
+// RedactedMetadata returns a copy of the skill's metadata with sensitive
+// values masked, for serializing skills outside the process. The skill
+// itself keeps the original values.
+func (s *Skill) RedactedMetadata() map[string]string {
+	if s == nil || len(s.Metadata) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(s.Metadata))
+	for k, v := range s.Metadata {
+		if v != "" && redact.IsSensitiveKey(k) {
+			v = RedactedValue
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -11,7 +11,9 @@ var (
 	skills = map[string]*Skill{}
 )
 
-// Register adds a skill to the global registry.
+// Register adds a skill to the global registry. Instructions larger than
+// the configured limit (see SetInstructionLimit) are rejected with
+// ErrSkillTooLarge or truncated, depending on the policy.
 func Register(s *Skill) error {
 	if s == nil {
 		return fmt.Errorf("skill is nil")
@@ -24,6 +26,22 @@ func Register(s *Skill) error {
 	if _, exists := skills[s.Name]; exists {
 		return fmt.Errorf("skill %q already registered", s.Name)
 	}
+	s, err := enforceInstructionLimit(s)
+	if err != nil {
+		return err
+	}
+	skills[s.Name] = s
+	return nil
+}
+
+// replace registers s, overwriting any skill with the same name.
+func replace(s *Skill) error {
+	mu.Lock()
+	defer mu.Unlock()
+	s, err := enforceInstructionLimit(s)
+	if err != nil {
+		return err
+	}
 	skills[s.Name] = s
 	return nil
 }
@@ -24,6 +24,7 @@ type Skill struct {
 	Description  string            `json:"description"`
 	License      string            `json:"license,omitempty"`
 	AllowedTools []string          `json:"allowedTools,omitempty"`
+	Requires     []string          `json:"requires,omitempty"` // names of skills this one builds on
 	Metadata     map[string]string `json:"metadata,omitempty"`
 	Instructions string            `json:"instructions"`
 	Path         string            `json:"path,omitempty"`
@@ -102,8 +103,11 @@ func parseFrontmatter(fm string, s *Skill) error {
 	metadataMap := make(map[string]string)
 
 	flushList := func() {
-		if currentKey == "allowed-tools" {
+		switch currentKey {
+		case "allowed-tools":
 			s.AllowedTools = listItems
+		case "requires":
+			s.Requires = listItems
 		}
 		listItems = nil
 		inList = false
@@ -152,7 +156,9 @@ func parseFrontmatter(fm string, s *Skill) error {
 
 		key := strings.TrimSpace(line[:colonIdx])
 		value := strings.TrimSpace(line[colonIdx+1:])
-		value = strings.Trim(value, `"'`)
+		if key != "requires" {
+			value = strings.Trim(value, `"'`)
+		}
 		currentKey = key
 
 		switch key {
@@ -167,6 +173,13 @@ func parseFrontmatter(fm string, s *Skill) error {
 				inList = true
 				listItems = nil
 			}
+		case "requires":
+			if value == "" {
+				inList = true
+				listItems = nil
+			} else {
+				s.Requires = parseInlineList(value)
+			}
 		case "metadata":
 			if value == "" {
 				inMetadata = true
@@ -189,3 +202,17 @@ func parseFrontmatter(fm string, s *Skill) error {
 
 	return scanner.Err()
 }
+
+// parseInlineList parses a flow-style list ("[a, b]") or a single bare value.
+func parseInlineList(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var out []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.Trim(strings.TrimSpace(item), `"'`)
+		if item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
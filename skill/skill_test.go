@@ -1,9 +1,21 @@
 package skill
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 const testSkillMD = `---
@@ -164,6 +176,100 @@ func TestRegistryEmptyName(t *testing.T) {
 	}
 }
 
+func TestInstructionLimit(t *testing.T) {
+	Reset()
+	defer Reset()
+	defer SetInstructionLimit(DefaultMaxInstructionBytes, OversizeReject)
+
+	SetInstructionLimit(16, OversizeReject)
+	big := &Skill{Name: "big", Description: "d", Instructions: strings.Repeat("x", 32)}
+	if err := Register(big); !errors.Is(err, ErrSkillTooLarge) {
+		t.Fatalf("Register oversized = %v, want ErrSkillTooLarge", err)
+	}
+	if _, ok := Get("big"); ok {
+		t.Fatal("oversized skill should not be registered")
+	}
+
+	dir := t.TempDir()
+	skillDir := filepath.Join(dir, "big")
+	os.MkdirAll(skillDir, 0o755)
+	os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: big\ndescription: d\n---\n"+strings.Repeat("é", 20)), 0o644)
+	if n, _ := LoadFromDir(dir); n != 0 {
+		t.Fatalf("loaded %d oversized skills, want 0", n)
+	}
+
+	SetInstructionLimit(0, OversizeTruncate)
+	if max, policy := InstructionLimit(); max != 0 || policy != OversizeTruncate {
+		t.Fatalf("InstructionLimit = %d, %s", max, policy)
+	}
+	SetInstructionLimit(15, "")
+	if n, _ := LoadFromDir(dir); n != 1 {
+		t.Fatalf("loaded %d truncated skills, want 1", n)
+	}
+	s, _ := Get("big")
+	if !strings.HasPrefix(s.Instructions, strings.Repeat("é", 7)+"\n") || !strings.Contains(s.Instructions, "[truncated") {
+		t.Fatalf("unexpected truncated instructions %q", s.Instructions)
+	}
+
+	own := &Skill{Name: "own", Description: "d", Instructions: strings.Repeat("y", 32)}
+	if err := Register(own); err != nil {
+		t.Fatalf("Register under truncate: %v", err)
+	}
+	if len(own.Instructions) != 32 {
+		t.Fatalf("caller's skill was modified: %q", own.Instructions)
+	}
+	if got, _ := Get("own"); got == own || !strings.Contains(got.Instructions, "[truncated") {
+		t.Fatalf("registered skill = %+v, want a truncated copy", got)
+	}
+}
+
+func TestParse_Requires(t *testing.T) {
+	inline, err := Parse("---\nname: k8s-rollout\ndescription: d\nrequires: [k8s-debug, \"kubectl\"]\n---\nbody")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if strings.Join(inline.Requires, ",") != "k8s-debug,kubectl" {
+		t.Errorf("inline Requires = %v", inline.Requires)
+	}
+	block, err := Parse("---\nname: k8s-rollout\ndescription: d\nrequires:\n  - k8s-debug\n---\nbody")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(block.Requires) != 1 || block.Requires[0] != "k8s-debug" {
+		t.Errorf("block Requires = %v", block.Requires)
+	}
+}
+
+func TestResolveDependencies(t *testing.T) {
+	Reset()
+	defer Reset()
+	MustRegister(&Skill{Name: "base", Description: "d"})
+	MustRegister(&Skill{Name: "k8s-debug", Description: "d", Requires: []string{"base"}})
+	MustRegister(&Skill{Name: "k8s-rollout", Description: "d", Requires: []string{"k8s-debug", "base"}})
+
+	resolved, err := ResolveDependencies([]string{"k8s-rollout", "base"})
+	if err != nil {
+		t.Fatalf("ResolveDependencies failed: %v", err)
+	}
+	var order []string
+	for _, s := range resolved {
+		order = append(order, s.Name)
+	}
+	if got := strings.Join(order, ","); got != "base,k8s-debug,k8s-rollout" {
+		t.Errorf("load order = %s", got)
+	}
+
+	MustRegister(&Skill{Name: "orphan", Description: "d", Requires: []string{"ghost"}})
+	if _, err := ResolveDependencies([]string{"orphan"}); !errors.Is(err, ErrMissingDependency) {
+		t.Errorf("missing dep err = %v", err)
+	}
+	MustRegister(&Skill{Name: "a", Description: "d", Requires: []string{"b"}})
+	MustRegister(&Skill{Name: "b", Description: "d", Requires: []string{"a"}})
+	if _, err := ResolveDependencies([]string{"a"}); !errors.Is(err, ErrDependencyCycle) || !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("cycle err = %v", err)
+	}
+}
+
 func TestLoadFromDir(t *testing.T) {
 	Reset()
 	defer Reset()
@@ -189,6 +295,88 @@ func TestLoadFromDir(t *testing.T) {
 	}
 }
 
+func TestLoadFromFS(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	skillMD := func(name string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte("---\nname: " + name + "\ndescription: Test " + name + "\n---\nInstructions for " + name)}
+	}
+	fsys := fstest.MapFS{
+		"bundle/skill-a/SKILL.md":          skillMD("skill-a"),
+		"bundle/.curated/skill-b/SKILL.md": skillMD("skill-b"),
+		"bundle/notes/README.md":           &fstest.MapFile{Data: []byte("not a skill")},
+		"bundle/broken/SKILL.md":           &fstest.MapFile{Data: []byte("no frontmatter")},
+		"other/skill-a/SKILL.md":           skillMD("skill-a"),
+	}
+
+	n, err := LoadFromFS(fsys, "bundle")
+	if err != nil {
+		t.Fatalf("LoadFromFS failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("loaded %d, want 2", n)
+	}
+	s, ok := Get("skill-a")
+	if !ok || s.Source != "embed:bundle/skill-a" || s.Path != "" {
+		t.Errorf("skill-a = %+v", s)
+	}
+
+	_, err = LoadFromFS(fsys, "other", WithConflictPolicy(ConflictError))
+	if !errors.Is(err, ErrSkillConflict) {
+		t.Errorf("err = %v, want ErrSkillConflict", err)
+	}
+	if n, err := LoadFromFS(fsys, "missing"); n != 0 || err != nil {
+		t.Errorf("missing root = %d, %v", n, err)
+	}
+}
+
+func TestLoadFromPaths_ConflictPolicy(t *testing.T) {
+	writeSkill := func(dir, name, body string) {
+		t.Helper()
+		skillDir := filepath.Join(dir, name)
+		if err := os.MkdirAll(skillDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\nname: " + name + "\ndescription: Test " + name + "\n---\n" + body
+		if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	local := t.TempDir()
+	writeSkill(local, "k8s-debug", "local override")
+	builtin := func() {
+		Reset()
+		MustRegister(&Skill{Name: "k8s-debug", Description: "builtin", Instructions: "builtin", Source: "builtin"})
+	}
+	defer Reset()
+
+	builtin()
+	if n := LoadFromPaths([]string{local}); n != 0 {
+		t.Errorf("first-wins loaded %d, want 0", n)
+	}
+	if s, _ := Get("k8s-debug"); s.Instructions != "builtin" {
+		t.Errorf("first-wins replaced builtin: %q", s.Instructions)
+	}
+
+	builtin()
+	if n := LoadFromPaths([]string{local}, WithConflictPolicy(ConflictLastWins)); n != 1 {
+		t.Errorf("last-wins loaded %d, want 1", n)
+	}
+	if s, _ := Get("k8s-debug"); s.Instructions != "local override" || s.Source != "local" {
+		t.Errorf("last-wins kept %+v", s)
+	}
+
+	builtin()
+	n, err := LoadFromDir(local, WithConflictPolicy(ConflictError))
+	if !errors.Is(err, ErrSkillConflict) || n != 0 {
+		t.Errorf("error policy = %d, %v", n, err)
+	}
+	if s, _ := Get("k8s-debug"); s.Instructions != "builtin" {
+		t.Errorf("error policy replaced builtin: %q", s.Instructions)
+	}
+}
+
 func TestLoadFromDir_Nonexistent(t *testing.T) {
 	n, err := LoadFromDir("/nonexistent/path/12345")
 	if err != nil {
@@ -199,6 +387,32 @@ func TestLoadFromDir_Nonexistent(t *testing.T) {
 	}
 }
 
+func TestSearchPaths_Env(t *testing.T) {
+	defaults := DefaultSearchPaths()
+	sep := string(os.PathListSeparator)
+
+	t.Setenv("AGENT_SKILLS_PATH", "")
+	if got := SearchPaths(); strings.Join(got, sep) != strings.Join(defaults, sep) {
+		t.Errorf("unset = %v, want defaults", got)
+	}
+
+	t.Setenv("AGENT_SKILLS_PATH", "/etc/agent/skills"+sep+" "+sep+"/opt/skills")
+	got := SearchPaths()
+	if len(got) != len(defaults)+2 || got[len(got)-2] != "/etc/agent/skills" || got[len(got)-1] != "/opt/skills" {
+		t.Errorf("append = %v", got)
+	}
+
+	t.Setenv("AGENT_SKILLS_PATH_MODE", "prepend")
+	if got := SearchPaths(); got[0] != "/etc/agent/skills" || len(got) != len(defaults)+2 {
+		t.Errorf("prepend = %v", got)
+	}
+
+	t.Setenv("AGENT_SKILLS_PATH_MODE", "REPLACE")
+	if got := SearchPaths(); len(got) != 2 || got[0] != "/etc/agent/skills" {
+		t.Errorf("replace = %v", got)
+	}
+}
+
 func TestBuiltins(t *testing.T) {
 	Reset()
 	defer Reset()
@@ -221,6 +435,37 @@ func TestBuiltins(t *testing.T) {
 	}
 }
 
+func TestRegisterBuiltin(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	for _, name := range []string{"k8s-debug", "incident-response"} {
+		if err := RegisterBuiltin(name); err != nil {
+			t.Fatalf("RegisterBuiltin(%q): %v", name, err)
+		}
+	}
+	if Count() != 2 {
+		t.Errorf("Count = %d, want 2", Count())
+	}
+	if err := RegisterBuiltin("no-such-skill"); err == nil {
+		t.Error("expected error for unknown built-in")
+	}
+
+	all := BuiltinSkills()
+	if len(all) < Count() {
+		t.Fatalf("BuiltinSkills returned %d skills", len(all))
+	}
+	custom := all[0]
+	custom.Name = "custom-" + custom.Name
+	custom.AllowedTools[0] = "changed"
+	if again := BuiltinSkills()[0]; again.Name == custom.Name || again.AllowedTools[0] == "changed" {
+		t.Errorf("BuiltinSkills should return copies, got %+v", again)
+	}
+	if s, _ := Get("k8s-debug"); s.AllowedTools[0] == "changed" {
+		t.Error("registered built-in shares state with BuiltinSkills copy")
+	}
+}
+
 func TestLearnedPattern(t *testing.T) {
 	Reset()
 	defer Reset()
@@ -272,3 +517,211 @@ func TestMustRegister_Panics(t *testing.T) {
 	MustRegister(s)
 	MustRegister(s) // should panic
 }
+
+// rewriteTransport sends every request to a test server, preserving the path.
+type rewriteTransport struct {
+	target *url.URL
+	mu     sync.Mutex
+	hosts  []string
+}
+
+func (rt *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.hosts = append(rt.hosts, req.URL.Host)
+	rt.mu.Unlock()
+	out := req.Clone(req.Context())
+	out.URL.Scheme = rt.target.Scheme
+	out.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(out)
+}
+
+func TestInstallFromGitHub_CustomHTTPClient(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/acme/skills/main/tools/test-skill/SKILL.md" {
+			_, _ = w.Write([]byte(testSkillMD))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	rt := &rewriteTransport{target: target}
+	dir := t.TempDir()
+
+	var events []InstallProgress
+	n, err := InstallFromGitHub(context.Background(), "acme/skills/tools/test-skill", dir,
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithProgress(func(ev InstallProgress) { events = append(events, ev) }),
+	)
+	if err != nil {
+		t.Fatalf("InstallFromGitHub failed: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("installed = %d, want 1", n)
+	}
+	if len(rt.hosts) == 0 || rt.hosts[0] != "raw.githubusercontent.com" {
+		t.Errorf("requests did not go through custom client: %v", rt.hosts)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test-skill", "SKILL.md")); err != nil {
+		t.Errorf("SKILL.md not written: %v", err)
+	}
+	if _, ok := Get("test-skill"); !ok {
+		t.Error("installed skill not registered")
+	}
+	if len(events) != 2 || events[0].Stage != InstallStageDiscovered || events[1].Stage != InstallStageInstalled {
+		t.Fatalf("progress events = %+v", events)
+	}
+	if events[1].Skill != "test-skill" || events[1].Installed != 1 {
+		t.Errorf("installed event = %+v", events[1])
+	}
+}
+
+func TestInstallFromGitHub_FetchFailureReportsProgress(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	var events []InstallProgress
+	_, err := InstallFromGitHub(context.Background(), "acme/skills/tools/missing", t.TempDir(),
+		WithHTTPClient(&http.Client{Transport: &rewriteTransport{target: target}}),
+		WithProgress(func(ev InstallProgress) { events = append(events, ev) }),
+	)
+	if err == nil {
+		t.Fatal("expected fetch error")
+	}
+	if len(events) != 1 || events[0].Stage != InstallStageFailed || events[0].RepoPath != "tools/missing" || events[0].Err == nil {
+		t.Fatalf("progress events = %+v, want one failed event", events)
+	}
+}
+
+func TestInstallFromGitHub_Cancelled(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := InstallFromGitHub(ctx, "acme/skills", t.TempDir(), WithHTTPClient(&http.Client{Transport: &rewriteTransport{target: target}}))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if calls != 0 {
+		t.Errorf("server received %d requests after cancellation", calls)
+	}
+}
+
+func TestDiscoverSkillCollectionPaths_Parallel(t *testing.T) {
+	tree := map[string][]githubEntry{
+		"":           {{Name: "a", Type: "dir"}, {Name: "b", Type: "dir"}, {Name: "README.md", Type: "file"}},
+		"a":          {{Name: "skills", Type: "dir"}, {Name: "x", Type: "dir"}},
+		"b":          {{Name: "c", Type: "dir"}, {Name: "y", Type: "dir"}},
+		"b/c":        {{Name: "skills", Type: "dir"}},
+		"a/skills":   {{Name: "one", Type: "dir"}},
+		"b/c/skills": {{Name: "two", Type: "dir"}},
+	}
+	var inFlight, maxInFlight int32
+	var listed sync.Map
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/repos/acme/mono/contents"), "/")
+		if _, dup := listed.LoadOrStore(path, true); dup {
+			t.Errorf("directory %q listed twice", path)
+		}
+		entries, ok := tree[path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	in := newInstaller(
+		WithHTTPClient(&http.Client{Transport: &rewriteTransport{target: target}}),
+		WithDiscoveryConcurrency(2),
+	)
+	got, err := in.discoverSkillCollectionPaths(context.Background(), "acme", "mono", "", 5)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	want := []string{"a/skills", "b/c/skills"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+	if m := atomic.LoadInt32(&maxInFlight); m > 2 {
+		t.Errorf("max concurrent listings = %d, want <= 2", m)
+	}
+}
+
+func TestWithProxy_DoesNotMutateCallerTransport(t *testing.T) {
+	base := &http.Transport{}
+	proxy, _ := url.Parse("http://proxy.internal:3128")
+	in := newInstaller(WithHTTPClient(&http.Client{Transport: base}), WithProxy(proxy))
+
+	if base.Proxy != nil {
+		t.Error("caller transport was modified")
+	}
+	tr, ok := in.client.Transport.(*http.Transport)
+	if !ok || tr.Proxy == nil {
+		t.Fatal("proxy not configured on installer transport")
+	}
+	got, err := tr.Proxy(httptest.NewRequest(http.MethodGet, "https://api.github.com", nil))
+	if err != nil || got.String() != proxy.String() {
+		t.Errorf("Proxy = %v, %v; want %v", got, err, proxy)
+	}
+}
+
+func TestRedactedMetadata(t *testing.T) {
+	s := &Skill{Name: "meta", Metadata: map[string]string{
+		"author":        "dev@example.com",
+		"keywords":      "k8s,debug",
+		"github_token":  "ghp_abc",
+		"apiKey":        "sk-123",
+		"signing-key":   "xyz",
+		"Password":      "hunter2",
+		"client_secret": "",
+	}}
+
+	got := s.RedactedMetadata()
+	for _, k := range []string{"github_token", "apiKey", "signing-key", "Password"} {
+		if got[k] != RedactedValue {
+			t.Errorf("metadata[%q] = %q, want redacted", k, got[k])
+		}
+	}
+	for _, k := range []string{"author", "keywords"} {
+		if got[k] != s.Metadata[k] {
+			t.Errorf("metadata[%q] = %q, want %q", k, got[k], s.Metadata[k])
+		}
+	}
+	if got["client_secret"] != "" {
+		t.Errorf("empty secret should stay empty, got %q", got["client_secret"])
+	}
+	if s.Metadata["github_token"] != "ghp_abc" {
+		t.Error("RedactedMetadata modified the skill's own metadata")
+	}
+}
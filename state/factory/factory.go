@@ -10,6 +10,7 @@ import (
 
 	"github.com/PipeOpsHQ/agent-sdk-go/state"
 	"github.com/PipeOpsHQ/agent-sdk-go/state/hybrid"
+	memorystore "github.com/PipeOpsHQ/agent-sdk-go/state/memory"
 	redisstore "github.com/PipeOpsHQ/agent-sdk-go/state/redis"
 	sqlitestore "github.com/PipeOpsHQ/agent-sdk-go/state/sqlite"
 )
@@ -26,6 +27,9 @@ func FromEnv(ctx context.Context) (state.Store, error) {
 	case "redis":
 		return newRedisStoreFromEnv()
 
+	case "memory":
+		return memorystore.New(), nil
+
 	case "hybrid":
 		path := getenv("AGENT_SQLITE_PATH", "./.ai-agent/state.db")
 		durable, err := sqlitestore.New(path)
@@ -39,7 +43,7 @@ func FromEnv(ctx context.Context) (state.Store, error) {
 		return hybrid.New(durable, cache)
 
 	default:
-		return nil, fmt.Errorf("unsupported AGENT_STATE_BACKEND %q (use sqlite, redis, or hybrid)", backend)
+		return nil, fmt.Errorf("unsupported AGENT_STATE_BACKEND %q (use sqlite, redis, hybrid, or memory)", backend)
 	}
 }
 
@@ -0,0 +1,270 @@
+// Package memory provides an in-process state.Store for tests, examples,
+// and ephemeral runs. It mirrors the SQLite store's semantics: records are
+// JSON round-tripped (so metadata numbers come back as float64), created_at
+// is preserved across upserts, runs list newest first, and checkpoints
+// require an existing run and a unique (run, seq).
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/state"
+)
+
+const defaultLimit = 50
+
+type runEntry struct {
+	raw []byte
+	seq int64 // insertion order, breaks created_at ties
+}
+
+type Store struct {
+	mu          sync.RWMutex
+	runs        map[string]runEntry
+	checkpoints map[string]map[int][]byte
+	nextSeq     int64
+}
+
+var _ state.Store = (*Store)(nil)
+
+// New returns an empty in-memory store.
+func New() *Store {
+	return &Store{
+		runs:        map[string]runEntry{},
+		checkpoints: map[string]map[int][]byte{},
+	}
+}
+
+func (s *Store) SaveRun(_ context.Context, run state.RunRecord) error {
+	now := time.Now().UTC()
+	if run.CreatedAt == nil {
+		run.CreatedAt = &now
+	}
+	if run.UpdatedAt == nil {
+		run.UpdatedAt = &now
+	}
+	if run.RunID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+	if run.SessionID == "" {
+		return fmt.Errorf("session_id is required")
+	}
+	if run.Provider == "" {
+		run.Provider = "unknown"
+	}
+	if run.Status == "" {
+		run.Status = state.RunRunning
+	}
+	if run.Metadata == nil {
+		run.Metadata = map[string]any{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, exists := s.runs[run.RunID]
+	if exists {
+		// Like the SQLite upsert, created_at is fixed by the first save.
+		prev, err := decodeRun(entry.raw)
+		if err != nil {
+			return err
+		}
+		run.CreatedAt = prev.CreatedAt
+		run.StatusHistory = prev.StatusHistory
+	} else {
+		s.nextSeq++
+		entry.seq = s.nextSeq
+	}
+	run.StatusHistory = state.AppendTransition(run.StatusHistory, run.Status, *run.UpdatedAt)
+	raw, err := encodeRun(run)
+	if err != nil {
+		return err
+	}
+	entry.raw = raw
+	s.runs[run.RunID] = entry
+	return nil
+}
+
+func (s *Store) LoadRun(_ context.Context, runID string) (state.RunRecord, error) {
+	if strings.TrimSpace(runID) == "" {
+		return state.RunRecord{}, fmt.Errorf("run_id is required")
+	}
+	s.mu.RLock()
+	entry, ok := s.runs[runID]
+	s.mu.RUnlock()
+	if !ok {
+		return state.RunRecord{}, state.ErrNotFound
+	}
+	return decodeRun(entry.raw)
+}
+
+func (s *Store) ListRuns(_ context.Context, query state.ListRunsQuery) ([]state.RunRecord, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	type candidate struct {
+		run state.RunRecord
+		seq int64
+	}
+	s.mu.RLock()
+	matches := make([]candidate, 0, len(s.runs))
+	for _, entry := range s.runs {
+		run, err := decodeRun(entry.raw)
+		if err != nil {
+			s.mu.RUnlock()
+			return nil, err
+		}
+		if query.SessionID != "" && run.SessionID != query.SessionID {
+			continue
+		}
+		if query.Status != "" && run.Status != query.Status {
+			continue
+		}
+		if !state.MatchMetadata(run.Metadata, query.Metadata) {
+			continue
+		}
+		matches = append(matches, candidate{run: run, seq: entry.seq})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		ci, cj := matches[i].run.CreatedAt, matches[j].run.CreatedAt
+		if !ci.Equal(*cj) {
+			return ci.After(*cj)
+		}
+		return matches[i].seq > matches[j].seq
+	})
+
+	out := make([]state.RunRecord, 0, limit)
+	for i := offset; i < len(matches) && len(out) < limit; i++ {
+		out = append(out, matches[i].run)
+	}
+	return out, nil
+}
+
+func (s *Store) SaveCheckpoint(_ context.Context, checkpoint state.CheckpointRecord) error {
+	if checkpoint.RunID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+	if checkpoint.Seq < 0 {
+		return fmt.Errorf("seq must be >= 0")
+	}
+	if checkpoint.NodeID == "" {
+		checkpoint.NodeID = "unknown"
+	}
+	if checkpoint.State == nil {
+		checkpoint.State = map[string]any{}
+	}
+	if checkpoint.CreatedAt.IsZero() {
+		checkpoint.CreatedAt = time.Now().UTC()
+	}
+	checkpoint.CreatedAt = checkpoint.CreatedAt.UTC()
+	raw, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint state: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.runs[checkpoint.RunID]; !ok {
+		return fmt.Errorf("failed to save checkpoint: run %q: %w", checkpoint.RunID, state.ErrNotFound)
+	}
+	bySeq := s.checkpoints[checkpoint.RunID]
+	if bySeq == nil {
+		bySeq = map[int][]byte{}
+		s.checkpoints[checkpoint.RunID] = bySeq
+	}
+	if _, exists := bySeq[checkpoint.Seq]; exists {
+		return state.ErrConflict
+	}
+	bySeq[checkpoint.Seq] = raw
+	return nil
+}
+
+func (s *Store) LoadLatestCheckpoint(ctx context.Context, runID string) (state.CheckpointRecord, error) {
+	if runID == "" {
+		return state.CheckpointRecord{}, fmt.Errorf("run_id is required")
+	}
+	records, err := s.ListCheckpoints(ctx, runID, 1)
+	if err != nil {
+		return state.CheckpointRecord{}, err
+	}
+	if len(records) == 0 {
+		return state.CheckpointRecord{}, state.ErrNotFound
+	}
+	return records[0], nil
+}
+
+func (s *Store) ListCheckpoints(_ context.Context, runID string, limit int) ([]state.CheckpointRecord, error) {
+	if runID == "" {
+		return nil, fmt.Errorf("run_id is required")
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	bySeq := s.checkpoints[runID]
+	seqs := make([]int, 0, len(bySeq))
+	for seq := range bySeq {
+		seqs = append(seqs, seq)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(seqs)))
+
+	out := make([]state.CheckpointRecord, 0, min(limit, len(seqs)))
+	for _, seq := range seqs {
+		if len(out) >= limit {
+			break
+		}
+		var record state.CheckpointRecord
+		if err := json.Unmarshal(bySeq[seq], &record); err != nil {
+			return nil, fmt.Errorf("failed to decode checkpoint state: %w", err)
+		}
+		out = append(out, record)
+	}
+	return out, nil
+}
+
+func (s *Store) Close() error { return nil }
+
+// encodeRun marshals run with its timestamps in UTC. run is a copy, but
+// its time pointers are the caller's, so they are replaced, not written.
+func encodeRun(run state.RunRecord) ([]byte, error) {
+	run.CreatedAt, run.UpdatedAt, run.CompletedAt = utcTime(run.CreatedAt), utcTime(run.UpdatedAt), utcTime(run.CompletedAt)
+	raw, err := json.Marshal(run)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal run: %w", err)
+	}
+	return raw, nil
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+func decodeRun(raw []byte) (state.RunRecord, error) {
+	var run state.RunRecord
+	if err := json.Unmarshal(raw, &run); err != nil {
+		return state.RunRecord{}, fmt.Errorf("failed to decode run: %w", err)
+	}
+	if run.Metadata == nil {
+		run.Metadata = map[string]any{}
+	}
+	return run, nil
+}
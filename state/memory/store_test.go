@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/state"
+)
+
+func TestStore_SaveLoadRun(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	if err := s.SaveRun(ctx, state.RunRecord{SessionID: "sess"}); err == nil {
+		t.Fatal("expected error for missing run_id")
+	}
+	if err := s.SaveRun(ctx, state.RunRecord{RunID: "run"}); err == nil {
+		t.Fatal("expected error for missing session_id")
+	}
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := state.RunRecord{
+		RunID:     "run-1",
+		SessionID: "sess-1",
+		Input:     "hello",
+		Metadata:  map[string]any{"max_attempts": 3},
+		CreatedAt: &created,
+	}
+	if err := s.SaveRun(ctx, record); err != nil {
+		t.Fatalf("SaveRun failed: %v", err)
+	}
+	record.Metadata["max_attempts"] = 99 // stored copy must not alias caller maps
+
+	local := time.Date(2024, 1, 1, 9, 0, 0, 0, time.FixedZone("UTC+9", 9*3600))
+	localCopy := local
+	if err := s.SaveRun(ctx, state.RunRecord{RunID: "run-tz", SessionID: "sess-1", CreatedAt: &local, UpdatedAt: &local}); err != nil {
+		t.Fatalf("SaveRun failed: %v", err)
+	}
+	if local != localCopy {
+		t.Errorf("SaveRun rewrote the caller's time to %v", local)
+	}
+
+	got, err := s.LoadRun(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("LoadRun failed: %v", err)
+	}
+	if got.Provider != "unknown" || got.Status != "running" {
+		t.Errorf("defaults not applied: provider=%q status=%q", got.Provider, got.Status)
+	}
+	if v, ok := got.Metadata["max_attempts"].(float64); !ok || v != 3 {
+		t.Errorf("max_attempts = %#v, want float64(3)", got.Metadata["max_attempts"])
+	}
+
+	later := created.Add(time.Hour)
+	got.Status = "completed"
+	got.CreatedAt = &later
+	if err := s.SaveRun(ctx, got); err != nil {
+		t.Fatalf("upsert failed: %v", err)
+	}
+	got, _ = s.LoadRun(ctx, "run-1")
+	if got.Status != "completed" || !got.CreatedAt.Equal(created) {
+		t.Errorf("upsert status=%q createdAt=%v, want completed and %v", got.Status, got.CreatedAt, created)
+	}
+
+	if _, err := s.LoadRun(ctx, "missing"); !errors.Is(err, state.ErrNotFound) {
+		t.Errorf("missing run err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_ListRuns(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, spec := range []struct {
+		id, session, status, team string
+	}{
+		{"r1", "a", "completed", "red"},
+		{"r2", "a", "failed", "blue"},
+		{"r3", "b", "completed", "red"},
+	} {
+		created := base.Add(time.Duration(i) * time.Minute)
+		if err := s.SaveRun(ctx, state.RunRecord{
+			RunID: spec.id, SessionID: spec.session, Status: spec.status,
+			Metadata: map[string]any{"team": spec.team}, CreatedAt: &created,
+		}); err != nil {
+			t.Fatalf("SaveRun %s: %v", spec.id, err)
+		}
+	}
+
+	ids := func(runs []state.RunRecord) []string {
+		out := make([]string, len(runs))
+		for i, r := range runs {
+			out[i] = r.RunID
+		}
+		return out
+	}
+	cases := []struct {
+		name  string
+		query state.ListRunsQuery
+		want  []string
+	}{
+		{"all newest first", state.ListRunsQuery{}, []string{"r3", "r2", "r1"}},
+		{"session", state.ListRunsQuery{SessionID: "a"}, []string{"r2", "r1"}},
+		{"status", state.ListRunsQuery{Status: "completed"}, []string{"r3", "r1"}},
+		{"metadata", state.ListRunsQuery{Metadata: map[string]string{"team": "red"}}, []string{"r3", "r1"}},
+		{"paged", state.ListRunsQuery{Limit: 1, Offset: 1}, []string{"r2"}},
+	}
+	for _, tc := range cases {
+		runs, err := s.ListRuns(ctx, tc.query)
+		if err != nil {
+			t.Fatalf("%s: ListRuns failed: %v", tc.name, err)
+		}
+		if got := ids(runs); len(got) != len(tc.want) || (len(got) > 0 && got[0] != tc.want[0]) || (len(got) > 1 && got[1] != tc.want[1]) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestStore_Checkpoints(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	if err := s.SaveCheckpoint(ctx, state.CheckpointRecord{RunID: "run-1", Seq: 1}); !errors.Is(err, state.ErrNotFound) {
+		t.Fatalf("checkpoint for unknown run err = %v, want ErrNotFound", err)
+	}
+	if err := s.SaveRun(ctx, state.RunRecord{RunID: "run-1", SessionID: "sess"}); err != nil {
+		t.Fatalf("SaveRun failed: %v", err)
+	}
+	if err := s.SaveCheckpoint(ctx, state.CheckpointRecord{RunID: "run-1", Seq: -1}); err == nil {
+		t.Fatal("expected error for negative seq")
+	}
+	for seq := 1; seq <= 3; seq++ {
+		if err := s.SaveCheckpoint(ctx, state.CheckpointRecord{RunID: "run-1", Seq: seq, State: map[string]any{"n": seq}}); err != nil {
+			t.Fatalf("SaveCheckpoint %d: %v", seq, err)
+		}
+	}
+	if err := s.SaveCheckpoint(ctx, state.CheckpointRecord{RunID: "run-1", Seq: 2}); !errors.Is(err, state.ErrConflict) {
+		t.Errorf("duplicate seq err = %v, want ErrConflict", err)
+	}
+
+	latest, err := s.LoadLatestCheckpoint(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("LoadLatestCheckpoint failed: %v", err)
+	}
+	if latest.Seq != 3 || latest.NodeID != "unknown" || latest.State["n"] != float64(3) {
+		t.Errorf("latest = %+v", latest)
+	}
+	list, err := s.ListCheckpoints(ctx, "run-1", 2)
+	if err != nil || len(list) != 2 || list[0].Seq != 3 || list[1].Seq != 2 {
+		t.Errorf("ListCheckpoints = %+v, %v", list, err)
+	}
+	if _, err := s.LoadLatestCheckpoint(ctx, "other"); !errors.Is(err, state.ErrNotFound) {
+		t.Errorf("no checkpoints err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_StatusLifecycle(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, status := range []string{state.RunQueued, state.RunRunning, state.RunRunning} {
+		at := base.Add(time.Duration(i) * time.Second)
+		if err := s.SaveRun(ctx, state.RunRecord{RunID: "run-1", SessionID: "sess", Status: status, UpdatedAt: &at}); err != nil {
+			t.Fatalf("SaveRun %s: %v", status, err)
+		}
+	}
+	if _, err := state.TransitionRun(ctx, s, "run-1", state.RunCompleted, ""); err != nil {
+		t.Fatalf("TransitionRun completed: %v", err)
+	}
+	if _, err := state.TransitionRun(ctx, s, "run-1", state.RunRunning, ""); !errors.Is(err, state.ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+
+	run, err := s.LoadRun(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("LoadRun: %v", err)
+	}
+	var got []string
+	for _, tr := range run.StatusHistory {
+		got = append(got, tr.Status)
+	}
+	if len(got) != 3 || got[0] != state.RunQueued || got[1] != state.RunRunning || got[2] != state.RunCompleted {
+		t.Fatalf("status history = %v", got)
+	}
+	if !run.StatusHistory[1].At.Equal(base.Add(time.Second)) || run.CompletedAt == nil {
+		t.Fatalf("unexpected timestamps: %+v, completedAt=%v", run.StatusHistory, run.CompletedAt)
+	}
+
+	if err := s.SaveRun(ctx, state.RunRecord{RunID: "run-2", SessionID: "sess", Status: state.RunFailed}); err != nil {
+		t.Fatalf("SaveRun run-2: %v", err)
+	}
+	failed, err := state.ListRunsByStatus(ctx, s, "FAILED", 10, 0)
+	if err != nil || len(failed) != 1 || failed[0].RunID != "run-2" {
+		t.Fatalf("ListRunsByStatus failed = %+v, %v", failed, err)
+	}
+}
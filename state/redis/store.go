@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -17,6 +18,8 @@ const (
 	defaultTTL    = 72 * time.Hour
 	defaultLimit  = 50
 	defaultPrefix = "aiag"
+
+	maxSaveRunAttempts = 10
 )
 
 type Store struct {
@@ -113,26 +116,53 @@ func (s *Store) SaveRun(ctx context.Context, run state.RunRecord) error {
 		run.Metadata = map[string]any{}
 	}
 
-	runRaw, err := json.Marshal(run)
-	if err != nil {
-		return fmt.Errorf("failed to marshal run: %w", err)
-	}
-
-	updatedUnix := float64(run.UpdatedAt.Unix())
 	runKey := s.runKey(run.RunID)
 	sessionIdx := s.sessionIndexKey(run.SessionID)
+	updatedUnix := float64(run.UpdatedAt.Unix())
 
-	pipe := s.client.TxPipeline()
-	pipe.Set(ctx, runKey, string(runRaw), s.ttl)
-	pipe.ZAdd(ctx, sessionIdx, goredis.Z{
-		Score:  updatedUnix,
-		Member: run.RunID,
-	})
-	pipe.Expire(ctx, sessionIdx, s.ttl)
-	if _, err := pipe.Exec(ctx); err != nil {
-		return fmt.Errorf("failed to save run in redis: %w", err)
+	// StatusHistory is read-modify-write: WATCH the run key so a concurrent
+	// save forces a retry instead of dropping a transition.
+	txn := func(tx *goredis.Tx) error {
+		rec := run
+		prevRaw, err := tx.Get(ctx, runKey).Result()
+		switch {
+		case err == nil:
+			var prev state.RunRecord
+			if err := json.Unmarshal([]byte(prevRaw), &prev); err != nil {
+				return fmt.Errorf("failed to decode run from redis: %w", err)
+			}
+			rec.StatusHistory = prev.StatusHistory
+		case err != goredis.Nil:
+			return fmt.Errorf("failed to load run from redis: %w", err)
+		}
+		rec.StatusHistory = state.AppendTransition(rec.StatusHistory, rec.Status, *rec.UpdatedAt)
+
+		runRaw, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("failed to marshal run: %w", err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Set(ctx, runKey, string(runRaw), s.ttl)
+			pipe.ZAdd(ctx, sessionIdx, goredis.Z{
+				Score:  updatedUnix,
+				Member: rec.RunID,
+			})
+			pipe.Expire(ctx, sessionIdx, s.ttl)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save run in redis: %w", err)
+		}
+		return nil
 	}
-	return nil
+	for attempt := 0; attempt < maxSaveRunAttempts; attempt++ {
+		err := s.client.Watch(ctx, txn, runKey)
+		if errors.Is(err, goredis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("failed to save run in redis: run %q kept changing during %d attempts", run.RunID, maxSaveRunAttempts)
 }
 
 func (s *Store) LoadRun(ctx context.Context, runID string) (state.RunRecord, error) {
@@ -223,6 +253,9 @@ func (s *Store) ListRuns(ctx context.Context, query state.ListRunsQuery) ([]stat
 		if query.Status != "" && run.Status != query.Status {
 			continue
 		}
+		if !state.MatchMetadata(run.Metadata, query.Metadata) {
+			continue
+		}
 		out = append(out, run)
 	}
 
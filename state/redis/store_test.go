@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -261,3 +262,40 @@ func BenchmarkRedisStore_SaveRun(b *testing.B) {
 		}
 	}
 }
+
+func TestRedisStore_SaveRunConcurrentKeepsStatusHistory(t *testing.T) {
+	s := newTestRedisStore(t)
+	ctx := context.Background()
+
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			at := time.Now().UTC()
+			errs <- s.SaveRun(ctx, state.RunRecord{
+				RunID:     "run-concurrent",
+				SessionID: "sess-concurrent",
+				Status:    fmt.Sprintf("step-%d", i),
+				UpdatedAt: &at,
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("SaveRun failed: %v", err)
+		}
+	}
+
+	got, err := s.LoadRun(ctx, "run-concurrent")
+	if err != nil {
+		t.Fatalf("LoadRun failed: %v", err)
+	}
+	if len(got.StatusHistory) != writers {
+		t.Fatalf("expected %d status transitions, got %d: %+v", writers, len(got.StatusHistory), got.StatusHistory)
+	}
+}
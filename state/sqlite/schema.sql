@@ -20,6 +20,16 @@ CREATE INDEX IF NOT EXISTS idx_runs_session_id ON runs (session_id);
 CREATE INDEX IF NOT EXISTS idx_runs_status ON runs (status);
 CREATE INDEX IF NOT EXISTS idx_runs_created_at ON runs (created_at DESC);
 
+CREATE TABLE IF NOT EXISTS run_status_transitions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  run_id TEXT NOT NULL,
+  status TEXT NOT NULL,
+  at TEXT NOT NULL,
+  FOREIGN KEY (run_id) REFERENCES runs(run_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_run_status_transitions_run_id ON run_status_transitions (run_id, id);
+
 CREATE TABLE IF NOT EXISTS checkpoints (
   run_id TEXT NOT NULL,
   seq INTEGER NOT NULL,
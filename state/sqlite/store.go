@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -128,7 +129,7 @@ func (s *Store) SaveRun(ctx context.Context, run state.RunRecord) error {
 		run.Provider = "unknown"
 	}
 	if run.Status == "" {
-		run.Status = "running"
+		run.Status = state.RunRunning
 	}
 
 	messagesRaw, err := json.Marshal(run.Messages)
@@ -165,7 +166,13 @@ ON CONFLICT(run_id) DO UPDATE SET
   completed_at=excluded.completed_at;
 `
 
-	_, err = s.db.ExecContext(
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin run tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(
 		ctx,
 		q,
 		run.RunID,
@@ -185,6 +192,18 @@ ON CONFLICT(run_id) DO UPDATE SET
 	if err != nil {
 		return fmt.Errorf("failed to save run: %w", err)
 	}
+
+	const transitionQ = `
+INSERT INTO run_status_transitions (run_id, status, at)
+SELECT ?, ?, ?
+WHERE COALESCE((SELECT status FROM run_status_transitions WHERE run_id = ? ORDER BY id DESC LIMIT 1), '') != ?;
+`
+	if _, err := tx.ExecContext(ctx, transitionQ, run.RunID, run.Status, toNullableTime(run.UpdatedAt), run.RunID, run.Status); err != nil {
+		return fmt.Errorf("failed to record run status transition: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit run: %w", err)
+	}
 	return nil
 }
 
@@ -234,9 +253,38 @@ WHERE run_id = ?;
 	if err != nil {
 		return state.RunRecord{}, err
 	}
+	if run.StatusHistory, err = s.loadStatusHistory(ctx, runID); err != nil {
+		return state.RunRecord{}, err
+	}
 	return run, nil
 }
 
+func (s *Store) loadStatusHistory(ctx context.Context, runID string) ([]state.StatusTransition, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT status, at FROM run_status_transitions WHERE run_id = ? ORDER BY id ASC;`, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load run status history: %w", err)
+	}
+	defer rows.Close()
+	var history []state.StatusTransition
+	for rows.Next() {
+		var (
+			t     state.StatusTransition
+			atRaw string
+		)
+		if err := rows.Scan(&t.Status, &atRaw); err != nil {
+			return nil, fmt.Errorf("failed to scan run status transition: %w", err)
+		}
+		if t.At, err = time.Parse(time.RFC3339Nano, atRaw); err != nil {
+			return nil, fmt.Errorf("failed to parse run status transition time: %w", err)
+		}
+		history = append(history, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate run status history: %w", err)
+	}
+	return history, nil
+}
+
 func (s *Store) ListRuns(ctx context.Context, query state.ListRunsQuery) ([]state.RunRecord, error) {
 	limit := query.Limit
 	if limit <= 0 {
@@ -259,6 +307,13 @@ func (s *Store) ListRuns(ctx context.Context, query state.ListRunsQuery) ([]stat
 		where = append(where, "status = ?")
 		args = append(args, query.Status)
 	}
+	for _, key := range sortedKeys(query.Metadata) {
+		// json_extract returns SQL values, so normalize booleans and
+		// numbers to the same text form state.MatchMetadata compares.
+		where = append(where, `(CASE json_type(metadata, ?) WHEN 'true' THEN 'true' WHEN 'false' THEN 'false' ELSE CAST(json_extract(metadata, ?) AS TEXT) END) = ?`)
+		path := metadataPath(key)
+		args = append(args, path, path, query.Metadata[key])
+	}
 
 	sqlText := `
 SELECT run_id, session_id, provider, status, input, output, messages, usage, metadata, error, created_at, updated_at, completed_at
@@ -530,3 +585,18 @@ func nullIfEmptyJSON(raw []byte) any {
 func isUniqueViolation(err error) bool {
 	return strings.Contains(strings.ToLower(err.Error()), "unique constraint failed")
 }
+
+// metadataPath builds a JSON path for a top-level metadata key, quoting it
+// so keys containing dots or brackets are matched literally.
+func metadataPath(key string) string {
+	return `$."` + strings.ReplaceAll(key, `"`, `\"`) + `"`
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"path/filepath"
+	"sort"
 	"testing"
 	"time"
 
@@ -184,3 +185,96 @@ func TestSQLiteStore_NotFound(t *testing.T) {
 		t.Fatalf("expected ErrNotFound for missing checkpoint, got %v", err)
 	}
 }
+
+func TestSQLiteStore_ListRunsByMetadata(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	runs := []state.RunRecord{
+		{RunID: "a", SessionID: "s", Status: "completed", Metadata: map[string]any{"example": "distributed_enqueue", "attempt": 1, "canceled": false}},
+		{RunID: "b", SessionID: "s", Status: "completed", Metadata: map[string]any{"example": "other", "attempt": 2}},
+		{RunID: "c", SessionID: "s", Status: "failed", Metadata: map[string]any{"example": "distributed_enqueue", "team.name": "ops"}},
+	}
+	for _, run := range runs {
+		run.CreatedAt, run.UpdatedAt = &now, &now
+		if err := s.SaveRun(ctx, run); err != nil {
+			t.Fatalf("SaveRun failed: %v", err)
+		}
+	}
+
+	ids := func(q state.ListRunsQuery) []string {
+		t.Helper()
+		got, err := s.ListRuns(ctx, q)
+		if err != nil {
+			t.Fatalf("ListRuns failed: %v", err)
+		}
+		out := make([]string, 0, len(got))
+		for _, r := range got {
+			out = append(out, r.RunID)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	if got := ids(state.ListRunsQuery{Metadata: map[string]string{"example": "distributed_enqueue"}}); len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Fatalf("by example = %v", got)
+	}
+	if got := ids(state.ListRunsQuery{Status: "completed", Metadata: map[string]string{"example": "distributed_enqueue"}}); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("by example+status = %v", got)
+	}
+	if got := ids(state.ListRunsQuery{Metadata: map[string]string{"attempt": "2"}}); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("by numeric attempt = %v", got)
+	}
+	if got := ids(state.ListRunsQuery{Metadata: map[string]string{"canceled": "false"}}); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("by bool = %v", got)
+	}
+	if got := ids(state.ListRunsQuery{Metadata: map[string]string{"team.name": "ops"}}); len(got) != 1 || got[0] != "c" {
+		t.Fatalf("by dotted key = %v", got)
+	}
+	if !state.MatchMetadata(runs[0].Metadata, map[string]string{"attempt": "1", "canceled": "false"}) {
+		t.Fatal("MatchMetadata disagrees with sqlite filter")
+	}
+}
+
+func TestSQLiteStore_StatusLifecycle(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, status := range []string{state.RunQueued, state.RunRunning, state.RunRunning} {
+		at := base.Add(time.Duration(i) * time.Second)
+		if err := s.SaveRun(ctx, state.RunRecord{RunID: "run-1", SessionID: "sess", Status: status, UpdatedAt: &at}); err != nil {
+			t.Fatalf("SaveRun %s: %v", status, err)
+		}
+	}
+	if _, err := state.TransitionRun(ctx, s, "run-1", state.RunCompleted, ""); err != nil {
+		t.Fatalf("TransitionRun completed: %v", err)
+	}
+	if _, err := state.TransitionRun(ctx, s, "run-1", state.RunRunning, ""); !errors.Is(err, state.ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+
+	run, err := s.LoadRun(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("LoadRun: %v", err)
+	}
+	var got []string
+	for _, tr := range run.StatusHistory {
+		got = append(got, tr.Status)
+	}
+	if len(got) != 3 || got[0] != state.RunQueued || got[1] != state.RunRunning || got[2] != state.RunCompleted {
+		t.Fatalf("status history = %v", got)
+	}
+	if !run.StatusHistory[1].At.Equal(base.Add(time.Second)) || run.CompletedAt == nil {
+		t.Fatalf("unexpected timestamps: %+v, completedAt=%v", run.StatusHistory, run.CompletedAt)
+	}
+
+	if err := s.SaveRun(ctx, state.RunRecord{RunID: "run-2", SessionID: "sess", Status: state.RunFailed}); err != nil {
+		t.Fatalf("SaveRun run-2: %v", err)
+	}
+	failed, err := state.ListRunsByStatus(ctx, s, "FAILED", 10, 0)
+	if err != nil || len(failed) != 1 || failed[0].RunID != "run-2" {
+		t.Fatalf("ListRunsByStatus failed = %+v, %v", failed, err)
+	}
+}
@@ -0,0 +1,121 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Run statuses. A run normally moves queued → running → completed or
+// failed; a failed run may be re-queued or resumed, and a queued or running
+// run may be canceled. Completed and canceled are final.
+const (
+	RunQueued    = "queued"
+	RunRunning   = "running"
+	RunCompleted = "completed"
+	RunFailed    = "failed"
+	RunCanceled  = "canceled"
+)
+
+// ErrInvalidTransition is returned by TransitionRun for a status change the
+// lifecycle does not allow.
+var ErrInvalidTransition = errors.New("state: invalid run status transition")
+
+// StatusTransition records when a run entered a status.
+type StatusTransition struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+var allowedTransitions = map[string][]string{
+	RunQueued:  {RunRunning, RunFailed, RunCanceled},
+	RunRunning: {RunQueued, RunCompleted, RunFailed, RunCanceled},
+	RunFailed:  {RunQueued, RunRunning},
+}
+
+// NormalizeRunStatus lowercases status and maps the "cancelled" spelling to
+// RunCanceled.
+func NormalizeRunStatus(status string) string {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status == "cancelled" {
+		return RunCanceled
+	}
+	return status
+}
+
+// IsTerminalStatus reports whether a run in status can no longer change.
+func IsTerminalStatus(status string) bool {
+	switch NormalizeRunStatus(status) {
+	case RunCompleted, RunCanceled:
+		return true
+	}
+	return false
+}
+
+// CanTransition reports whether a run may move from one status to another.
+// Any status is allowed for a new run (empty from), and saving the same
+// status again is always allowed.
+func CanTransition(from, to string) bool {
+	from, to = NormalizeRunStatus(from), NormalizeRunStatus(to)
+	if from == "" || from == to {
+		return true
+	}
+	for _, next := range allowedTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendTransition returns history with status entered at appended, unless
+// status is already the latest entry. Stores call it from SaveRun so every
+// status change is kept with its timestamp.
+func AppendTransition(history []StatusTransition, status string, at time.Time) []StatusTransition {
+	if status == "" {
+		return history
+	}
+	if n := len(history); n > 0 && history[n-1].Status == status {
+		return history
+	}
+	return append(history, StatusTransition{Status: status, At: at.UTC()})
+}
+
+// TransitionRun moves a stored run to status, enforcing the lifecycle. errText
+// is recorded for failed runs. Terminal statuses set CompletedAt.
+func TransitionRun(ctx context.Context, store Store, runID, status, errText string) (RunRecord, error) {
+	run, err := store.LoadRun(ctx, runID)
+	if err != nil {
+		return RunRecord{}, err
+	}
+	status = NormalizeRunStatus(status)
+	if !CanTransition(run.Status, status) {
+		return RunRecord{}, fmt.Errorf("%w: %s → %s", ErrInvalidTransition, run.Status, status)
+	}
+	now := time.Now().UTC()
+	run.Status = status
+	run.UpdatedAt = &now
+	if status == RunFailed || errText != "" {
+		run.Error = errText
+	}
+	if IsTerminalStatus(status) || status == RunFailed {
+		run.CompletedAt = &now
+	} else {
+		run.CompletedAt = nil
+	}
+	if err := store.SaveRun(ctx, run); err != nil {
+		return RunRecord{}, err
+	}
+	return store.LoadRun(ctx, runID)
+}
+
+// ListRunsByStatus lists runs in status, newest first.
+func ListRunsByStatus(ctx context.Context, store Store, status string, limit, offset int) ([]RunRecord, error) {
+	status = NormalizeRunStatus(status)
+	if status == "" {
+		return nil, fmt.Errorf("status is required")
+	}
+	return store.ListRuns(ctx, ListRunsQuery{Status: status, Limit: limit, Offset: offset})
+}
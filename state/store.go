@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	"errors"
+	"fmt"
 )
 
 var (
@@ -15,6 +16,20 @@ type ListRunsQuery struct {
 	Limit     int
 	Offset    int
 	Status    string
+	// Metadata keeps only runs whose metadata has every key with a value
+	// whose string form (fmt %v) equals the given value.
+	Metadata map[string]string
+}
+
+// MatchMetadata reports whether metadata satisfies every key/value filter.
+func MatchMetadata(metadata map[string]any, filter map[string]string) bool {
+	for key, want := range filter {
+		got, ok := metadata[key]
+		if !ok || got == nil || fmt.Sprint(got) != want {
+			return false
+		}
+	}
+	return true
 }
 
 type Store interface {
@@ -20,6 +20,9 @@ type RunRecord struct {
 	CreatedAt   *time.Time      `json:"createdAt,omitempty"`
 	UpdatedAt   *time.Time      `json:"updatedAt,omitempty"`
 	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+	// StatusHistory is maintained by the store: each SaveRun that changes
+	// Status appends a transition. ListRuns results may omit it.
+	StatusHistory []StatusTransition `json:"statusHistory,omitempty"`
 }
 
 type CheckpointRecord struct {
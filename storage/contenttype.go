@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// sniffLen is how much of the content detectors see, matching
+// http.DetectContentType.
+const sniffLen = 512
+
+// ContentTypeDetector returns the MIME type for content starting with head,
+// or "" if unknown. head holds at most 512 bytes.
+type ContentTypeDetector func(head []byte) string
+
+// DetectContentType is the default detector. It recognizes JSON by its
+// leading brace or bracket and defers everything else to
+// http.DetectContentType.
+func DetectContentType(head []byte) string {
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return "application/json"
+	}
+	if len(trimmed) == 0 {
+		return ""
+	}
+	return http.DetectContentType(head)
+}
+
+// preferredExtensions picks one extension where mime lists several, or
+// where the system MIME table may lack an entry.
+var preferredExtensions = map[string]string{
+	"application/gzip":       ".gz",
+	"application/javascript": ".js",
+	"application/json":       ".json",
+	"application/pdf":        ".pdf",
+	"application/x-gzip":     ".gz",
+	"application/x-yaml":     ".yaml",
+	"application/xml":        ".xml",
+	"application/yaml":       ".yaml",
+	"application/zip":        ".zip",
+	"image/gif":              ".gif",
+	"image/jpeg":             ".jpg",
+	"image/png":              ".png",
+	"image/svg+xml":          ".svg",
+	"text/csv":               ".csv",
+	"text/html":              ".html",
+	"text/markdown":          ".md",
+	"text/plain":             ".txt",
+	"text/xml":               ".xml",
+	"text/yaml":              ".yaml",
+}
+
+// extensionlessNames are conventional file names that never take an
+// extension, keyed in lower case.
+var extensionlessNames = map[string]bool{
+	"authors":       true,
+	"brewfile":      true,
+	"changelog":     true,
+	"codeowners":    true,
+	"containerfile": true,
+	"contributing":  true,
+	"copying":       true,
+	"dockerfile":    true,
+	"gemfile":       true,
+	"jenkinsfile":   true,
+	"license":       true,
+	"makefile":      true,
+	"notice":        true,
+	"procfile":      true,
+	"rakefile":      true,
+	"readme":        true,
+	"vagrantfile":   true,
+}
+
+// ExtensionForContentType returns the file extension (with dot) for a MIME
+// type, or "" when there is none. Parameters such as charset are ignored.
+func ExtensionForContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		return ""
+	}
+	if ext, ok := preferredExtensions[mediaType]; ok {
+		return ext
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// applyContentType adds an extension to path when it has none, using the
+// declared content type or, failing that, the detector on r's first bytes.
+// Well-known extensionless names such as Dockerfile or LICENSE are left
+// as they are. It returns the path, the reader to copy from (sniffed bytes
+// are not lost), and the content type.
+func (m *Manager) applyContentType(path, declared string, r io.Reader) (string, io.Reader, string) {
+	if ext := filepath.Ext(path); ext != "" {
+		if declared == "" {
+			declared = mime.TypeByExtension(ext)
+		}
+		return path, r, declared
+	}
+	contentType := declared
+	if contentType == "" {
+		br := bufio.NewReaderSize(r, sniffLen)
+		head, _ := br.Peek(sniffLen)
+		r = br
+		detect := DetectContentType
+		if m != nil && m.detector != nil {
+			detect = m.detector
+		}
+		contentType = detect(head)
+	}
+	if extensionlessNames[strings.ToLower(filepath.Base(path))] {
+		return path, r, contentType
+	}
+	return path + ExtensionForContentType(contentType), r, contentType
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"strings"
+)
+
+type contextKey string
+
+const runIDContextKey contextKey = "storage.run_id"
+
+// WithRunID stores the run ID that per-run artifact dirs are keyed by.
+func WithRunID(ctx context.Context, runID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	runID = strings.TrimSpace(runID)
+	if runID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, runIDContextKey, runID)
+}
+
+// RunIDFromContext returns the run ID set by WithRunID, or "".
+func RunIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	v, _ := ctx.Value(runIDContextKey).(string)
+	return v
+}
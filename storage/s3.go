@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -44,13 +45,26 @@ func (u *s3Uploader) UploadFile(ctx context.Context, localPath string) (*BackupI
 		return nil, err
 	}
 
-	key := u.objectKey(localPath)
+	return u.copy(ctx, localPath, localPath, nil)
+}
+
+// UploadReader streams r to S3 via "aws s3 cp -" so no local file is needed.
+func (u *s3Uploader) UploadReader(ctx context.Context, path string, r io.Reader) (*BackupInfo, error) {
+	if u == nil {
+		return nil, fmt.Errorf("s3 uploader not configured")
+	}
+	return u.copy(ctx, path, "-", r)
+}
+
+func (u *s3Uploader) copy(ctx context.Context, path, src string, stdin io.Reader) (*BackupInfo, error) {
+	key := u.objectKey(path)
 	uri := fmt.Sprintf("s3://%s/%s", u.bucket, key)
-	args := []string{"s3", "cp", localPath, uri, "--only-show-errors"}
+	args := []string{"s3", "cp", src, uri, "--only-show-errors"}
 	if strings.TrimSpace(u.endpoint) != "" {
 		args = append(args, "--endpoint-url", strings.TrimSpace(u.endpoint))
 	}
 	cmd := exec.CommandContext(ctx, "aws", args...)
+	cmd.Stdin = stdin
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("aws s3 cp failed: %w: %s", err, strings.TrimSpace(string(out)))
@@ -1,12 +1,20 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/observe"
 )
 
 type BackupInfo struct {
@@ -18,18 +26,130 @@ type BackupInfo struct {
 }
 
 type SaveResult struct {
-	Path   string      `json:"path"`
-	Bytes  int         `json:"bytes"`
-	Backup *BackupInfo `json:"backup,omitempty"`
+	Path        string      `json:"path"`
+	Bytes       int         `json:"bytes"`
+	ContentType string      `json:"contentType,omitempty"`
+	Backup      *BackupInfo `json:"backup,omitempty"`
 }
 
 type BackupUploader interface {
 	UploadFile(ctx context.Context, localPath string) (*BackupInfo, error)
 }
 
+// StreamUploader is implemented by uploaders that can write an object
+// directly from a reader without a local file. path is the logical output
+// path (under the manager's base dir) used to derive the object key.
+type StreamUploader interface {
+	UploadReader(ctx context.Context, path string, r io.Reader) (*BackupInfo, error)
+}
+
+// Mode selects where saved artifacts are written.
+type Mode string
+
+const (
+	// ModeLocal writes to the local base dir and optionally backs up remotely.
+	ModeLocal Mode = "local"
+	// ModeRemote uploads straight to the configured StreamUploader without
+	// touching the local filesystem.
+	ModeRemote Mode = "remote"
+)
+
+// CollisionPolicy controls what happens when the output path already exists.
+type CollisionPolicy string
+
+const (
+	// CollisionSuffix keeps the existing file and writes to "name-2.ext",
+	// "name-3.ext", ... instead. This is the default.
+	CollisionSuffix CollisionPolicy = "suffix"
+	// CollisionOverwrite replaces the existing file.
+	CollisionOverwrite CollisionPolicy = "overwrite"
+	// CollisionError fails with ErrFileExists.
+	CollisionError CollisionPolicy = "error"
+)
+
+var ErrFileExists = errors.New("storage: file already exists")
+
+const maxCollisionSuffix = 10000
+
+// runsDir is the base-dir subdirectory that holds per-run dirs.
+const runsDir = "runs"
+
+type SaveOption func(*saveOptions)
+
+type saveOptions struct {
+	collision   CollisionPolicy
+	contentType string
+}
+
+// WithCollisionPolicy selects how SaveBytes handles an existing file at the
+// output path. It has no effect in ModeRemote.
+func WithCollisionPolicy(p CollisionPolicy) SaveOption {
+	return func(o *saveOptions) { o.collision = p }
+}
+
+// WithContentType declares the artifact's MIME type. When the output name
+// has no extension, the matching one is appended instead of sniffing the
+// content.
+func WithContentType(contentType string) SaveOption {
+	return func(o *saveOptions) { o.contentType = strings.TrimSpace(contentType) }
+}
+
 type Manager struct {
-	baseDir  string
-	uploader BackupUploader
+	baseDir   string
+	uploader  BackupUploader
+	mode      Mode
+	collision CollisionPolicy
+	perRun    bool
+	detector  ContentTypeDetector
+
+	observerMu sync.RWMutex
+	observer   observe.Sink
+}
+
+type Option func(*Manager)
+
+// WithUploader sets the uploader used for backups (ModeLocal) or direct
+// uploads (ModeRemote).
+func WithUploader(u BackupUploader) Option {
+	return func(m *Manager) { m.uploader = u }
+}
+
+// WithMode selects local or direct-to-remote writes.
+func WithMode(mode Mode) Option {
+	return func(m *Manager) { m.mode = mode }
+}
+
+// WithDefaultCollisionPolicy sets the policy used when SaveBytes is called
+// without WithCollisionPolicy.
+func WithDefaultCollisionPolicy(p CollisionPolicy) Option {
+	return func(m *Manager) { m.collision = p }
+}
+
+// WithPerRunDirs writes relative paths under RunDir for the run ID on the
+// save context (see WithRunID), isolating concurrent runs. Saves without a
+// run ID still go to the base dir.
+func WithPerRunDirs() Option {
+	return func(m *Manager) { m.perRun = true }
+}
+
+// WithContentTypeDetector replaces DetectContentType for names saved
+// without an extension.
+func WithContentTypeDetector(d ContentTypeDetector) Option {
+	return func(m *Manager) { m.detector = d }
+}
+
+// WithObserver emits a KindArtifact event for every save; see SetObserver.
+func WithObserver(observer observe.Sink) Option {
+	return func(m *Manager) { m.observer = observer }
+}
+
+// New returns a Manager rooted at baseDir.
+func New(baseDir string, opts ...Option) *Manager {
+	mgr := &Manager{baseDir: strings.TrimSpace(baseDir), mode: ModeLocal}
+	for _, opt := range opts {
+		opt(mgr)
+	}
+	return mgr
 }
 
 var (
@@ -49,13 +169,43 @@ func NewFromEnv() *Manager {
 	if baseDir == "" {
 		baseDir = "./.ai-agent/generated"
 	}
-	mgr := &Manager{baseDir: baseDir}
+	mgr := &Manager{baseDir: baseDir, mode: ModeLocal}
 	if uploader, err := newS3UploaderFromEnv(baseDir); err == nil {
 		mgr.uploader = uploader
 	}
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("AGENT_STORAGE_MODE")), string(ModeRemote)) {
+		mgr.mode = ModeRemote
+	}
+	if v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("AGENT_STORAGE_PER_RUN"))); err == nil {
+		mgr.perRun = v
+	}
+	switch p := CollisionPolicy(strings.ToLower(strings.TrimSpace(os.Getenv("AGENT_STORAGE_ON_COLLISION")))); p {
+	case CollisionSuffix, CollisionOverwrite, CollisionError:
+		mgr.collision = p
+	}
 	return mgr
 }
 
+// Mode reports whether the manager writes locally or directly to remote storage.
+func (m *Manager) Mode() Mode {
+	if m == nil || m.mode == "" {
+		return ModeLocal
+	}
+	return m.mode
+}
+
+// SetObserver sets the sink that receives a KindArtifact event for every
+// save, carrying the path, size, and backup location. The event's RunID
+// comes from the save context (see WithRunID). Safe to call on Default.
+func (m *Manager) SetObserver(observer observe.Sink) {
+	if m == nil {
+		return
+	}
+	m.observerMu.Lock()
+	m.observer = observer
+	m.observerMu.Unlock()
+}
+
 func (m *Manager) BaseDir() string {
 	if m == nil {
 		return "./.ai-agent/generated"
@@ -66,15 +216,44 @@ func (m *Manager) BaseDir() string {
 	return m.baseDir
 }
 
-func (m *Manager) SaveBytes(ctx context.Context, requestedPath, defaultFileName string, content []byte) (SaveResult, error) {
-	path := m.resolveOutputPath(requestedPath, defaultFileName)
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+func (m *Manager) SaveBytes(ctx context.Context, requestedPath, defaultFileName string, content []byte, opts ...SaveOption) (SaveResult, error) {
+	return m.SaveReader(ctx, requestedPath, defaultFileName, bytes.NewReader(content), opts...)
+}
+
+// SaveReader streams r to the output path without buffering it in memory.
+// The backup upload (or the direct upload in ModeRemote) reads from the
+// written file or from r respectively. Bytes is the number of bytes copied.
+// Every save, successful or not, is reported to the observer if one is set.
+func (m *Manager) SaveReader(ctx context.Context, requestedPath, defaultFileName string, r io.Reader, opts ...SaveOption) (SaveResult, error) {
+	started := time.Now()
+	result, err := m.saveReader(ctx, requestedPath, defaultFileName, r, opts...)
+	m.emitSave(ctx, result, err, time.Since(started))
+	return result, err
+}
+
+func (m *Manager) saveReader(ctx context.Context, requestedPath, defaultFileName string, r io.Reader, opts ...SaveOption) (SaveResult, error) {
+	o := m.saveOptions(opts)
+	path := m.resolveOutputPath(m.outputDir(ctx), requestedPath, defaultFileName)
+	path, r, contentType := m.applyContentType(path, o.contentType, r)
+	if m.Mode() == ModeRemote {
+		result, err := m.uploadDirect(ctx, path, r)
+		result.ContentType = contentType
+		return result, err
+	}
+	f, path, err := createOutputFile(path, o.collision)
+	if err != nil {
 		return SaveResult{}, err
 	}
-	if err := os.WriteFile(path, content, 0644); err != nil {
+	n, err := io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
 		return SaveResult{}, err
 	}
-	result := SaveResult{Path: path, Bytes: len(content)}
+	if err := f.Close(); err != nil {
+		return SaveResult{}, err
+	}
+	result := SaveResult{Path: path, Bytes: int(n), ContentType: contentType}
 	if m != nil && m.uploader != nil {
 		backup, err := m.uploader.UploadFile(ctx, path)
 		if err != nil {
@@ -86,8 +265,152 @@ func (m *Manager) SaveBytes(ctx context.Context, requestedPath, defaultFileName
 	return result, nil
 }
 
-func (m *Manager) resolveOutputPath(requestedPath, defaultFileName string) string {
-	base := m.BaseDir()
+func (m *Manager) emitSave(ctx context.Context, result SaveResult, saveErr error, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	m.observerMu.RLock()
+	observer := m.observer
+	m.observerMu.RUnlock()
+	if observer == nil {
+		return
+	}
+	event := observe.Event{
+		Timestamp:  time.Now().UTC(),
+		RunID:      RunIDFromContext(ctx),
+		Kind:       observe.KindArtifact,
+		Status:     observe.StatusCompleted,
+		Name:       "storage.save",
+		DurationMs: elapsed.Milliseconds(),
+	}
+	event.SetAttr("mode", string(m.Mode())).SetAttr("bytes", result.Bytes)
+	if result.Path != "" {
+		event.SetAttr("path", result.Path)
+	}
+	if result.ContentType != "" {
+		event.SetAttr("content_type", result.ContentType)
+	}
+	if saveErr != nil {
+		event.Status = observe.StatusFailed
+		event.Error = saveErr.Error()
+	}
+	if b := result.Backup; b != nil {
+		event.SetAttr("backup_provider", b.Provider)
+		if b.Key != "" {
+			event.SetAttr("backup_key", b.Key)
+		}
+		if b.URL != "" {
+			event.SetAttr("backup_url", b.URL)
+		}
+		if b.Error != "" {
+			event.SetAttr("backup_error", b.Error)
+		}
+	}
+	_ = observer.Emit(ctx, event)
+}
+
+func (m *Manager) saveOptions(opts []SaveOption) saveOptions {
+	o := saveOptions{collision: CollisionSuffix}
+	if m != nil && m.collision != "" {
+		o.collision = m.collision
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// createOutputFile opens the file to write according to the collision
+// policy and returns it along with the path actually used.
+func createOutputFile(path string, policy CollisionPolicy) (*os.File, string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, "", err
+	}
+	switch policy {
+	case CollisionOverwrite:
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		return f, path, err
+	case CollisionError:
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			return nil, "", fmt.Errorf("%w: %s", ErrFileExists, path)
+		}
+		return f, path, err
+	default:
+		ext := filepath.Ext(path)
+		stem := strings.TrimSuffix(path, ext)
+		candidate := path
+		for i := 2; i <= maxCollisionSuffix; i++ {
+			f, err := os.OpenFile(candidate, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+			if err == nil {
+				return f, candidate, nil
+			}
+			if !errors.Is(err, os.ErrExist) {
+				return nil, "", err
+			}
+			candidate = fmt.Sprintf("%s-%d%s", stem, i, ext)
+		}
+		return nil, "", fmt.Errorf("%w: no free name for %s", ErrFileExists, path)
+	}
+}
+
+// uploadDirect streams r to the remote uploader; the result carries only the
+// remote location.
+func (m *Manager) uploadDirect(ctx context.Context, path string, r io.Reader) (SaveResult, error) {
+	if m == nil || m.uploader == nil {
+		return SaveResult{}, fmt.Errorf("remote storage mode requires an uploader")
+	}
+	su, ok := m.uploader.(StreamUploader)
+	if !ok {
+		return SaveResult{}, fmt.Errorf("uploader %T does not support direct uploads", m.uploader)
+	}
+	cr := &countingReader{r: r}
+	backup, err := su.UploadReader(ctx, path, cr)
+	if err != nil {
+		return SaveResult{}, err
+	}
+	return SaveResult{Bytes: int(cr.n), Backup: backup}, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// RunDir is the directory holding runID's artifacts when per-run dirs are
+// enabled.
+func (m *Manager) RunDir(runID string) string {
+	return filepath.Join(m.BaseDir(), runsDir, sanitizeFileName(runID))
+}
+
+// Prune deletes every artifact saved locally for runID. A run with no
+// artifacts is not an error.
+func (m *Manager) Prune(runID string) error {
+	if strings.TrimSpace(runID) == "" {
+		return fmt.Errorf("run id is required")
+	}
+	if err := os.RemoveAll(m.RunDir(runID)); err != nil {
+		return fmt.Errorf("failed to prune run %q: %w", runID, err)
+	}
+	return nil
+}
+
+func (m *Manager) outputDir(ctx context.Context) string {
+	if m != nil && m.perRun {
+		if runID := RunIDFromContext(ctx); runID != "" {
+			return m.RunDir(runID)
+		}
+	}
+	return m.BaseDir()
+}
+
+func (m *Manager) resolveOutputPath(base, requestedPath, defaultFileName string) string {
 	requested := strings.TrimSpace(requestedPath)
 	if requested == "" {
 		return filepath.Join(base, sanitizeFileName(defaultFileName))
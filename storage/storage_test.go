@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/observe"
+)
+
+type fakeUploader struct {
+	paths   []string
+	content []string
+}
+
+func (f *fakeUploader) UploadFile(_ context.Context, localPath string) (*BackupInfo, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return nil, err
+	}
+	f.paths = append(f.paths, localPath)
+	f.content = append(f.content, string(data))
+	return &BackupInfo{Provider: "fake", Key: filepath.Base(localPath)}, nil
+}
+
+func (f *fakeUploader) UploadReader(_ context.Context, path string, r io.Reader) (*BackupInfo, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.paths = append(f.paths, path)
+	f.content = append(f.content, string(data))
+	return &BackupInfo{Provider: "fake", Key: filepath.Base(path), URL: "fake://" + filepath.Base(path)}, nil
+}
+
+func TestSaveBytes_LocalWithBackup(t *testing.T) {
+	dir := t.TempDir()
+	up := &fakeUploader{}
+	mgr := New(dir, WithUploader(up))
+
+	res, err := mgr.SaveBytes(context.Background(), "report.txt", "", []byte("hello"))
+	if err != nil {
+		t.Fatalf("SaveBytes failed: %v", err)
+	}
+	if res.Path != filepath.Join(dir, "report.txt") || res.Bytes != 5 {
+		t.Errorf("result = %+v", res)
+	}
+	if data, err := os.ReadFile(res.Path); err != nil || string(data) != "hello" {
+		t.Errorf("local file = %q, %v", data, err)
+	}
+	if res.Backup == nil || res.Backup.Provider != "fake" || len(up.paths) != 1 {
+		t.Errorf("backup = %+v, uploads = %v", res.Backup, up.paths)
+	}
+}
+
+func TestSaveBytes_RemoteMode(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "never-created")
+	up := &fakeUploader{}
+	mgr := New(dir, WithUploader(up), WithMode(ModeRemote))
+
+	res, err := mgr.SaveBytes(context.Background(), "", "out.txt", []byte("remote"))
+	if err != nil {
+		t.Fatalf("SaveBytes failed: %v", err)
+	}
+	if res.Path != "" {
+		t.Errorf("Path = %q, want empty in remote mode", res.Path)
+	}
+	if res.Backup == nil || res.Backup.URL != "fake://out.txt" || res.Bytes != 6 {
+		t.Errorf("result = %+v", res)
+	}
+	if len(up.content) != 1 || up.content[0] != "remote" {
+		t.Errorf("uploaded = %v", up.content)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("remote mode touched local disk: %v", err)
+	}
+}
+
+func TestSaveBytes_RemoteModeRequiresStreamUploader(t *testing.T) {
+	mgr := New(t.TempDir(), WithMode(ModeRemote))
+	if _, err := mgr.SaveBytes(context.Background(), "", "x.txt", []byte("x")); err == nil {
+		t.Error("expected error without uploader")
+	}
+}
+
+func TestSaveBytes_CollisionPolicies(t *testing.T) {
+	dir := t.TempDir()
+	mgr := New(dir)
+	ctx := context.Background()
+
+	first, err := mgr.SaveBytes(ctx, "report.txt", "", []byte("one"))
+	if err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	second, err := mgr.SaveBytes(ctx, "report.txt", "", []byte("two"))
+	if err != nil {
+		t.Fatalf("second save: %v", err)
+	}
+	if second.Path != filepath.Join(dir, "report-2.txt") {
+		t.Errorf("default suffix path = %q", second.Path)
+	}
+	if data, _ := os.ReadFile(first.Path); string(data) != "one" {
+		t.Errorf("original clobbered: %q", data)
+	}
+
+	if _, err := mgr.SaveBytes(ctx, "report.txt", "", []byte("x"), WithCollisionPolicy(CollisionError)); !errors.Is(err, ErrFileExists) {
+		t.Errorf("error policy err = %v, want ErrFileExists", err)
+	}
+
+	over, err := mgr.SaveBytes(ctx, "report.txt", "", []byte("three"), WithCollisionPolicy(CollisionOverwrite))
+	if err != nil {
+		t.Fatalf("overwrite save: %v", err)
+	}
+	if data, _ := os.ReadFile(over.Path); over.Path != first.Path || string(data) != "three" {
+		t.Errorf("overwrite path=%q content=%q", over.Path, data)
+	}
+}
+
+func TestSaveReader(t *testing.T) {
+	dir := t.TempDir()
+	up := &fakeUploader{}
+	mgr := New(dir, WithUploader(up))
+	payload := strings.Repeat("x", 1<<20)
+
+	res, err := mgr.SaveReader(context.Background(), "", "big.bin", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("SaveReader failed: %v", err)
+	}
+	if res.Bytes != len(payload) {
+		t.Errorf("Bytes = %d, want %d", res.Bytes, len(payload))
+	}
+	if info, err := os.Stat(res.Path); err != nil || info.Size() != int64(len(payload)) {
+		t.Errorf("file size = %v, %v", info, err)
+	}
+	if len(up.content) != 1 || len(up.content[0]) != len(payload) {
+		t.Error("backup did not receive full content")
+	}
+
+	remote := New(dir, WithUploader(&fakeUploader{}), WithMode(ModeRemote))
+	res, err = remote.SaveReader(context.Background(), "", "big.bin", strings.NewReader(payload))
+	if err != nil || res.Bytes != len(payload) || res.Path != "" {
+		t.Errorf("remote SaveReader = %+v, %v", res, err)
+	}
+}
+
+func TestPerRunDirsAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	mgr := New(dir, WithPerRunDirs())
+
+	ctxA := WithRunID(context.Background(), "run-a")
+	ctxB := WithRunID(context.Background(), "run-b")
+	a, err := mgr.SaveBytes(ctxA, "out/report.txt", "", []byte("a"))
+	if err != nil {
+		t.Fatalf("SaveBytes run-a failed: %v", err)
+	}
+	b, err := mgr.SaveBytes(ctxB, "out/report.txt", "", []byte("b"))
+	if err != nil {
+		t.Fatalf("SaveBytes run-b failed: %v", err)
+	}
+	if a.Path != filepath.Join(mgr.RunDir("run-a"), "out", "report.txt") || b.Path == a.Path {
+		t.Fatalf("expected isolated run paths, got %q and %q", a.Path, b.Path)
+	}
+	shared, err := mgr.SaveBytes(context.Background(), "shared.txt", "", []byte("s"))
+	if err != nil || shared.Path != filepath.Join(dir, "shared.txt") {
+		t.Fatalf("expected save without run id in base dir, got %+v, %v", shared, err)
+	}
+
+	if err := mgr.Prune("run-a"); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if _, err := os.Stat(a.Path); !os.IsNotExist(err) {
+		t.Errorf("expected run-a artifacts removed, stat err = %v", err)
+	}
+	for _, p := range []string{b.Path, shared.Path} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("expected %s kept: %v", p, err)
+		}
+	}
+	if err := mgr.Prune(""); err == nil {
+		t.Error("expected error for empty run id")
+	}
+}
+
+func TestSaveEmitsArtifactEvent(t *testing.T) {
+	dir := t.TempDir()
+	var events []observe.Event
+	sink := observe.SinkFunc(func(_ context.Context, e observe.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	mgr := New(dir, WithUploader(&fakeUploader{}), WithObserver(sink))
+
+	ctx := WithRunID(context.Background(), "run-1")
+	res, err := mgr.SaveBytes(ctx, "notes.txt", "", []byte("hello"))
+	if err != nil {
+		t.Fatalf("SaveBytes failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	e := events[0]
+	if e.Kind != observe.KindArtifact || e.Status != observe.StatusCompleted || e.RunID != "run-1" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if e.Attributes["path"] != res.Path || e.Attributes["bytes"] != 5 || e.Attributes["backup_provider"] != "fake" {
+		t.Fatalf("unexpected attributes %+v", e.Attributes)
+	}
+
+	_, err = New(dir, WithMode(ModeRemote), WithObserver(sink)).SaveBytes(ctx, "x.txt", "", []byte("x"))
+	if err == nil || len(events) != 2 || events[1].Status != observe.StatusFailed || events[1].Error == "" {
+		t.Fatalf("expected failed event, got err=%v events=%+v", err, events)
+	}
+}
+
+func TestSaveAppendsExtensionForContentType(t *testing.T) {
+	dir := t.TempDir()
+	mgr := New(dir)
+	ctx := context.Background()
+
+	res, err := mgr.SaveBytes(ctx, "report", "", []byte(`  {"ok": true}`))
+	if err != nil || res.Path != filepath.Join(dir, "report.json") || res.ContentType != "application/json" {
+		t.Fatalf("sniffed JSON = %+v, %v", res, err)
+	}
+	if data, _ := os.ReadFile(res.Path); string(data) != `  {"ok": true}` {
+		t.Fatalf("sniffing lost content: %q", data)
+	}
+	res, err = mgr.SaveBytes(ctx, "", "notes", []byte("# Title"), WithContentType("text/markdown; charset=utf-8"))
+	if err != nil || res.Path != filepath.Join(dir, "notes.md") {
+		t.Fatalf("declared markdown = %+v, %v", res, err)
+	}
+	res, err = mgr.SaveBytes(ctx, "data.csv", "", []byte(`{"not":"csv"}`))
+	if err != nil || res.Path != filepath.Join(dir, "data.csv") {
+		t.Fatalf("existing extension should be kept, got %+v, %v", res, err)
+	}
+
+	custom := New(dir, WithContentTypeDetector(func([]byte) string { return "text/csv" }))
+	res, err = custom.SaveBytes(ctx, "rows", "", []byte("a,b\n1,2\n"))
+	if err != nil || res.Path != filepath.Join(dir, "rows.csv") {
+		t.Fatalf("custom detector = %+v, %v", res, err)
+	}
+	for _, name := range []string{"Dockerfile", "Makefile", "LICENSE"} {
+		res, err = mgr.SaveBytes(ctx, name, "", []byte("plain text\n"))
+		if err != nil || res.Path != filepath.Join(dir, name) || res.ContentType == "" {
+			t.Fatalf("%s = %+v, %v; want the name kept", name, res, err)
+		}
+	}
+	if got := ExtensionForContentType("application/octet-stream"); got != "" {
+		t.Errorf("octet-stream extension = %q, want none", got)
+	}
+}
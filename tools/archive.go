@@ -152,7 +152,7 @@ func archiveCreate(ctx context.Context, in archiveArgs) (*archiveResult, error)
 			args = append(args, in.Source)
 		}
 		args = append(args, in.Files...)
-		cmd = exec.CommandContext(ctx, "zip", args...)
+		cmd = commandContext(ctx, "zip", args...)
 	} else {
 		flag := "cf" + tarFlag(format)
 		args := []string{flag, in.Archive}
@@ -160,7 +160,7 @@ func archiveCreate(ctx context.Context, in archiveArgs) (*archiveResult, error)
 			args = append(args, in.Source)
 		}
 		args = append(args, in.Files...)
-		cmd = exec.CommandContext(ctx, "tar", args...)
+		cmd = commandContext(ctx, "tar", args...)
 	}
 
 	var stderr bytes.Buffer
@@ -189,10 +189,10 @@ func archiveExtract(ctx context.Context, in archiveArgs) (*archiveResult, error)
 
 	var cmd *exec.Cmd
 	if format == "zip" {
-		cmd = exec.CommandContext(ctx, "unzip", "-o", in.Archive, "-d", dest)
+		cmd = commandContext(ctx, "unzip", "-o", in.Archive, "-d", dest)
 	} else {
 		flag := "xf" + tarFlag(format)
-		cmd = exec.CommandContext(ctx, "tar", flag, in.Archive, "-C", dest)
+		cmd = commandContext(ctx, "tar", flag, in.Archive, "-C", dest)
 	}
 
 	var stdout, stderr bytes.Buffer
@@ -217,10 +217,10 @@ func archiveList(ctx context.Context, in archiveArgs) (*archiveResult, error) {
 
 	var cmd *exec.Cmd
 	if format == "zip" {
-		cmd = exec.CommandContext(ctx, "unzip", "-l", in.Archive)
+		cmd = commandContext(ctx, "unzip", "-l", in.Archive)
 	} else {
 		flag := "tf" + tarFlag(format)
-		cmd = exec.CommandContext(ctx, "tar", flag, in.Archive)
+		cmd = commandContext(ctx, "tar", flag, in.Archive)
 	}
 
 	var stdout, stderr bytes.Buffer
@@ -140,6 +140,11 @@ func init() {
 		"Store and retrieve information across agent interactions with TTL support.",
 		func() Tool { return NewMemoryStore() },
 	)
+	MustRegisterTool(
+		"memory_compact",
+		"Summarize long working context with the run's model and store the summary per session.",
+		func() Tool { return NewMemoryCompact() },
+	)
 	MustRegisterTool(
 		"tmpdir",
 		"Create and manage temporary directories with file read/write support.",
@@ -284,6 +289,7 @@ func init() {
 
 	MustRegisterBundle("memory", "State and memory tools", []string{
 		"memory_store",
+		"memory_compact",
 		"todo_manager",
 	})
 
@@ -366,6 +372,7 @@ func init() {
 		"file_system",
 		"env_vars",
 		"memory_store",
+		"memory_compact",
 		"tmpdir",
 		"process_manager",
 		"disk_usage",
@@ -623,3 +623,63 @@ func TestGitRepo(t *testing.T) {
 		CleanupRepos()
 	})
 }
+
+func TestParseGitDiff(t *testing.T) {
+	diff := strings.Join([]string{
+		"diff --git a/main.go b/main.go",
+		"index 1111111..2222222 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1,3 +1,4 @@ package main",
+		" package main",
+		"-import \"fmt\"",
+		"+import (",
+		"+\t\"fmt\"",
+		"+)",
+		"@@ -10 +11,2 @@ func main() {",
+		"+\tfmt.Println(\"hi\")",
+		"+\tfmt.Println(\"bye\")",
+		"diff --git a/old.txt b/new.txt",
+		"similarity index 90%",
+		"rename from old.txt",
+		"rename to new.txt",
+		"diff --git a/logo.png b/logo.png",
+		"new file mode 100644",
+		"Binary files /dev/null and b/logo.png differ",
+		"",
+	}, "\n")
+
+	got := parseGitDiff(diff, 3)
+	if got.FileCount != 3 || got.Additions != 5 || got.Deletions != 1 {
+		t.Fatalf("summary = %+v", got)
+	}
+	main := got.Files[0]
+	if main.Path != "main.go" || main.Status != "modified" || len(main.Hunks) != 2 {
+		t.Fatalf("main.go = %+v", main)
+	}
+	if h := main.Hunks[1]; h.OldStart != 10 || h.OldLines != 1 || h.NewStart != 11 || h.NewLines != 2 {
+		t.Errorf("second hunk = %+v", h)
+	}
+	if !main.Truncated || !got.Truncated || len(main.Hunks[0].Lines) != 3 || len(main.Hunks[1].Lines) != 0 {
+		t.Errorf("truncation: file=%v lines=%d/%d", main.Truncated, len(main.Hunks[0].Lines), len(main.Hunks[1].Lines))
+	}
+	if r := got.Files[1]; r.Status != "renamed" || r.OldPath != "old.txt" || r.Path != "new.txt" {
+		t.Errorf("rename = %+v", r)
+	}
+	if b := got.Files[2]; b.Status != "added" || !b.Binary {
+		t.Errorf("binary = %+v", b)
+	}
+}
+
+func TestValidateGitRef(t *testing.T) {
+	for _, ref := range []string{"main", "origin/main", "v1.2.3", "HEAD~2", "main^", "HEAD@{1}", "a1b2c3d"} {
+		if err := validateGitRef(ref); err != nil {
+			t.Errorf("validateGitRef(%q) = %v", ref, err)
+		}
+	}
+	for _, ref := range []string{"--output=/tmp/x", "--upload-pack=touch /tmp/pwned", "-p", "main..evil", "ma in", "a;b", ""} {
+		if err := validateGitRef(ref); err == nil {
+			t.Errorf("validateGitRef(%q) accepted", ref)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/llm"
+)
+
+type contextKey string
+
+const (
+	providerContextKey  contextKey = "tools.provider"
+	modelContextKey     contextKey = "tools.model"
+	sessionIDContextKey contextKey = "tools.session_id"
+)
+
+// WithProvider stores the provider of the current run so tools that need a
+// model call (such as memory_compact) can reuse it.
+func WithProvider(ctx context.Context, provider llm.Provider) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if provider == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, providerContextKey, provider)
+}
+
+// ProviderFromContext returns the provider set by WithProvider, or nil.
+func ProviderFromContext(ctx context.Context) llm.Provider {
+	if ctx == nil {
+		return nil
+	}
+	p, _ := ctx.Value(providerContextKey).(llm.Provider)
+	return p
+}
+
+// WithModel stores the model the current run is configured with, so tools
+// that call ProviderFromContext use the same model.
+func WithModel(ctx context.Context, model string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, modelContextKey, model)
+}
+
+// ModelFromContext returns the model set by WithModel, or "" for the
+// provider's default.
+func ModelFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	v, _ := ctx.Value(modelContextKey).(string)
+	return v
+}
+
+// WithSessionID stores the session ID of the current run.
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionIDContextKey, sessionID)
+}
+
+// SessionIDFromContext returns the session ID set by WithSessionID, or "".
+func SessionIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	v, _ := ctx.Value(sessionIDContextKey).(string)
+	return v
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"runtime"
 	"strings"
 	"time"
@@ -107,7 +106,7 @@ func executeDiskUsage(ctx context.Context, in diskUsageArgs) (*diskUsageResult,
 }
 
 func runDF(ctx context.Context) (*diskUsageResult, error) {
-	cmd := exec.CommandContext(ctx, "df", "-h")
+	cmd := commandContext(ctx, "df", "-h")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -152,7 +151,7 @@ func runDU(ctx context.Context, in diskUsageArgs) (*diskUsageResult, error) {
 
 	// Use du with sort to get largest first
 	duArgs := []string{"-h", fmt.Sprintf("-d%d", depth), path}
-	cmd := exec.CommandContext(ctx, "du", duArgs...)
+	cmd := commandContext(ctx, "du", duArgs...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &bytes.Buffer{} // suppress permission errors
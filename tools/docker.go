@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -16,6 +19,7 @@ type dockerArgs struct {
 	Container  string            `json:"container,omitempty"`
 	Command    []string          `json:"command,omitempty"`
 	Env        map[string]string `json:"env,omitempty"`
+	EnvFile    string            `json:"envFile,omitempty"`
 	Ports      []string          `json:"ports,omitempty"`
 	Volumes    []string          `json:"volumes,omitempty"`
 	Dockerfile string            `json:"dockerfile,omitempty"`
@@ -29,10 +33,23 @@ type dockerArgs struct {
 
 // DockerResult contains the result of a docker operation.
 type DockerResult struct {
-	Success  bool   `json:"success"`
-	Output   string `json:"output,omitempty"`
-	Error    string `json:"error,omitempty"`
-	Duration string `json:"duration,omitempty"`
+	Success  bool          `json:"success"`
+	Output   string        `json:"output,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration string        `json:"duration,omitempty"`
+	Health   *DockerHealth `json:"health,omitempty"`
+}
+
+// DockerHealth reports what the healthcheck operation found about the local
+// docker installation.
+type DockerHealth struct {
+	ClientVersion    string   `json:"clientVersion,omitempty"`
+	ServerVersion    string   `json:"serverVersion,omitempty"`
+	DaemonReachable  bool     `json:"daemonReachable"`
+	PermissionDenied bool     `json:"permissionDenied"`
+	Buildx           bool     `json:"buildx"`
+	Compose          bool     `json:"compose"`
+	Issues           []string `json:"issues,omitempty"`
 }
 
 func NewDocker() Tool {
@@ -41,8 +58,8 @@ func NewDocker() Tool {
 		"properties": map[string]any{
 			"operation": map[string]any{
 				"type":        "string",
-				"enum":        []string{"ps", "images", "run", "stop", "logs", "inspect", "build", "pull", "exec"},
-				"description": "Operation: ps, images, run, stop, logs, inspect, build, pull, exec.",
+				"enum":        []string{"ps", "images", "run", "stop", "logs", "inspect", "build", "pull", "exec", "healthcheck"},
+				"description": "Operation: ps, images, run, stop, logs, inspect, build, pull, exec, healthcheck (verify daemon access, permissions, buildx and compose).",
 			},
 			"image": map[string]any{
 				"type":        "string",
@@ -59,7 +76,11 @@ func NewDocker() Tool {
 			},
 			"env": map[string]any{
 				"type":        "object",
-				"description": "Environment variables as key-value pairs (for run operation).",
+				"description": "Environment variables as key-value pairs (for run operation). Passed through a temporary env-file, so values stay out of the process list; multi-line values fall back to -e.",
+			},
+			"envFile": map[string]any{
+				"type":        "string",
+				"description": "Path to an existing env-file passed as --env-file (for run operation). Must be inside the directory named by AGENT_DOCKER_ENV_DIR; rejected when that is unset.",
 			},
 			"ports": map[string]any{
 				"type":        "array",
@@ -149,11 +170,13 @@ func NewDocker() Tool {
 				return dockerExec(ctx, timeout, "pull", in.Image)
 			case "exec":
 				return dockerExecInContainer(ctx, timeout, in)
+			case "healthcheck":
+				return dockerHealthcheck(ctx, timeout)
 			default:
 				return nil, fmt.Errorf("unsupported operation %q", in.Operation)
 			}
 		},
-	)
+	).WithAvailability(DockerAvailable)
 }
 
 func dockerExec(ctx context.Context, timeout int, args ...string) (*DockerResult, error) {
@@ -161,7 +184,7 @@ func dockerExec(ctx context.Context, timeout int, args ...string) (*DockerResult
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd := commandContext(ctx, "docker", args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -203,8 +226,25 @@ func dockerRun(ctx context.Context, timeout int, in dockerArgs) (*DockerResult,
 	for _, v := range in.Volumes {
 		args = append(args, "-v", v)
 	}
-	for k, v := range in.Env {
-		args = append(args, "-e", fmt.Sprintf("%s=%s", k, v))
+	if in.EnvFile != "" {
+		path, err := allowedDockerEnvFile(in.EnvFile)
+		if err != nil {
+			return &DockerResult{Success: false, Error: err.Error()}, nil
+		}
+		args = append(args, "--env-file", path)
+	}
+	if len(in.Env) > 0 {
+		path, inline, err := writeDockerEnvFile(in.Env)
+		if err != nil {
+			return &DockerResult{Success: false, Error: err.Error()}, nil
+		}
+		if path != "" {
+			defer os.Remove(path)
+			args = append(args, "--env-file", path)
+		}
+		for _, k := range inline {
+			args = append(args, "-e", k+"="+in.Env[k])
+		}
 	}
 
 	args = append(args, in.Image)
@@ -213,6 +253,79 @@ func dockerRun(ctx context.Context, timeout int, in dockerArgs) (*DockerResult,
 	return dockerExec(ctx, timeout, args...)
 }
 
+// dockerEnvDirVar names the only directory the envFile argument may read
+// from, so the model cannot load arbitrary host files into a container.
+const dockerEnvDirVar = "AGENT_DOCKER_ENV_DIR"
+
+// allowedDockerEnvFile resolves path and checks that it lies inside the
+// directory named by AGENT_DOCKER_ENV_DIR.
+func allowedDockerEnvFile(path string) (string, error) {
+	dir := strings.TrimSpace(os.Getenv(dockerEnvDirVar))
+	if dir == "" {
+		return "", fmt.Errorf("envFile is disabled; set %s to allow env-files from a directory", dockerEnvDirVar)
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", dockerEnvDirVar, err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("envFile: %w", err)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("envFile %q is outside %s", path, dockerEnvDirVar)
+	}
+	return resolved, nil
+}
+
+// writeDockerEnvFile writes env to a private temp file in --env-file format
+// so values never appear on the docker command line. The env-file format
+// cannot hold multi-line values; their keys are returned in inline for the
+// caller to pass with -e. path is empty when nothing was written; otherwise
+// the caller removes it.
+func writeDockerEnvFile(env map[string]string) (path string, inline []string, err error) {
+	keys := make([]string, 0, len(env))
+	for k, v := range env {
+		if k == "" || strings.ContainsAny(k, "=\n") {
+			return "", nil, fmt.Errorf("invalid env var name %q", k)
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			inline = append(inline, k)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sort.Strings(inline)
+	if len(keys) == 0 {
+		return "", inline, nil
+	}
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", k, env[k])
+	}
+
+	f, err := os.CreateTemp("", "docker-env-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("create env file: %w", err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", nil, fmt.Errorf("write env file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", nil, fmt.Errorf("write env file: %w", err)
+	}
+	return f.Name(), inline, nil
+}
+
 func dockerLogs(ctx context.Context, timeout int, in dockerArgs) (*DockerResult, error) {
 	if in.Container == "" {
 		return &DockerResult{Success: false, Error: "container is required for logs"}, nil
@@ -262,6 +375,64 @@ func dockerExecInContainer(ctx context.Context, timeout int, in dockerArgs) (*Do
 	return dockerExec(ctx, timeout, args...)
 }
 
+// dockerHealthcheck probes the client, daemon, and common plugins so a flow
+// can confirm the environment before running real operations. All probes
+// share one timeout.
+func dockerHealthcheck(ctx context.Context, timeout int) (*DockerResult, error) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	health := &DockerHealth{}
+
+	client, _ := dockerExec(ctx, timeout, "version", "--format", "{{.Client.Version}}")
+	health.ClientVersion = strings.TrimSpace(client.Output)
+	if health.ClientVersion == "" {
+		health.Issues = append(health.Issues, "docker client not found or not runnable")
+		return &DockerResult{
+			Success:  false,
+			Error:    strings.Join(health.Issues, "; "),
+			Duration: time.Since(start).String(),
+			Health:   health,
+		}, nil
+	}
+
+	info, _ := dockerExec(ctx, timeout, "info", "--format", "{{.ServerVersion}}")
+	if info.Success {
+		health.DaemonReachable = true
+		health.ServerVersion = strings.TrimSpace(info.Output)
+	} else {
+		lower := strings.ToLower(info.Error)
+		switch {
+		case strings.Contains(lower, "permission denied"):
+			health.PermissionDenied = true
+			health.Issues = append(health.Issues, "permission denied on the docker socket; add the user to the docker group or use rootless docker")
+		case strings.Contains(lower, "cannot connect") || strings.Contains(lower, "is the docker daemon running"):
+			health.Issues = append(health.Issues, "docker daemon is not reachable")
+		default:
+			health.Issues = append(health.Issues, "docker info failed: "+strings.TrimSpace(info.Error))
+		}
+	}
+
+	if r, _ := dockerExec(ctx, timeout, "buildx", "version"); r.Success {
+		health.Buildx = true
+	}
+	if r, _ := dockerExec(ctx, timeout, "compose", "version"); r.Success {
+		health.Compose = true
+	}
+
+	result := &DockerResult{
+		Success:  health.DaemonReachable,
+		Duration: time.Since(start).String(),
+		Health:   health,
+		Output: fmt.Sprintf("client=%s server=%s daemon=%t buildx=%t compose=%t",
+			health.ClientVersion, health.ServerVersion, health.DaemonReachable, health.Buildx, health.Compose),
+	}
+	if !result.Success {
+		result.Error = strings.Join(health.Issues, "; ")
+	}
+	return result, nil
+}
+
 // DockerAvailable checks if docker CLI is available.
 func DockerAvailable() bool {
 	cmd := exec.Command("docker", "version", "--format", "{{.Client.Version}}")
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"time"
 )
 
@@ -118,7 +117,7 @@ func NewDockerCompose() Tool {
 				return nil, fmt.Errorf("unsupported operation %q", in.Operation)
 			}
 		},
-	)
+	).WithAvailability(DockerAvailable)
 }
 
 func buildComposeBase(in dockerComposeArgs) []string {
@@ -139,7 +138,7 @@ func runComposeCmd(ctx context.Context, timeout int, in dockerComposeArgs, cmdAr
 	base := buildComposeBase(in)
 	fullArgs := append(base, cmdArgs...)
 
-	cmd := exec.CommandContext(ctx, "docker", fullArgs...)
+	cmd := commandContext(ctx, "docker", fullArgs...)
 	if in.ProjectDir != "" {
 		cmd.Dir = in.ProjectDir
 	}
@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"context"
+	"os/exec"
+	"time"
+)
+
+// commandWaitDelay bounds how long Wait keeps copying output after the
+// child is killed, in case a descendant escaped the process group but
+// still holds the output pipes.
+const commandWaitDelay = 2 * time.Second
+
+// commandContext is exec.CommandContext for tool subprocesses: the child
+// runs in its own process group and the whole group is killed when ctx is
+// done, so grandchildren (docker build steps, du over slow mounts, shell
+// pipelines) don't outlive the tool call.
+func commandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	setProcessGroup(cmd)
+	cmd.WaitDelay = commandWaitDelay
+	return cmd
+}
+
+// binaryAvailable reports whether name resolves on PATH.
+func binaryAvailable(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package tools
+
+import "os/exec"
+
+// setProcessGroup is a no-op where process groups are unavailable; the
+// default exec.CommandContext behaviour kills only the direct child.
+func setProcessGroup(*exec.Cmd) {}
@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const defaultDiffLinesPerFile = 200
+
+// GitDiffHunk is one @@ section of a unified diff.
+type GitDiffHunk struct {
+	Header   string   `json:"header"`
+	OldStart int      `json:"oldStart"`
+	OldLines int      `json:"oldLines"`
+	NewStart int      `json:"newStart"`
+	NewLines int      `json:"newLines"`
+	Lines    []string `json:"lines,omitempty"`
+}
+
+// GitDiffFile summarizes the changes to one file.
+type GitDiffFile struct {
+	Path      string        `json:"path"`
+	OldPath   string        `json:"oldPath,omitempty"`
+	Status    string        `json:"status"` // added, deleted, modified, renamed
+	Binary    bool          `json:"binary,omitempty"`
+	Additions int           `json:"additions"`
+	Deletions int           `json:"deletions"`
+	Hunks     []GitDiffHunk `json:"hunks,omitempty"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// GitDiffSummary is the structured form of a unified diff.
+type GitDiffSummary struct {
+	Files     []GitDiffFile `json:"files"`
+	FileCount int           `json:"fileCount"`
+	Additions int           `json:"additions"`
+	Deletions int           `json:"deletions"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// gitRefRe matches branch, tag, and commit names plus revision suffixes
+// such as HEAD~2 or main^.
+var gitRefRe = regexp.MustCompile(`^[A-Za-z0-9._/~^@{}+-]+$`)
+
+// validateGitRef rejects model-supplied refs that git could read as an
+// option (e.g. --output=..., --upload-pack=...) or that are not ref-shaped.
+func validateGitRef(ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref %q: must not start with '-'", ref)
+	}
+	if !gitRefRe.MatchString(ref) || strings.Contains(ref, "..") {
+		return fmt.Errorf("invalid ref %q", ref)
+	}
+	return nil
+}
+
+// parseGitDiff parses `git diff`/`git show` output. Addition and deletion
+// counts cover the whole diff; only the first maxLinesPerFile hunk lines of
+// each file are kept.
+func parseGitDiff(diff string, maxLinesPerFile int) GitDiffSummary {
+	if maxLinesPerFile <= 0 {
+		maxLinesPerFile = defaultDiffLinesPerFile
+	}
+	summary := GitDiffSummary{Files: []GitDiffFile{}}
+	var (
+		file *GitDiffFile
+		hunk *GitDiffHunk
+		kept int
+	)
+	flush := func() {
+		if file == nil {
+			return
+		}
+		summary.Additions += file.Additions
+		summary.Deletions += file.Deletions
+		summary.Truncated = summary.Truncated || file.Truncated
+		summary.Files = append(summary.Files, *file)
+		file, hunk = nil, nil
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			oldPath, newPath := splitDiffGitPaths(strings.TrimPrefix(line, "diff --git "))
+			file = &GitDiffFile{Path: newPath, Status: "modified"}
+			if oldPath != newPath {
+				file.OldPath = oldPath
+			}
+			kept = 0
+			continue
+		}
+		if file == nil {
+			continue
+		}
+		if hunk == nil {
+			switch {
+			case strings.HasPrefix(line, "new file mode"):
+				file.Status = "added"
+			case strings.HasPrefix(line, "deleted file mode"):
+				file.Status = "deleted"
+			case strings.HasPrefix(line, "rename from "):
+				file.Status = "renamed"
+				file.OldPath = strings.TrimPrefix(line, "rename from ")
+			case strings.HasPrefix(line, "rename to "):
+				file.Path = strings.TrimPrefix(line, "rename to ")
+			case strings.HasPrefix(line, "Binary files "), strings.HasPrefix(line, "GIT binary patch"):
+				file.Binary = true
+			}
+		}
+		if m := hunkHeaderRe.FindStringSubmatch(line); m != nil {
+			file.Hunks = append(file.Hunks, GitDiffHunk{
+				Header:   line,
+				OldStart: atoiDefault(m[1], 0),
+				OldLines: atoiDefault(m[2], 1),
+				NewStart: atoiDefault(m[3], 0),
+				NewLines: atoiDefault(m[4], 1),
+			})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+			continue
+		}
+		if hunk == nil || line == "" {
+			continue
+		}
+		switch line[0] {
+		case '+':
+			file.Additions++
+		case '-':
+			file.Deletions++
+		case ' ', '\\':
+		default:
+			continue
+		}
+		if kept >= maxLinesPerFile {
+			file.Truncated = true
+			continue
+		}
+		hunk.Lines = append(hunk.Lines, line)
+		kept++
+	}
+	flush()
+	summary.FileCount = len(summary.Files)
+	return summary
+}
+
+// splitDiffGitPaths splits the "a/old b/new" part of a diff --git header.
+func splitDiffGitPaths(s string) (string, string) {
+	if i := strings.Index(s, " b/"); i >= 0 && strings.HasPrefix(s, "a/") {
+		return s[2:i], s[i+3:]
+	}
+	parts := strings.SplitN(s, " ", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return s, s
+}
+
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func diffRepo(ctx context.Context, args gitRepoArgs, operation string) (map[string]any, error) {
+	result, err := cloneRepo(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return map[string]any{"success": false, "error": result.Error}, nil
+	}
+
+	var cmdArgs []string
+	switch operation {
+	case "diff":
+		if strings.TrimSpace(args.Base) == "" {
+			return map[string]any{"success": false, "error": "base is required for diff"}, nil
+		}
+		if err := validateGitRef(args.Base); err != nil {
+			return map[string]any{"success": false, "error": err.Error()}, nil
+		}
+		cmdArgs = []string{"-C", result.LocalPath, "diff", "--no-color", "--end-of-options", args.Base}
+		if args.Target != "" {
+			if err := validateGitRef(args.Target); err != nil {
+				return map[string]any{"success": false, "error": err.Error()}, nil
+			}
+			cmdArgs = append(cmdArgs, args.Target)
+		}
+	case "show":
+		ref := args.Target
+		if ref == "" {
+			ref = "HEAD"
+		}
+		if err := validateGitRef(ref); err != nil {
+			return map[string]any{"success": false, "error": err.Error()}, nil
+		}
+		cmdArgs = []string{"-C", result.LocalPath, "show", "--no-color", "--format=", "--end-of-options", ref}
+	}
+	if args.Path != "" {
+		cmdArgs = append(cmdArgs, "--", args.Path)
+	}
+
+	output, err := commandContext(ctx, "git", cmdArgs...).Output()
+	if err != nil && operation == "diff" {
+		// Shallow clones may not have the base yet; fetch it and retry once.
+		if fetchErr := commandContext(ctx, "git", "-C", result.LocalPath, "fetch", "--depth=1", "--end-of-options", "origin", args.Base).Run(); fetchErr == nil {
+			output, err = commandContext(ctx, "git", cmdArgs...).Output()
+		}
+	}
+	if err != nil {
+		return map[string]any{"success": false, "error": fmt.Sprintf("git %s failed: %v", operation, err)}, nil
+	}
+
+	out := map[string]any{
+		"success":   true,
+		"localPath": result.LocalPath,
+		"repoName":  result.RepoName,
+		"commit":    result.Commit,
+	}
+	if args.Format == "structured" {
+		out["diff"] = parseGitDiff(string(output), args.MaxLinesPerFile)
+		return out, nil
+	}
+	out["diff"] = limitOutput(string(output), 100*1024)
+	return out, nil
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -19,6 +18,11 @@ type gitRepoArgs struct {
 	Path      string `json:"path,omitempty"`
 	Depth     int    `json:"depth,omitempty"`
 	Operation string `json:"operation,omitempty"`
+
+	Base            string `json:"base,omitempty"`
+	Target          string `json:"target,omitempty"`
+	Format          string `json:"format,omitempty"`
+	MaxLinesPerFile int    `json:"maxLinesPerFile,omitempty"`
 }
 
 // GitRepoResult contains the result of a git operation.
@@ -74,8 +78,26 @@ func NewGitRepo() Tool {
 			},
 			"operation": map[string]any{
 				"type":        "string",
-				"enum":        []string{"clone", "read_files", "list_files", "get_info", "read_file"},
-				"description": "Operation: clone (clone repo), list_files (list files in path), read_files (read multiple files), read_file (read single file), get_info (repo metadata). Defaults to clone.",
+				"enum":        []string{"clone", "read_files", "list_files", "get_info", "read_file", "diff", "show"},
+				"description": "Operation: clone (clone repo), list_files (list files in path), read_files (read multiple files), read_file (read single file), get_info (repo metadata), diff (changes between base and target), show (changes introduced by a commit). Defaults to clone.",
+			},
+			"base": map[string]any{
+				"type":        "string",
+				"description": "For diff: the ref to compare from (branch, tag, or commit).",
+			},
+			"target": map[string]any{
+				"type":        "string",
+				"description": "For diff: the ref to compare to (defaults to the working tree). For show: the commit to show (defaults to HEAD).",
+			},
+			"format": map[string]any{
+				"type":        "string",
+				"enum":        []string{"raw", "structured"},
+				"description": "For diff/show: raw returns the unified diff text; structured returns files, hunks, and addition/deletion counts. Defaults to raw.",
+			},
+			"maxLinesPerFile": map[string]any{
+				"type":        "integer",
+				"description": "For structured diff/show: maximum hunk lines kept per file (counts still cover the full diff). Default 200.",
+				"minimum":     1,
 			},
 		},
 		"required": []string{"url"},
@@ -103,6 +125,10 @@ func NewGitRepo() Tool {
 			if in.Depth <= 0 {
 				in.Depth = 1
 			}
+			// show needs the parent commit to diff against.
+			if operation == "show" && in.Depth < 2 {
+				in.Depth = 2
+			}
 
 			switch operation {
 			case "clone":
@@ -115,11 +141,13 @@ func NewGitRepo() Tool {
 				return readSingleFile(ctx, in)
 			case "get_info":
 				return getRepoInfo(ctx, in)
+			case "diff", "show":
+				return diffRepo(ctx, in, operation)
 			default:
 				return nil, fmt.Errorf("unsupported operation %q", operation)
 			}
 		},
-	)
+	).WithAvailability(func() bool { return binaryAvailable("git") })
 }
 
 // getRepoLocalPath returns the local path for a cloned repo.
@@ -209,7 +237,7 @@ func cloneRepo(ctx context.Context, args gitRepoArgs) (*GitRepoResult, error) {
 
 	cmdArgs = append(cmdArgs, args.URL, localPath)
 
-	cmd := exec.CommandContext(ctx, "git", cmdArgs...)
+	cmd := commandContext(ctx, "git", cmdArgs...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return &GitRepoResult{
@@ -259,7 +287,7 @@ func pullRepo(ctx context.Context, localPath string, args gitRepoArgs) (*GitRepo
 			if args.Tag != "" {
 				ref = args.Tag
 			}
-			cmd := exec.CommandContext(ctx, "git", "-C", localPath, "checkout", ref)
+			cmd := commandContext(ctx, "git", "-C", localPath, "checkout", ref)
 			if output, err := cmd.CombinedOutput(); err != nil {
 				return &GitRepoResult{
 					Success: false,
@@ -269,7 +297,7 @@ func pullRepo(ctx context.Context, localPath string, args gitRepoArgs) (*GitRepo
 		}
 
 		// Pull latest changes
-		cmd := exec.CommandContext(ctx, "git", "-C", localPath, "pull", "--ff-only")
+		cmd := commandContext(ctx, "git", "-C", localPath, "pull", "--ff-only")
 		cmd.CombinedOutput() // Ignore errors for shallow clones
 	}
 
@@ -288,10 +316,10 @@ func pullRepo(ctx context.Context, localPath string, args gitRepoArgs) (*GitRepo
 
 func checkoutCommit(ctx context.Context, localPath, commit string) error {
 	// For shallow clones, we may need to fetch the specific commit
-	fetchCmd := exec.CommandContext(ctx, "git", "-C", localPath, "fetch", "--depth=1", "origin", commit)
+	fetchCmd := commandContext(ctx, "git", "-C", localPath, "fetch", "--depth=1", "origin", commit)
 	fetchCmd.CombinedOutput() // Ignore errors
 
-	cmd := exec.CommandContext(ctx, "git", "-C", localPath, "checkout", commit)
+	cmd := commandContext(ctx, "git", "-C", localPath, "checkout", commit)
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("%v - %s", err, string(output))
 	}
@@ -299,7 +327,7 @@ func checkoutCommit(ctx context.Context, localPath, commit string) error {
 }
 
 func getCurrentCommit(ctx context.Context, localPath string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", "-C", localPath, "rev-parse", "HEAD")
+	cmd := commandContext(ctx, "git", "-C", localPath, "rev-parse", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -308,7 +336,7 @@ func getCurrentCommit(ctx context.Context, localPath string) (string, error) {
 }
 
 func getCurrentBranch(ctx context.Context, localPath string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", "-C", localPath, "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := commandContext(ctx, "git", "-C", localPath, "rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -597,25 +625,25 @@ func getRepoInfo(ctx context.Context, args gitRepoArgs) (map[string]any, error)
 	}
 
 	// Get remote info
-	cmd := exec.CommandContext(ctx, "git", "-C", result.LocalPath, "remote", "-v")
+	cmd := commandContext(ctx, "git", "-C", result.LocalPath, "remote", "-v")
 	if output, err := cmd.Output(); err == nil {
 		info["remotes"] = strings.TrimSpace(string(output))
 	}
 
 	// Get last commit message
-	cmd = exec.CommandContext(ctx, "git", "-C", result.LocalPath, "log", "-1", "--pretty=%s")
+	cmd = commandContext(ctx, "git", "-C", result.LocalPath, "log", "-1", "--pretty=%s")
 	if output, err := cmd.Output(); err == nil {
 		info["lastCommitMessage"] = strings.TrimSpace(string(output))
 	}
 
 	// Get last commit author
-	cmd = exec.CommandContext(ctx, "git", "-C", result.LocalPath, "log", "-1", "--pretty=%an <%ae>")
+	cmd = commandContext(ctx, "git", "-C", result.LocalPath, "log", "-1", "--pretty=%an <%ae>")
 	if output, err := cmd.Output(); err == nil {
 		info["lastCommitAuthor"] = strings.TrimSpace(string(output))
 	}
 
 	// Get last commit date
-	cmd = exec.CommandContext(ctx, "git", "-C", result.LocalPath, "log", "-1", "--pretty=%ci")
+	cmd = commandContext(ctx, "git", "-C", result.LocalPath, "log", "-1", "--pretty=%ci")
 	if output, err := cmd.Output(); err == nil {
 		info["lastCommitDate"] = strings.TrimSpace(string(output))
 	}
@@ -92,7 +92,7 @@ func k3sExec(ctx context.Context, timeout int, name string, args ...string) (*K3
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, name, args...)
+	cmd := commandContext(ctx, name, args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -178,7 +178,7 @@ func k3sStatus(ctx context.Context, timeout int) (*K3sResult, error) {
 	result.Installed = true
 
 	// Also get version
-	versionCmd := exec.CommandContext(ctx, "k3s", "--version")
+	versionCmd := commandContext(ctx, "k3s", "--version")
 	if versionOut, vErr := versionCmd.Output(); vErr == nil {
 		result.Output = string(versionOut) + "\n" + result.Output
 	}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 )
@@ -171,7 +170,7 @@ func NewKubectl() Tool {
 				return nil, fmt.Errorf("unsupported operation %q", in.Operation)
 			}
 		},
-	)
+	).WithAvailability(func() bool { return binaryAvailable("kubectl") })
 }
 
 func buildKubectlBase(in kubectlArgs) []string {
@@ -195,7 +194,7 @@ func runKubectl(ctx context.Context, timeout int, cmdArgs []string) (*KubectlRes
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "kubectl", cmdArgs...)
+	cmd := commandContext(ctx, "kubectl", cmdArgs...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -290,7 +289,7 @@ func kubectlApply(ctx context.Context, timeout int, in kubectlArgs) (*KubectlRes
 		defer cancel()
 
 		fullArgs := append([]string{"apply", "-f", "-"}, buildKubectlBase(in)...)
-		cmd := exec.CommandContext(ctx, "kubectl", fullArgs...)
+		cmd := commandContext(ctx, "kubectl", fullArgs...)
 		cmd.Stdin = strings.NewReader(in.Manifest)
 
 		var stdout, stderr bytes.Buffer
@@ -381,7 +380,7 @@ func kubectlPortForward(ctx context.Context, timeout int, in kubectlArgs) (*Kube
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	cmd := commandContext(ctx, "kubectl", args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -101,13 +101,13 @@ func executeLogViewer(ctx context.Context, in logViewerArgs) (*logViewerResult,
 		if in.File == "" {
 			return nil, fmt.Errorf("file is required for tail")
 		}
-		return runLogCommand(ctx, "tail", in.File, exec.CommandContext(ctx, "tail", "-n", fmt.Sprintf("%d", lines), in.File))
+		return runLogCommand(ctx, "tail", in.File, commandContext(ctx, "tail", "-n", fmt.Sprintf("%d", lines), in.File))
 
 	case "head":
 		if in.File == "" {
 			return nil, fmt.Errorf("file is required for head")
 		}
-		return runLogCommand(ctx, "head", in.File, exec.CommandContext(ctx, "head", "-n", fmt.Sprintf("%d", lines), in.File))
+		return runLogCommand(ctx, "head", in.File, commandContext(ctx, "head", "-n", fmt.Sprintf("%d", lines), in.File))
 
 	case "grep":
 		if in.Pattern == "" {
@@ -128,7 +128,7 @@ func executeLogViewer(ctx context.Context, in logViewerArgs) (*logViewerResult,
 		}
 		args = append(args, in.Pattern, in.File)
 
-		result, err := runLogCommand(ctx, "grep", in.File, exec.CommandContext(ctx, "grep", args...))
+		result, err := runLogCommand(ctx, "grep", in.File, commandContext(ctx, "grep", args...))
 		if result != nil {
 			result.Matches = result.Count
 			if result.Count > lines {
@@ -146,7 +146,7 @@ func executeLogViewer(ctx context.Context, in logViewerArgs) (*logViewerResult,
 		if in.Since != "" {
 			args = append(args, "--since", in.Since)
 		}
-		return runLogCommand(ctx, "journalctl", in.Service, exec.CommandContext(ctx, "journalctl", args...))
+		return runLogCommand(ctx, "journalctl", in.Service, commandContext(ctx, "journalctl", args...))
 
 	default:
 		return nil, fmt.Errorf("unknown action %q, use: tail, head, grep, journalctl", in.Action)
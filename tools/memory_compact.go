@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
+)
+
+const (
+	memoryCompactNamespace = "compact"
+	defaultCompactMaxWords = 200
+	maxCompactMaxWords     = 2000
+)
+
+type memoryCompactArgs struct {
+	Text     string `json:"text"`
+	Focus    string `json:"focus,omitempty"`
+	MaxWords int    `json:"max_words,omitempty"`
+}
+
+// SummaryStore receives summaries produced by memory_compact. It is
+// satisfied by multiagent.SharedMemory.
+type SummaryStore interface {
+	Set(key string, value any, createdBy string)
+}
+
+// MemoryCompactResult contains a compacted summary and where it was stored.
+type MemoryCompactResult struct {
+	Summary      string `json:"summary"`
+	Key          string `json:"key"`
+	Namespace    string `json:"namespace,omitempty"`
+	SessionID    string `json:"sessionId"`
+	InputChars   int    `json:"inputChars"`
+	SummaryChars int    `json:"summaryChars"`
+}
+
+// NewMemoryCompact returns a tool that summarizes a block of text with the
+// run's provider and keeps the summary in memory_store under the "compact"
+// namespace, keyed by session ID.
+func NewMemoryCompact() Tool {
+	return newMemoryCompact(nil)
+}
+
+// NewMemoryCompactWithStore is like NewMemoryCompact but stores summaries in
+// store under "compact:<sessionID>".
+func NewMemoryCompactWithStore(store SummaryStore) Tool {
+	return newMemoryCompact(store)
+}
+
+func newMemoryCompact(store SummaryStore) Tool {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"text": map[string]any{
+				"type":        "string",
+				"description": "Working notes, findings, or transcript excerpts to compact.",
+			},
+			"focus": map[string]any{
+				"type":        "string",
+				"description": "Optional: what the summary must preserve (e.g. open questions, file paths, decisions).",
+			},
+			"max_words": map[string]any{
+				"type":        "integer",
+				"description": fmt.Sprintf("Target summary length in words. Defaults to %d.", defaultCompactMaxWords),
+			},
+		},
+		"required": []string{"text"},
+	}
+
+	return NewFuncTool(
+		"memory_compact",
+		"Checkpoint long working context: summarize a block of text and store the summary for this session so later steps can rely on it instead of the full text.",
+		schema,
+		func(ctx context.Context, args json.RawMessage) (any, error) {
+			var in memoryCompactArgs
+			if err := json.Unmarshal(args, &in); err != nil {
+				return nil, fmt.Errorf("invalid memory_compact args: %w", err)
+			}
+			text := strings.TrimSpace(in.Text)
+			if text == "" {
+				return nil, fmt.Errorf("text is required")
+			}
+			provider := ProviderFromContext(ctx)
+			if provider == nil {
+				return nil, fmt.Errorf("memory_compact requires a provider in the run context")
+			}
+			// Without a session every run would share one summary slot.
+			sessionID := SessionIDFromContext(ctx)
+			if sessionID == "" {
+				return nil, fmt.Errorf("memory_compact requires a session ID in the run context")
+			}
+
+			maxWords := in.MaxWords
+			if maxWords <= 0 {
+				maxWords = defaultCompactMaxWords
+			}
+			if maxWords > maxCompactMaxWords {
+				maxWords = maxCompactMaxWords
+			}
+
+			resp, err := provider.Generate(ctx, types.Request{
+				Model:        ModelFromContext(ctx),
+				SystemPrompt: compactSystemPrompt(maxWords, in.Focus),
+				Messages:     []types.Message{{Role: types.RoleUser, Content: text}},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("summarize: %w", err)
+			}
+			summary := strings.TrimSpace(resp.Message.Content)
+			if summary == "" {
+				return nil, fmt.Errorf("summarize: provider returned an empty summary")
+			}
+
+			result := &MemoryCompactResult{
+				Summary:      summary,
+				SessionID:    sessionID,
+				InputChars:   len(text),
+				SummaryChars: len(summary),
+			}
+			if store != nil {
+				result.Key = memoryCompactNamespace + ":" + sessionID
+				store.Set(result.Key, summary, "memory_compact")
+			} else {
+				result.Key = sessionID
+				result.Namespace = memoryCompactNamespace
+				if _, err := memSet(memoryCompactNamespace, sessionID, summary, 0); err != nil {
+					return nil, err
+				}
+			}
+			return result, nil
+		},
+	)
+}
+
+func compactSystemPrompt(maxWords int, focus string) string {
+	var b strings.Builder
+	b.WriteString("You compress an agent's working context into a checkpoint it will rely on later. ")
+	fmt.Fprintf(&b, "Write at most %d words. ", maxWords)
+	b.WriteString("Keep facts, decisions, identifiers, file paths, numbers, and open questions; drop repetition and narration. ")
+	b.WriteString("Reply with the summary only.")
+	if focus = strings.TrimSpace(focus); focus != "" {
+		b.WriteString("\nPreserve in particular: ")
+		b.WriteString(focus)
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/redact"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
+)
+
+// Middleware wraps a Tool to add behavior around Execute, such as logging,
+// rate limiting, redaction, or approval.
+type Middleware func(Tool) Tool
+
+// Chain wraps t with mw. The first middleware is the outermost, so it sees
+// each call first and its result last. Nil middlewares are skipped.
+func Chain(t Tool, mw ...Middleware) Tool {
+	for i := len(mw) - 1; i >= 0; i-- {
+		if mw[i] != nil {
+			t = mw[i](t)
+		}
+	}
+	return t
+}
+
+// ExecuteFunc is the signature of Tool.Execute.
+type ExecuteFunc func(ctx context.Context, args json.RawMessage) (any, error)
+
+// WrapExecute returns a Tool that keeps t's definition, availability check,
+// and result formatter but runs exec, which receives t.Execute as next.
+// It is the usual building block for a Middleware.
+func WrapExecute(t Tool, exec func(ctx context.Context, args json.RawMessage, next ExecuteFunc) (any, error)) Tool {
+	return &wrappedTool{inner: t, exec: exec}
+}
+
+type wrappedTool struct {
+	inner Tool
+	exec  func(ctx context.Context, args json.RawMessage, next ExecuteFunc) (any, error)
+}
+
+func (w *wrappedTool) Definition() types.ToolDefinition { return w.inner.Definition() }
+
+func (w *wrappedTool) Execute(ctx context.Context, args json.RawMessage) (any, error) {
+	return w.exec(ctx, args, w.inner.Execute)
+}
+
+func (w *wrappedTool) Available() bool { return IsAvailable(w.inner) }
+
+func (w *wrappedTool) FormatResult(result any) string {
+	if f, ok := w.inner.(ResultFormatter); ok {
+		return f.FormatResult(result)
+	}
+	return ""
+}
+
+// RateLimit allows at most n calls per interval, spread evenly; calls over
+// the limit wait their turn or return the context's error. A call cancelled
+// while waiting gives its turn back when no later call has queued behind it.
+// The limit is shared by every tool wrapped by the returned middleware.
+// n <= 0 or per <= 0 means no limit: RateLimit returns nil, which Chain
+// skips.
+func RateLimit(n int, per time.Duration) Middleware {
+	if n <= 0 || per <= 0 {
+		return nil
+	}
+	gap := per / time.Duration(n)
+	var (
+		mu   sync.Mutex
+		next time.Time
+	)
+	reserve := func() (slot time.Time, wait time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if next.Before(now) {
+			next = now
+		}
+		slot = next
+		next = next.Add(gap)
+		return slot, slot.Sub(now)
+	}
+	release := func(slot time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		if next.Equal(slot.Add(gap)) {
+			next = slot
+		}
+	}
+	return func(t Tool) Tool {
+		return WrapExecute(t, func(ctx context.Context, args json.RawMessage, next ExecuteFunc) (any, error) {
+			if slot, wait := reserve(); wait > 0 {
+				timer := time.NewTimer(wait)
+				defer timer.Stop()
+				select {
+				case <-ctx.Done():
+					release(slot)
+					return nil, fmt.Errorf("tool %q rate limited: %w", t.Definition().Name, ctx.Err())
+				case <-timer.C:
+				}
+			}
+			return next(ctx, args)
+		})
+	}
+}
+
+// Redact scrubs secrets (see the redact package) from tool results, their
+// formatted text, and error messages before they reach the model.
+// Non-string results come back as their decoded JSON form.
+func Redact() Middleware {
+	return func(t Tool) Tool {
+		return &redactingTool{wrappedTool{inner: t, exec: func(ctx context.Context, args json.RawMessage, next ExecuteFunc) (any, error) {
+			out, err := next(ctx, args)
+			if err != nil {
+				return nil, &redactedError{msg: redact.Redact(err.Error()), err: err}
+			}
+			return redactResult(out), nil
+		}}}
+	}
+}
+
+type redactingTool struct{ wrappedTool }
+
+func (r *redactingTool) FormatResult(result any) string {
+	return redact.Redact(r.wrappedTool.FormatResult(result))
+}
+
+func redactResult(out any) any {
+	switch v := out.(type) {
+	case nil:
+		return nil
+	case string:
+		return redact.Redact(v)
+	case map[string]any, []any:
+		return redact.Value(v, redact.Replacement)
+	}
+	encoded, err := json.Marshal(out)
+	if err != nil {
+		return out
+	}
+	var generic any
+	if err := json.Unmarshal(encoded, &generic); err != nil {
+		return out
+	}
+	return redact.Value(generic, redact.Replacement)
+}
+
+// redactedError hides secrets in the message while keeping the original
+// error reachable through errors.Is and errors.As.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// ErrApprovalDenied is returned when an Approver rejects a tool call.
+var ErrApprovalDenied = errors.New("tool call not approved")
+
+// Approver decides whether a tool call may run, e.g. by asking a human.
+type Approver func(ctx context.Context, tool string, args json.RawMessage) (bool, error)
+
+// RequireApproval runs each call past approve first. A rejected call fails
+// with ErrApprovalDenied; an approver error fails the call with that error.
+// A nil approve returns a nil middleware, which Chain skips.
+func RequireApproval(approve Approver) Middleware {
+	if approve == nil {
+		return nil
+	}
+	return func(t Tool) Tool {
+		return WrapExecute(t, func(ctx context.Context, args json.RawMessage, next ExecuteFunc) (any, error) {
+			name := t.Definition().Name
+			ok, err := approve(ctx, name, args)
+			if err != nil {
+				return nil, fmt.Errorf("approval for tool %q: %w", name, err)
+			}
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrApprovalDenied, name)
+			}
+			return next(ctx, args)
+		})
+	}
+}
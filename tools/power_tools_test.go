@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/llm"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
 )
 
 func TestHTTPClient(t *testing.T) {
@@ -95,6 +100,31 @@ func TestShellCommand(t *testing.T) {
 	})
 }
 
+func TestCommandContext_KillsProcessGroup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups are unix-only")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// The shell forks sleep, which inherits stdout; killing only the shell
+	// would leave Run blocked on the pipe until WaitDelay expires.
+	cmd := commandContext(ctx, "/bin/sh", "-c", "sleep 30; echo done")
+	var out strings.Builder
+	cmd.Stdout = &out
+	start := time.Now()
+	err := cmd.Run()
+	if err == nil {
+		t.Fatal("expected command to be killed")
+	}
+	if elapsed := time.Since(start); elapsed >= commandWaitDelay {
+		t.Fatalf("Run took %v; grandchild outlived cancellation", elapsed)
+	}
+	if out.Len() != 0 {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
 func TestFileSystem(t *testing.T) {
 	tool := NewFileSystem()
 	tempDir := t.TempDir()
@@ -519,3 +549,165 @@ func TestTextProcessor(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatResult(t *testing.T) {
+	plain := NewFuncTool("plain", "", nil, nil)
+	if got, err := FormatResult(plain, map[string]int{"n": 1}); err != nil || got != `{"n":1}` {
+		t.Fatalf("FormatResult without formatter = %q, %v; want JSON", got, err)
+	}
+
+	tool := NewSystemInfo()
+	out, err := tool.Execute(context.Background(), json.RawMessage(`{"action":"os"}`))
+	if err != nil {
+		t.Fatalf("system_info failed: %v", err)
+	}
+	got, err := FormatResult(tool, out)
+	if err != nil {
+		t.Fatalf("FormatResult failed: %v", err)
+	}
+	if strings.HasPrefix(got, "{") || !strings.Contains(got, "os: "+runtime.GOOS) {
+		t.Fatalf("expected text rendering, got %q", got)
+	}
+
+	empty := NewFuncTool("empty", "", nil, nil).WithFormatter(func(any) string { return "" })
+	if got, _ := FormatResult(empty, []string{"a"}); got != `["a"]` {
+		t.Fatalf("empty formatter output should fall back to JSON, got %q", got)
+	}
+}
+
+type summaryProvider struct{ req types.Request }
+
+func (p *summaryProvider) Name() string                   { return "summary" }
+func (p *summaryProvider) Capabilities() llm.Capabilities { return llm.Capabilities{} }
+func (p *summaryProvider) Generate(_ context.Context, req types.Request) (types.Response, error) {
+	p.req = req
+	return types.Response{Message: types.Message{Role: types.RoleAssistant, Content: " found root cause in db.go "}}, nil
+}
+
+type recordingStore map[string]any
+
+func (s recordingStore) Set(key string, value any, _ string) { s[key] = value }
+
+func TestMemoryCompact(t *testing.T) {
+	ClearAllMemory()
+	defer ClearAllMemory()
+
+	args := json.RawMessage(`{"text":"long investigation notes","focus":"file paths"}`)
+	if _, err := NewMemoryCompact().Execute(context.Background(), args); err == nil {
+		t.Fatal("expected error without a provider in context")
+	}
+
+	provider := &summaryProvider{}
+	if _, err := NewMemoryCompact().Execute(WithProvider(context.Background(), provider), args); err == nil {
+		t.Fatal("expected error without a session ID in context")
+	}
+	if provider.req.Messages != nil {
+		t.Fatal("provider called without a session ID")
+	}
+
+	ctx := WithSessionID(WithModel(WithProvider(context.Background(), provider), "small-model"), "sess-1")
+	out, err := NewMemoryCompact().Execute(ctx, args)
+	if err != nil {
+		t.Fatalf("memory_compact failed: %v", err)
+	}
+	res := out.(*MemoryCompactResult)
+	if res.Summary != "found root cause in db.go" || res.Key != "sess-1" || res.Namespace != "compact" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if !strings.Contains(provider.req.SystemPrompt, "file paths") || provider.req.Messages[0].Content != "long investigation notes" || provider.req.Model != "small-model" {
+		t.Fatalf("unexpected summarize request: %+v", provider.req)
+	}
+	stored, _ := memGet("compact", "sess-1")
+	if stored.Data["value"] != res.Summary {
+		t.Fatalf("expected summary in memory_store, got %+v", stored.Data)
+	}
+
+	store := recordingStore{}
+	if _, err := NewMemoryCompactWithStore(store).Execute(ctx, args); err != nil {
+		t.Fatalf("memory_compact with store failed: %v", err)
+	}
+	if store["compact:sess-1"] != res.Summary {
+		t.Fatalf("expected summary in store, got %v", store)
+	}
+}
+
+func TestWriteDockerEnvFile(t *testing.T) {
+	path, inline, err := writeDockerEnvFile(map[string]string{"TOKEN": "s3cret", "A": "1", "CERT": "line1\nline2"})
+	if err != nil {
+		t.Fatalf("writeDockerEnvFile failed: %v", err)
+	}
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "A=1\nTOKEN=s3cret\n" {
+		t.Errorf("unexpected env file contents %q", data)
+	}
+	if info, _ := os.Stat(path); runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("env file mode = %v, want 0600", info.Mode().Perm())
+	}
+	if len(inline) != 1 || inline[0] != "CERT" {
+		t.Errorf("inline = %v, want [CERT]", inline)
+	}
+
+	path, inline, err = writeDockerEnvFile(map[string]string{"KEY": "line1\nline2"})
+	if err != nil || path != "" || len(inline) != 1 {
+		t.Errorf("multi-line only: path=%q inline=%v err=%v", path, inline, err)
+	}
+}
+
+func TestAllowedDockerEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "app.env")
+	if err := os.WriteFile(envPath, []byte("A=1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(dockerEnvDirVar, "")
+	if _, err := allowedDockerEnvFile(envPath); err == nil {
+		t.Error("expected envFile to be rejected when the allow-list is unset")
+	}
+
+	t.Setenv(dockerEnvDirVar, dir)
+	if _, err := allowedDockerEnvFile("app.env"); err != nil {
+		t.Errorf("relative path inside dir rejected: %v", err)
+	}
+	if _, err := allowedDockerEnvFile("../../etc/passwd"); err == nil {
+		t.Error("expected path outside dir to be rejected")
+	}
+	outside := filepath.Join(t.TempDir(), "secret.env")
+	if err := os.WriteFile(outside, []byte("B=2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := allowedDockerEnvFile(outside); err == nil {
+		t.Error("expected absolute path outside dir to be rejected")
+	}
+}
+
+func TestDockerHealthcheck_SharedTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as docker")
+	}
+	// A fake docker whose client answers but whose daemon and plugin probes
+	// hang.
+	dir := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$1\" = version ]; then echo 27.0.0; exit 0; fi\nexec sleep 10\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	start := time.Now()
+	res, err := dockerHealthcheck(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("dockerHealthcheck: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2500*time.Millisecond {
+		t.Fatalf("healthcheck took %v, want the probes to share the 1s timeout", elapsed)
+	}
+	if res.Success || res.Health == nil || res.Health.ClientVersion != "27.0.0" || res.Health.DaemonReachable {
+		t.Fatalf("unexpected result: %+v health=%+v", res, res.Health)
+	}
+}
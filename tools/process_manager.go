@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"runtime"
 	"strconv"
 	"strings"
@@ -138,7 +137,7 @@ func executeProcessManager(ctx context.Context, in processManagerArgs) (*process
 
 func psCommand(ctx context.Context, nameFilter, userFilter string, limit int) (*processResult, error) {
 	args := []string{"ax", "-o", "pid,user,%cpu,%mem,vsz,rss,stat,start,comm"}
-	cmd := exec.CommandContext(ctx, "ps", args...)
+	cmd := commandContext(ctx, "ps", args...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -150,7 +149,7 @@ func psCommand(ctx context.Context, nameFilter, userFilter string, limit int) (*
 }
 
 func psInfoByPID(ctx context.Context, pid int) (*processResult, error) {
-	cmd := exec.CommandContext(ctx, "ps", "-p", strconv.Itoa(pid), "-o", "pid,user,%cpu,%mem,vsz,rss,stat,start,command")
+	cmd := commandContext(ctx, "ps", "-p", strconv.Itoa(pid), "-o", "pid,user,%cpu,%mem,vsz,rss,stat,start,command")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -181,7 +180,7 @@ func psTop(ctx context.Context, sortBy string, limit int) (*processResult, error
 	}
 
 	args := []string{"ax", "-o", "pid,user,%cpu,%mem,vsz,rss,stat,start,comm", "--sort=-" + sortFlag}
-	cmd := exec.CommandContext(ctx, "ps", args...)
+	cmd := commandContext(ctx, "ps", args...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -192,7 +191,7 @@ func psTop(ctx context.Context, sortBy string, limit int) (*processResult, error
 
 	// Get system stats
 	sys := &systemStats{TotalProcs: countLines(out.String()) - 1}
-	if uptimeOut, err := exec.CommandContext(ctx, "uptime").Output(); err == nil {
+	if uptimeOut, err := commandContext(ctx, "uptime").Output(); err == nil {
 		sys.Uptime = strings.TrimSpace(string(uptimeOut))
 	}
 
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -22,11 +23,25 @@ type ToolInfo struct {
 	Description string `json:"description,omitempty"`
 }
 
+// ToolDescriptor describes a registered tool, including its input schema
+// and the bundles that reference it.
+type ToolDescriptor struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty"`
+	Bundles     []string       `json:"bundles,omitempty"`
+	Disabled    bool           `json:"disabled,omitempty"`
+}
+
+// ErrToolDisabled is returned when executing a tool disabled via Disable.
+var ErrToolDisabled = errors.New("tools: tool is disabled")
+
 var (
 	regMu         sync.RWMutex
 	toolFactories = map[string]Factory{}
 	toolDescs     = map[string]string{}
 	bundles       = map[string]Bundle{}
+	disabledTools = map[string]bool{}
 )
 
 func RegisterTool(name, description string, factory Factory) error {
@@ -140,9 +155,57 @@ func RemoveTool(name string) bool {
 	}
 	delete(toolFactories, name)
 	delete(toolDescs, name)
+	delete(disabledTools, name)
+	return true
+}
+
+// Disable hides a registered tool from the advertised catalog and from
+// BuildSelection, and makes ExecuteTool fail with ErrToolDisabled. It is
+// meant as a runtime kill switch and survives UpsertTool. Returns false if
+// the tool is not registered.
+func Disable(name string) bool {
+	name = strings.TrimSpace(name)
+	regMu.Lock()
+	defer regMu.Unlock()
+	if _, ok := toolFactories[name]; !ok {
+		return false
+	}
+	disabledTools[name] = true
+	return true
+}
+
+// Enable restores a tool hidden by Disable. Returns false if the tool was
+// not disabled.
+func Enable(name string) bool {
+	name = strings.TrimSpace(name)
+	regMu.Lock()
+	defer regMu.Unlock()
+	if !disabledTools[name] {
+		return false
+	}
+	delete(disabledTools, name)
 	return true
 }
 
+// IsDisabled reports whether name was disabled via Disable.
+func IsDisabled(name string) bool {
+	regMu.RLock()
+	defer regMu.RUnlock()
+	return disabledTools[strings.TrimSpace(name)]
+}
+
+// DisabledTools returns the sorted names of disabled tools.
+func DisabledTools() []string {
+	regMu.RLock()
+	defer regMu.RUnlock()
+	out := make([]string, 0, len(disabledTools))
+	for n := range disabledTools {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
 func BundleNames() []string {
 	regMu.RLock()
 	defer regMu.RUnlock()
@@ -159,6 +222,9 @@ func ToolCatalog() []ToolInfo {
 	defer regMu.RUnlock()
 	out := make([]ToolInfo, 0, len(toolFactories))
 	for name := range toolFactories {
+		if disabledTools[name] {
+			continue
+		}
 		out = append(out, ToolInfo{
 			Name:        name,
 			Description: toolDescs[name],
@@ -184,11 +250,14 @@ func ToolSchema(name string) (map[string]any, bool) {
 	return t.Definition().JSONSchema, true
 }
 
-// ToolSchemas returns name→JSONSchema for all registered tools.
+// ToolSchemas returns name→JSONSchema for all enabled tools.
 func ToolSchemas() map[string]map[string]any {
 	regMu.RLock()
 	names := make([]string, 0, len(toolFactories))
 	for n := range toolFactories {
+		if disabledTools[n] {
+			continue
+		}
 		names = append(names, n)
 	}
 	regMu.RUnlock()
@@ -206,6 +275,64 @@ func ToolSchemas() map[string]map[string]any {
 	return out
 }
 
+// Registry returns a snapshot of every registered tool sorted by name,
+// including disabled ones (marked Disabled). Schemas are deep copies, so
+// callers may modify the result freely.
+func Registry() []ToolDescriptor {
+	regMu.RLock()
+	out := make([]ToolDescriptor, 0, len(toolFactories))
+	factories := make(map[string]Factory, len(toolFactories))
+	memberOf := map[string][]string{}
+	for _, bundle := range bundles {
+		for _, n := range bundle.Tools {
+			memberOf[n] = append(memberOf[n], bundle.Name)
+		}
+	}
+	for name, factory := range toolFactories {
+		factories[name] = factory
+		out = append(out, ToolDescriptor{Name: name, Description: toolDescs[name], Bundles: memberOf[name], Disabled: disabledTools[name]})
+	}
+	regMu.RUnlock()
+
+	// Factories run outside the lock; some build tools lazily.
+	for i := range out {
+		sort.Strings(out[i].Bundles)
+		if t := factories[out[i].Name](); t != nil {
+			out[i].Schema = cloneSchema(t.Definition().JSONSchema)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func cloneSchema(schema map[string]any) map[string]any {
+	if schema == nil {
+		return nil
+	}
+	return cloneValue(schema).(map[string]any)
+}
+
+func cloneValue(v any) any {
+	switch tv := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(tv))
+		for k, val := range tv {
+			out[k] = cloneValue(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(tv))
+		for i, val := range tv {
+			out[i] = cloneValue(val)
+		}
+		return out
+	case []string:
+		return append([]string(nil), tv...)
+	default:
+		return v
+	}
+}
+
 func BundleCatalog() []Bundle {
 	regMu.RLock()
 	defer regMu.RUnlock()
@@ -239,6 +366,9 @@ func BuildSelection(selection []string) ([]Tool, error) {
 		if !ok {
 			return nil, fmt.Errorf("unknown tool %q", name)
 		}
+		if disabledTools[name] {
+			continue
+		}
 		tool := factory()
 		if tool == nil {
 			return nil, fmt.Errorf("tool %q factory returned nil", name)
@@ -300,10 +430,14 @@ func expandSelection(selection []string) ([]string, error) {
 func ExecuteTool(ctx context.Context, name string, input json.RawMessage) (any, error) {
 	regMu.RLock()
 	factory, ok := toolFactories[name]
+	disabled := disabledTools[name]
 	regMu.RUnlock()
 	if !ok {
 		return nil, fmt.Errorf("unknown tool %q", name)
 	}
+	if disabled {
+		return nil, fmt.Errorf("%w: %q", ErrToolDisabled, name)
+	}
 	t := factory()
 	if t == nil {
 		return nil, fmt.Errorf("tool %q factory returned nil", name)
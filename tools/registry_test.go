@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -82,3 +83,77 @@ func TestCalculatorFromRegistry_Works(t *testing.T) {
 		t.Fatalf("unexpected calculator result: %#v", m)
 	}
 }
+
+func TestRegistry_Snapshot(t *testing.T) {
+	descs := Registry()
+	if len(descs) != len(ToolNames()) {
+		t.Fatalf("Registry returned %d tools, want %d", len(descs), len(ToolNames()))
+	}
+	var calc *ToolDescriptor
+	for i := range descs {
+		if i > 0 && descs[i-1].Name >= descs[i].Name {
+			t.Fatalf("registry not sorted at %q", descs[i].Name)
+		}
+		if descs[i].Name == "calculator" {
+			calc = &descs[i]
+		}
+	}
+	if calc == nil || calc.Description == "" || calc.Schema == nil {
+		t.Fatalf("calculator descriptor = %+v", calc)
+	}
+	found := false
+	for _, b := range calc.Bundles {
+		if b == "default" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("calculator bundles = %v, want default", calc.Bundles)
+	}
+
+	calc.Schema["type"] = "mutated"
+	if schema, _ := ToolSchema("calculator"); schema["type"] == "mutated" {
+		t.Error("Registry schema aliases the tool definition")
+	}
+}
+
+func TestDisableEnable(t *testing.T) {
+	if !Disable("calculator") {
+		t.Fatal("Disable(calculator) = false")
+	}
+	defer Enable("calculator")
+
+	if Disable("no-such-tool") {
+		t.Error("Disable of unknown tool should return false")
+	}
+	for _, info := range ToolCatalog() {
+		if info.Name == "calculator" {
+			t.Fatal("disabled tool still in catalog")
+		}
+	}
+	if _, ok := ToolSchemas()["calculator"]; ok {
+		t.Error("disabled tool still in schemas")
+	}
+	selected, err := BuildSelection([]string{"calculator", "@default"})
+	if err != nil {
+		t.Fatalf("BuildSelection failed: %v", err)
+	}
+	for _, tool := range selected {
+		if tool.Definition().Name == "calculator" {
+			t.Fatal("BuildSelection returned disabled tool")
+		}
+	}
+	if _, err := ExecuteTool(context.Background(), "calculator", json.RawMessage(`{"expression":"1+1"}`)); !errors.Is(err, ErrToolDisabled) {
+		t.Errorf("ExecuteTool err = %v, want ErrToolDisabled", err)
+	}
+	if got := DisabledTools(); len(got) != 1 || got[0] != "calculator" {
+		t.Errorf("DisabledTools = %v", got)
+	}
+
+	if !Enable("calculator") || IsDisabled("calculator") {
+		t.Fatal("Enable did not restore calculator")
+	}
+	if tools, err := BuildSelection([]string{"calculator"}); err != nil || len(tools) != 1 {
+		t.Errorf("BuildSelection after enable = %d, %v", len(tools), err)
+	}
+}
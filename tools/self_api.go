@@ -50,6 +50,7 @@ func NewSelfAPI(baseURL string) Tool {
 - POST /api/v1/cron/jobs/{name}/trigger — Trigger a cron job now
 - GET  /api/v1/tools/catalog      — List available tools
 - GET  /api/v1/tools/registry     — Tool registry with schemas
+- POST /api/v1/tools/registry/{name}/disable — Disable a tool at runtime (enable restores it)
 - GET  /api/v1/tools/custom       — List runtime custom tools
 - POST /api/v1/tools/custom       — Create or upsert runtime custom HTTP tool
 - GET  /api/v1/tools/custom/{name} — Get runtime custom tool spec
@@ -159,9 +159,9 @@ func executeCommand(ctx context.Context, args shellCommandArgs, timeout int) (*S
 			shellArgs = []string{"-c", fullCommand}
 		}
 
-		cmd = exec.CommandContext(ctx, shellCmd, shellArgs...)
+		cmd = commandContext(ctx, shellCmd, shellArgs...)
 	} else {
-		cmd = exec.CommandContext(ctx, args.Command, args.Args...)
+		cmd = commandContext(ctx, args.Command, args.Args...)
 	}
 
 	if args.WorkingDir != "" {
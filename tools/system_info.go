@@ -6,8 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 )
@@ -80,7 +80,67 @@ func NewSystemInfo() Tool {
 			}
 			return executeSystemInfo(ctx, in)
 		},
-	)
+	).WithFormatter(formatSystemInfo)
+}
+
+// formatSystemInfo renders results as "key: value" lines, which the model
+// reads more easily than nested JSON with embedded command output.
+func formatSystemInfo(result any) string {
+	r, ok := result.(*systemInfoResult)
+	if !ok || r == nil {
+		return ""
+	}
+	var b strings.Builder
+	line := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", key, value)
+		}
+	}
+	block := func(title, body string) {
+		if body = strings.TrimSpace(body); body != "" {
+			fmt.Fprintf(&b, "%s:\n%s\n", title, body)
+		}
+	}
+
+	switch info := r.Info.(type) {
+	case systemSummary:
+		line("hostname", info.Hostname)
+		line("os", info.OS+"/"+info.Arch)
+		line("kernel", info.KernelInfo)
+		line("cpus", fmt.Sprint(info.NumCPU))
+		line("uptime", info.Uptime)
+		line("go", fmt.Sprintf("%s (%d goroutines)", info.GoVersion, info.NumGoroutine))
+		block("memory", info.MemInfo)
+	case cpuInfo:
+		line("cpus", fmt.Sprint(info.NumCPU))
+		line("model", info.ModelName)
+		block("details", strings.Join(info.Details, "\n"))
+	case memInfo:
+		line("total", info.Total)
+		line("used", info.Used)
+		line("free", info.Free)
+		line("available", info.Available)
+		line("swap total", info.SwapTotal)
+		line("swap used", info.SwapUsed)
+		if info.Used == "" {
+			block("raw", info.Raw)
+		}
+	case networkInfo:
+		line("hostname", info.Hostname)
+		block("addresses", strings.Join(info.Interfaces, "\n"))
+	case map[string]string:
+		keys := make([]string, 0, len(info))
+		for k := range info {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			line(k, info[k])
+		}
+	default:
+		return ""
+	}
+	return strings.TrimSpace(b.String())
 }
 
 func executeSystemInfo(ctx context.Context, in systemInfoArgs) (*systemInfoResult, error) {
@@ -222,7 +282,7 @@ func executeSystemInfo(ctx context.Context, in systemInfoArgs) (*systemInfoResul
 }
 
 func runCmd(ctx context.Context, name string, args ...string) string {
-	cmd := exec.CommandContext(ctx, name, args...)
+	cmd := commandContext(ctx, name, args...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
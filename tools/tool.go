@@ -13,9 +13,49 @@ type Tool interface {
 	Execute(ctx context.Context, args json.RawMessage) (any, error)
 }
 
+// AvailabilityChecker is implemented by tools that depend on something
+// outside the process, such as a CLI binary, and can report up front
+// whether it is present.
+type AvailabilityChecker interface {
+	Available() bool
+}
+
+// IsAvailable reports whether t can run in this environment. Tools that do
+// not implement AvailabilityChecker are assumed available.
+func IsAvailable(t Tool) bool {
+	if c, ok := t.(AvailabilityChecker); ok {
+		return c.Available()
+	}
+	return true
+}
+
+// ResultFormatter is implemented by tools whose results read better (and
+// cost fewer tokens) as text than as JSON. The agent puts the returned text
+// in the transcript; an empty string falls back to JSON.
+type ResultFormatter interface {
+	FormatResult(result any) string
+}
+
+// FormatResult renders a successful tool result for the transcript, using
+// the tool's ResultFormatter when it has one and JSON otherwise.
+func FormatResult(t Tool, result any) (string, error) {
+	if f, ok := t.(ResultFormatter); ok {
+		if text := f.FormatResult(result); text != "" {
+			return text, nil
+		}
+	}
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
 type FuncTool struct {
-	def types.ToolDefinition
-	fn  func(ctx context.Context, args json.RawMessage) (any, error)
+	def       types.ToolDefinition
+	fn        func(ctx context.Context, args json.RawMessage) (any, error)
+	available func() bool
+	format    func(result any) string
 }
 
 func NewFuncTool(name, description string, schema map[string]any, fn func(ctx context.Context, args json.RawMessage) (any, error)) *FuncTool {
@@ -33,6 +73,32 @@ func (t *FuncTool) Definition() types.ToolDefinition {
 	return t.def
 }
 
+// WithAvailability attaches a pre-flight check reported by Available.
+func (t *FuncTool) WithAvailability(check func() bool) *FuncTool {
+	t.available = check
+	return t
+}
+
+func (t *FuncTool) Available() bool {
+	if t.available == nil {
+		return true
+	}
+	return t.available()
+}
+
+// WithFormatter attaches a text renderer used by FormatResult.
+func (t *FuncTool) WithFormatter(format func(result any) string) *FuncTool {
+	t.format = format
+	return t
+}
+
+func (t *FuncTool) FormatResult(result any) string {
+	if t.format == nil {
+		return ""
+	}
+	return t.format(result)
+}
+
 func (t *FuncTool) Execute(ctx context.Context, args json.RawMessage) (any, error) {
 	if t.fn == nil {
 		return nil, fmt.Errorf("tool %q has no execute function", t.def.Name)
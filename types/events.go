@@ -17,14 +17,16 @@ const (
 )
 
 type Event struct {
-	Type       EventType `json:"type"`
-	Timestamp  time.Time `json:"timestamp"`
-	RunID      string    `json:"runId,omitempty"`
-	SessionID  string    `json:"sessionId,omitempty"`
-	Provider   string    `json:"provider,omitempty"`
-	Iteration  int       `json:"iteration,omitempty"`
-	ToolName   string    `json:"toolName,omitempty"`
-	ToolCallID string    `json:"toolCallId,omitempty"`
-	Message    string    `json:"message,omitempty"`
-	Error      string    `json:"error,omitempty"`
+	Type       EventType   `json:"type"`
+	Timestamp  time.Time   `json:"timestamp"`
+	RunID      string      `json:"runId,omitempty"`
+	SessionID  string      `json:"sessionId,omitempty"`
+	Provider   string      `json:"provider,omitempty"`
+	Model      string      `json:"model,omitempty"`
+	Iteration  int         `json:"iteration,omitempty"`
+	ToolName   string      `json:"toolName,omitempty"`
+	ToolCallID string      `json:"toolCallId,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	Retries    *RetryStats `json:"retries,omitempty"`
 }
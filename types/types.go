@@ -52,6 +52,10 @@ type Usage struct {
 type Response struct {
 	Message Message `json:"message"`
 	Usage   *Usage  `json:"usage,omitempty"`
+	// Provider and Model identify what actually served the request, which may
+	// differ from the configured defaults when routing or fallback is involved.
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
 }
 
 type StreamChunk struct {
@@ -59,16 +63,43 @@ type StreamChunk struct {
 	Done bool   `json:"done,omitempty"`
 }
 
+// RetryStats records provider retries made during a run.
+type RetryStats struct {
+	// Retries counts re-sent requests after non-rate-limit failures.
+	Retries int `json:"retries"`
+	// RateLimitRetries counts re-sent requests after rate-limit errors.
+	RateLimitRetries int `json:"rateLimitRetries"`
+	// Backoff is the total time spent waiting between attempts.
+	Backoff time.Duration `json:"backoff"`
+}
+
+// Any reports whether any retry happened.
+func (s *RetryStats) Any() bool {
+	return s != nil && (s.Retries > 0 || s.RateLimitRetries > 0)
+}
+
 type RunResult struct {
-	Output      string     `json:"output"`
-	Messages    []Message  `json:"messages,omitempty"`
-	Usage       *Usage     `json:"usage,omitempty"`
-	Iterations  int        `json:"iterations"`
-	Provider    string     `json:"provider,omitempty"`
-	RunID       string     `json:"runId,omitempty"`
-	SessionID   string     `json:"sessionId,omitempty"`
-	StartedAt   *time.Time `json:"startedAt,omitempty"`
-	CompletedAt *time.Time `json:"completedAt,omitempty"`
-	Events      []Event    `json:"events,omitempty"`
-	NodeTrace   []string   `json:"nodeTrace,omitempty"`
+	Output      string       `json:"output"`
+	Messages    []Message    `json:"messages,omitempty"`
+	Usage       *Usage       `json:"usage,omitempty"`
+	Iterations  int          `json:"iterations"`
+	Provider    string       `json:"provider,omitempty"`
+	Model       string       `json:"model,omitempty"`
+	RunID       string       `json:"runId,omitempty"`
+	SessionID   string       `json:"sessionId,omitempty"`
+	StartedAt   *time.Time   `json:"startedAt,omitempty"`
+	CompletedAt *time.Time   `json:"completedAt,omitempty"`
+	Events      []Event      `json:"events,omitempty"`
+	NodeTrace   []string     `json:"nodeTrace,omitempty"`
+	Steps       []StepResult `json:"steps,omitempty"`
+	Retries     *RetryStats  `json:"retries,omitempty"`
+}
+
+// StepResult records one executed workflow step (graph node).
+type StepResult struct {
+	Name      string        `json:"name"`
+	Output    string        `json:"output,omitempty"`
+	StartedAt time.Time     `json:"startedAt"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
 }
@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PipeOpsHQ/agent-sdk-go/graph"
+	"github.com/PipeOpsHQ/agent-sdk-go/observe"
+	"github.com/PipeOpsHQ/agent-sdk-go/state"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
+)
+
+// Executor drives any registered workflow. *graph.Executor implements it.
+type Executor interface {
+	Run(ctx context.Context, input string) (types.RunResult, error)
+	Resume(ctx context.Context, runID string) (types.RunResult, error)
+	SetObserver(observer observe.Sink)
+}
+
+var _ Executor = (*graph.Executor)(nil)
+
+// Config holds the optional dependencies for NewExecutor.
+type Config struct {
+	Store     state.Store
+	SessionID string
+	Observer  observe.Sink
+	// Params are workflow-specific settings; see Configurable.
+	Params map[string]any
+}
+
+// NewExecutor builds an executor for the workflow registered under name.
+func NewExecutor(runner graph.AgentRunner, name string, cfg Config) (Executor, error) {
+	if runner == nil {
+		return nil, fmt.Errorf("runner is required")
+	}
+	name = strings.TrimSpace(name)
+	b, ok := Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown workflow %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	b, err := Configure(b, cfg.Params)
+	if err != nil {
+		return nil, err
+	}
+	exec, err := b.NewExecutor(runner, cfg.Store, cfg.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow %q: %w", name, err)
+	}
+	if cfg.Observer != nil {
+		exec.SetObserver(cfg.Observer)
+	}
+	return exec, nil
+}
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"sync"
@@ -60,3 +62,43 @@ func Names() []string {
 	sort.Strings(out)
 	return out
 }
+
+// Configurable is implemented by builders whose workflows take parameters
+// (routes, strategies). Configure validates params and returns a builder
+// with them applied; the registered builder is left unchanged.
+type Configurable interface {
+	Builder
+	Configure(params map[string]any) (Builder, error)
+}
+
+// Configure applies params to b. Empty params return b as is; non-empty
+// params are an error for builders that are not Configurable.
+func Configure(b Builder, params map[string]any) (Builder, error) {
+	if len(params) == 0 {
+		return b, nil
+	}
+	c, ok := b.(Configurable)
+	if !ok {
+		return nil, fmt.Errorf("workflow %q does not accept params", b.Name())
+	}
+	configured, err := c.Configure(params)
+	if err != nil {
+		return nil, fmt.Errorf("workflow %q: invalid params: %w", b.Name(), err)
+	}
+	return configured, nil
+}
+
+// DecodeParams decodes params into the struct pointed to by dst via JSON,
+// rejecting unknown keys.
+func DecodeParams(params map[string]any, dst any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("encode params: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("decode params: %w", err)
+	}
+	return nil
+}
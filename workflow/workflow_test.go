@@ -1,15 +1,24 @@
 package workflow_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/basic"
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/chain"
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/mapreduce"
 	_ "github.com/PipeOpsHQ/agent-sdk-go/graphs/router"
+	"github.com/PipeOpsHQ/agent-sdk-go/types"
 	"github.com/PipeOpsHQ/agent-sdk-go/workflow"
 )
 
+type echoRunner struct{}
+
+func (echoRunner) RunDetailed(_ context.Context, input string) (types.RunResult, error) {
+	return types.RunResult{Output: "echo:" + input}, nil
+}
+
 func TestBuiltInWorkflowsRegistered(t *testing.T) {
 	names := workflow.Names()
 	if len(names) < 4 {
@@ -22,3 +31,50 @@ func TestBuiltInWorkflowsRegistered(t *testing.T) {
 		}
 	}
 }
+
+func TestNewExecutor(t *testing.T) {
+	exec, err := workflow.NewExecutor(echoRunner{}, "basic", workflow.Config{})
+	if err != nil {
+		t.Fatalf("NewExecutor failed: %v", err)
+	}
+	out, err := exec.Run(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if out.Output == "" {
+		t.Fatal("expected output from basic workflow")
+	}
+
+	if _, err := workflow.NewExecutor(echoRunner{}, "missing", workflow.Config{}); err == nil || !strings.Contains(err.Error(), "available:") {
+		t.Fatalf("expected unknown workflow error listing names, got %v", err)
+	}
+}
+
+func TestNewExecutorParams(t *testing.T) {
+	params := map[string]any{"routes": []any{
+		map[string]any{"name": "billing", "prompt": "You handle billing."},
+		map[string]any{"name": "other", "prompt": "You handle everything else."},
+	}}
+	if _, err := workflow.NewExecutor(echoRunner{}, "router", workflow.Config{Params: params}); err != nil {
+		t.Fatalf("router with routes failed: %v", err)
+	}
+	if _, err := workflow.NewExecutor(echoRunner{}, "map-reduce", workflow.Config{Params: map[string]any{"strategy": "concat"}}); err != nil {
+		t.Fatalf("map-reduce with strategy failed: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		workflow string
+		params   map[string]any
+		want     string
+	}{
+		"unknown key":      {"map-reduce", map[string]any{"strategi": "concat"}, "unknown field"},
+		"bad strategy":     {"map-reduce", map[string]any{"strategy": "vote"}, "unknown reduce strategy"},
+		"bad default":      {"router", map[string]any{"default": "missing"}, "not a configured route"},
+		"not configurable": {"basic", map[string]any{"x": 1}, "does not accept params"},
+	} {
+		_, err := workflow.NewExecutor(echoRunner{}, tc.workflow, workflow.Config{Params: tc.params})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", name, tc.want, err)
+		}
+	}
+}